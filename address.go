@@ -0,0 +1,175 @@
+package mp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AddressOptions configures the Type returned by AddressTypeWithOptions.
+type AddressOptions struct {
+	// Countries are the allowed ISO 3166-1 alpha-2 country codes. If Countries is empty then any two letter code is
+	// allowed.
+	Countries []string
+
+	// DefaultCountry is used when the country is missing.
+	DefaultCountry string
+
+	// PostalCodePatterns maps country codes to the pattern that postal codes of the country must match. Postal codes
+	// are upper-cased before matching. Patterns are added to the built-in patterns and replace them for the same
+	// country. A postal code is required for a country with a pattern.
+	PostalCodePatterns map[string]*regexp.Regexp
+
+	// Regions maps country codes to their allowed region codes such as the states of the US. Regions are added to the
+	// built-in regions and replace them for the same country. A region is required for a country with regions.
+	Regions map[string][]string
+
+	// MaxLineLen is the maximum length of line1, line2, and city. If MaxLineLen is 0 then 100 is used.
+	MaxLineLen int
+}
+
+// AddressType returns a Type for postal addresses with the built-in postal code patterns and regions. See
+// AddressTypeWithOptions.
+func AddressType() *Type {
+	return AddressTypeWithOptions(AddressOptions{})
+}
+
+// AddressTypeWithOptions returns a Type for postal addresses. It is intended to be nested in other Types and to serve
+// as a reference for composite presets. It has the following fields:
+//
+//   - line1: the first line of the street address. It is required.
+//   - line2: the optional second line of the street address.
+//   - city: the city. It is required.
+//   - region: the state, province, or other region. For a country with regions it must be one of them. It is
+//     upper-cased.
+//   - postal_code: the postal code. For a country with a postal code pattern it must match the pattern. It is
+//     upper-cased.
+//   - country: the ISO 3166-1 alpha-2 country code. It is required and upper-cased.
+//
+// Built-in postal code patterns exist for AU, BR, CA, CH, DE, ES, FR, GB, IN, IT, JP, MX, NL, SE, and US. Built-in
+// regions exist for AU, CA, and US.
+func AddressTypeWithOptions(options AddressOptions) *Type {
+	maxLineLen := options.MaxLineLen
+	if maxLineLen == 0 {
+		maxLineLen = 100
+	}
+
+	postalCodePatterns := make(map[string]*regexp.Regexp, len(defaultPostalCodePatterns)+len(options.PostalCodePatterns))
+	for country, re := range defaultPostalCodePatterns {
+		postalCodePatterns[country] = re
+	}
+	for country, re := range options.PostalCodePatterns {
+		postalCodePatterns[strings.ToUpper(country)] = re
+	}
+
+	regions := make(map[string]stringSet, len(defaultRegions)+len(options.Regions))
+	for country, codes := range defaultRegions {
+		regions[country] = newStringSet(codes)
+	}
+	for country, codes := range options.Regions {
+		regions[strings.ToUpper(country)] = newStringSet(codes)
+	}
+
+	countryConverters := []ValueConverter{SingleLineString(), NilifyEmpty()}
+	if options.DefaultCountry != "" {
+		countryConverters = append(countryConverters, Default(options.DefaultCountry))
+	}
+	countryConverters = append(countryConverters, Require(), upperCase)
+	if len(options.Countries) > 0 {
+		countryConverters = append(countryConverters, AllowStrings(upperCaseAll(options.Countries)...))
+	} else {
+		countryConverters = append(countryConverters, Matches(countryCodeRegexp))
+	}
+
+	return NewType(
+		NewField("line1", SingleLineString(), Require(), MaxLen(maxLineLen)),
+		NewField("line2", SingleLineString(), NilifyEmpty(), MaxLen(maxLineLen)),
+		NewField("city", SingleLineString(), Require(), MaxLen(maxLineLen)),
+		NewField("region", SingleLineString(), NilifyEmpty(), upperCase, addressRegion(regions)),
+		NewField("postal_code", SingleLineString(), NilifyEmpty(), upperCase, addressPostalCode(postalCodePatterns)),
+		NewField("country", countryConverters...),
+	)
+}
+
+// addressRegion returns a converter that validates a region against the regions of the country of the address.
+func addressRegion(regions map[string]stringSet) ValueConverter {
+	return DependsOn([]string{"country"}, func(value any, deps map[string]any) (any, error) {
+		country, _ := deps["country"].(string)
+		allowed, ok := regions[country]
+		if !ok {
+			return value, nil
+		}
+		if value == nil {
+			return nil, errCannotBeNilOrEmpty
+		}
+		if _, ok := allowed.set[value.(string)]; !ok {
+			return nil, newKindError(ErrNotAllowed, fmt.Sprintf("not a valid region for %s", country))
+		}
+		return value, nil
+	})
+}
+
+// addressPostalCode returns a converter that validates a postal code against the pattern of the country of the
+// address.
+func addressPostalCode(patterns map[string]*regexp.Regexp) ValueConverter {
+	return DependsOn([]string{"country"}, func(value any, deps map[string]any) (any, error) {
+		country, _ := deps["country"].(string)
+		re, ok := patterns[country]
+		if !ok {
+			return value, nil
+		}
+		if value == nil {
+			return nil, errCannotBeNilOrEmpty
+		}
+		if !re.MatchString(value.(string)) {
+			return nil, newKindError(ErrInvalidFormat, fmt.Sprintf("not a valid postal code for %s", country))
+		}
+		return value, nil
+	})
+}
+
+var countryCodeRegexp = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// upperCase converts string values to upper case.
+var upperCase = ValueConverterFunc(func(value any) (any, error) {
+	if s, ok := value.(string); ok {
+		return strings.ToUpper(s), nil
+	}
+	return value, nil
+})
+
+func upperCaseAll(items []string) []string {
+	upper := make([]string, len(items))
+	for i, s := range items {
+		upper[i] = strings.ToUpper(s)
+	}
+	return upper
+}
+
+var defaultPostalCodePatterns = map[string]*regexp.Regexp{
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"BR": regexp.MustCompile(`^\d{5}-?\d{3}$`),
+	"CA": regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[A-Z] ?\d[A-Z]\d$`),
+	"CH": regexp.MustCompile(`^\d{4}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+	"IN": regexp.MustCompile(`^\d{6}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"MX": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+}
+
+var defaultRegions = map[string][]string{
+	"AU": {"ACT", "NSW", "NT", "QLD", "SA", "TAS", "VIC", "WA"},
+	"CA": {"AB", "BC", "MB", "NB", "NL", "NS", "NT", "NU", "ON", "PE", "QC", "SK", "YT"},
+	"US": {
+		"AK", "AL", "AR", "AS", "AZ", "CA", "CO", "CT", "DC", "DE", "FL", "GA", "GU", "HI", "IA", "ID", "IL", "IN", "KS",
+		"KY", "LA", "MA", "MD", "ME", "MI", "MN", "MO", "MP", "MS", "MT", "NC", "ND", "NE", "NH", "NJ", "NM", "NV", "NY",
+		"OH", "OK", "OR", "PA", "PR", "RI", "SC", "SD", "TN", "TX", "UM", "UT", "VA", "VI", "VT", "WA", "WI", "WV", "WY",
+	},
+}
@@ -0,0 +1,106 @@
+package mp_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressType(t *testing.T) {
+	tests := []struct {
+		attrs    map[string]any
+		expected map[string]any
+		errs     map[string]string
+	}{
+		{
+			attrs: map[string]any{"line1": " 1 Main St ", "city": "Springfield", "region": "il", "postal_code": "62701", "country": "us"},
+			expected: map[string]any{
+				"line1": "1 Main St", "line2": nil, "city": "Springfield", "region": "IL", "postal_code": "62701", "country": "US",
+			},
+		},
+		{
+			attrs: map[string]any{"line1": "10 Downing St", "line2": "", "city": "London", "postal_code": "sw1a 2aa", "country": "GB"},
+			expected: map[string]any{
+				"line1": "10 Downing St", "line2": nil, "city": "London", "region": nil, "postal_code": "SW1A 2AA", "country": "GB",
+			},
+		},
+		{
+			attrs: map[string]any{"line1": "Calle 1", "city": "Lima", "region": "Lima", "country": "PE"},
+			expected: map[string]any{
+				"line1": "Calle 1", "line2": nil, "city": "Lima", "region": "LIMA", "postal_code": nil, "country": "PE",
+			},
+		},
+		{
+			attrs: map[string]any{"line1": "1 Main St", "city": "Springfield", "region": "XX", "postal_code": "6270", "country": "US"},
+			errs:  map[string]string{"postal_code": "not a valid postal code for US", "region": "not a valid region for US"},
+		},
+		{
+			attrs: map[string]any{"line1": "1 Main St", "city": "Toronto", "country": "CA"},
+			errs:  map[string]string{"postal_code": "cannot be nil or empty", "region": "cannot be nil or empty"},
+		},
+		{
+			attrs: map[string]any{"line1": "1 Main St", "city": "Nowhere", "region": "XX", "country": "USA"},
+			errs:  map[string]string{"country": "invalid format"},
+		},
+		{
+			attrs: map[string]any{},
+			errs:  map[string]string{"city": "cannot be nil or empty", "country": "cannot be nil or empty", "line1": "cannot be nil or empty"},
+		},
+	}
+
+	at := mp.AddressType()
+	for i, tt := range tests {
+		record := at.Parse(tt.attrs)
+		if tt.errs != nil {
+			assert.Equalf(t, tt.errs, errorMessages(record.Errors()), "%d", i)
+			continue
+		}
+		require.NoErrorf(t, record.Errors(), "%d", i)
+		assert.Equalf(t, tt.expected, record.Attrs(), "%d", i)
+	}
+}
+
+func TestAddressTypeWithOptions(t *testing.T) {
+	at := mp.AddressTypeWithOptions(mp.AddressOptions{
+		Countries:          []string{"us", "IE"},
+		DefaultCountry:     "US",
+		PostalCodePatterns: map[string]*regexp.Regexp{"ie": regexp.MustCompile(`^[A-Z]\d{2} ?[A-Z\d]{4}$`)},
+		Regions:            map[string][]string{"IE": {"D", "C"}},
+		MaxLineLen:         10,
+	})
+
+	record := at.Parse(map[string]any{"line1": "1 Main St", "city": "Dublin", "region": "d", "postal_code": "d02 x285", "country": "ie"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "D02 X285", record.Get("postal_code"))
+
+	record = at.Parse(map[string]any{"line1": "1 Main St", "city": "Austin", "region": "TX", "postal_code": "78701"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "US", record.Get("country"))
+
+	record = at.Parse(map[string]any{"line1": "1 Main Street", "city": "Berlin", "postal_code": "10115", "country": "DE"})
+	assert.Equal(t, map[string]string{"country": "not allowed value", "line1": "too long"}, errorMessages(record.Errors()))
+
+	ot := mp.NewType(
+		mp.NewField("name", mp.PersonName()),
+		mp.NewField("shipping_address", at),
+	)
+	record = ot.Parse(map[string]any{
+		"name":             "jack",
+		"shipping_address": map[string]any{"line1": "1 Main St", "city": "Cork", "region": "C", "postal_code": "T12", "country": "IE"},
+	})
+	fieldErrors := mp.FlattenErrors(record.Errors())
+	require.Len(t, fieldErrors, 1)
+	assert.Equal(t, "/shipping_address/postal_code", fieldErrors[0].Pointer())
+	assert.Equal(t, "invalid_format", fieldErrors[0].Code())
+}
+
+func errorMessages(err error) map[string]string {
+	messages := make(map[string]string)
+	for name, fieldErr := range err.(mp.Errors) {
+		messages[name] = fieldErr.Error()
+	}
+	return messages
+}
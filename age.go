@@ -0,0 +1,166 @@
+package mp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var errInFuture = newKindError(ErrOutOfRange, "cannot be in the future")
+
+// Birthdate returns a ValueConverter that converts value to a time.Time and requires the age at that birthdate to be
+// between minAge and maxAge years inclusive. value may be a time.Time or a string in the format "2006-01-02". If maxAge
+// is 0 there is no maximum age. The current date is taken from clock. If clock is nil the Clock of the context passed
+// to ParseCtx or DefaultClock is used. If value is nil or a blank string nil is returned.
+func Birthdate(minAge, maxAge int, clock Clock) ValueConverter {
+	return &birthdateValueConverter{minAge: minAge, maxAge: maxAge, clock: clock}
+}
+
+type birthdateValueConverter struct {
+	minAge int
+	maxAge int
+	clock  Clock
+}
+
+func (c *birthdateValueConverter) ConvertValue(value any) (any, error) {
+	return c.ConvertValueContext(context.Background(), value)
+}
+
+func (c *birthdateValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	var birthdate time.Time
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		birthdate = value
+	case string:
+		var err error
+		birthdate, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, newKindError(ErrInvalidFormat, "not a valid date")
+		}
+	default:
+		return nil, newKindError(ErrInvalidFormat, "not a valid date")
+	}
+
+	now := currentTime(ctx, c.clock)
+	if birthdate.After(now) {
+		return nil, errInFuture
+	}
+
+	age := AgeAt(birthdate, now)
+	if age < c.minAge {
+		return nil, newKindError(ErrTooSmall, fmt.Sprintf("must be at least %d years old", c.minAge))
+	}
+	if c.maxAge > 0 && age > c.maxAge {
+		return nil, newKindError(ErrTooLarge, fmt.Sprintf("must be at most %d years old", c.maxAge))
+	}
+
+	return birthdate, nil
+}
+
+func (c *birthdateValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf(time.Time{})
+}
+
+func (c *birthdateValueConverter) ConverterParams() map[string]any {
+	params := map[string]any{"minAge": c.minAge}
+	if c.maxAge > 0 {
+		params["maxAge"] = c.maxAge
+	}
+	return params
+}
+
+// AgeAt returns the age in whole years at t of someone born on birthdate. Someone born on February 29 turns a year
+// older on March 1 in common years.
+func AgeAt(birthdate, t time.Time) int {
+	age := t.Year() - birthdate.Year()
+	if t.Month() < birthdate.Month() || t.Month() == birthdate.Month() && t.Day() < birthdate.Day() {
+		age--
+	}
+	return age
+}
+
+// NotInFuture returns a ValueConverter that returns an error if value is a time.Time after the current time. The
+// current time is taken from the Clock of the context passed to ParseCtx or DefaultClock. If value is nil then nil is
+// returned.
+func NotInFuture() ValueConverter {
+	return notInFutureValueConverter{}
+}
+
+type notInFutureValueConverter struct{}
+
+func (c notInFutureValueConverter) ConvertValue(value any) (any, error) {
+	return c.ConvertValueContext(context.Background(), value)
+}
+
+func (c notInFutureValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		if v.After(ClockFromContext(ctx).Now()) {
+			return nil, errInFuture
+		}
+		return v, nil
+	}
+
+	return nil, errors.New("not a time")
+}
+
+// OlderThan returns a ValueConverter that returns an error unless value is a time.Time at least d before the current
+// time. The current time is taken from the Clock of the context passed to ParseCtx or DefaultClock. If value is nil then
+// nil is returned.
+func OlderThan(d time.Duration) ValueConverter {
+	return olderThanValueConverter{d: d}
+}
+
+type olderThanValueConverter struct {
+	d time.Duration
+}
+
+func (c olderThanValueConverter) ConvertValue(value any) (any, error) {
+	return c.ConvertValueContext(context.Background(), value)
+}
+
+func (c olderThanValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		if v.After(ClockFromContext(ctx).Now().Add(-c.d)) {
+			return nil, newKindError(ErrOutOfRange, fmt.Sprintf("must be older than %v", c.d))
+		}
+		return v, nil
+	}
+
+	return nil, errors.New("not a time")
+}
+
+// NotBefore returns a ValueConverter that returns an error if value is a time.Time before t. If value is nil then nil
+// is returned.
+func NotBefore(t time.Time) ValueConverter {
+	return notBeforeValueConverter{t: t}
+}
+
+type notBeforeValueConverter struct {
+	t time.Time
+}
+
+func (c notBeforeValueConverter) ConvertValue(value any) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		if v.Before(c.t) {
+			return nil, newKindError(ErrOutOfRange, fmt.Sprintf("cannot be before %s", c.t.Format(time.RFC3339)))
+		}
+		return v, nil
+	}
+
+	return nil, errors.New("not a time")
+}
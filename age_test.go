@@ -0,0 +1,119 @@
+package mp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBirthdate(t *testing.T) {
+	clock := mp.FixedClock(time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{"2000-01-01", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"2006-03-10", time.Date(2006, 3, 10, 0, 0, 0, 0, time.UTC), ""},
+		{"2006-03-11", nil, "must be at least 18 years old"},
+		{"1904-03-11", time.Date(1904, 3, 11, 0, 0, 0, 0, time.UTC), ""},
+		{"1904-03-10", nil, "must be at most 119 years old"},
+		{"2025-01-01", nil, "cannot be in the future"},
+		{time.Date(1990, 5, 5, 0, 0, 0, 0, time.UTC), time.Date(1990, 5, 5, 0, 0, 0, 0, time.UTC), ""},
+		{"05/05/1990", nil, "not a valid date"},
+		{42, nil, "not a valid date"},
+		{"", nil, ""},
+		{nil, nil, ""},
+	}
+
+	vc := mp.Birthdate(18, 119, clock)
+	for i, tt := range tests {
+		value, err := vc.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+
+	_, err := vc.ConvertValue("2010-01-01")
+	assert.ErrorIs(t, err, mp.ErrTooSmall)
+
+	value, err := mp.Birthdate(0, 0, nil).ConvertValue("1850-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(1850, 1, 1, 0, 0, 0, 0, time.UTC), value)
+}
+
+func TestAgeAt(t *testing.T) {
+	leapDay := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		birthdate time.Time
+		t         time.Time
+		expected  int
+	}{
+		{leapDay, time.Date(2001, 2, 28, 0, 0, 0, 0, time.UTC), 0},
+		{leapDay, time.Date(2001, 3, 1, 0, 0, 0, 0, time.UTC), 1},
+		{leapDay, time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC), 4},
+		{time.Date(1990, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), 33},
+		{time.Date(1990, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 34},
+	}
+
+	for i, tt := range tests {
+		assert.Equalf(t, tt.expected, mp.AgeAt(tt.birthdate, tt.t), "%d", i)
+	}
+}
+
+func TestNotInFuture(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	value, err := mp.NotInFuture().ConvertValue(past)
+	require.NoError(t, err)
+	assert.Equal(t, past, value)
+
+	_, err = mp.NotInFuture().ConvertValue(time.Now().Add(time.Hour))
+	assert.EqualError(t, err, "cannot be in the future")
+	assert.ErrorIs(t, err, mp.ErrOutOfRange)
+
+	value, err = mp.NotInFuture().ConvertValue(nil)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, err = mp.NotInFuture().ConvertValue("2024-01-01")
+	assert.EqualError(t, err, "not a time")
+}
+
+func TestNotBefore(t *testing.T) {
+	min := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	value, err := mp.NotBefore(min).ConvertValue(min)
+	require.NoError(t, err)
+	assert.Equal(t, min, value)
+
+	_, err = mp.NotBefore(min).ConvertValue(min.Add(-time.Second))
+	assert.EqualError(t, err, "cannot be before 2024-01-01T00:00:00Z")
+	assert.ErrorIs(t, err, mp.ErrOutOfRange)
+
+	value, err = mp.NotBefore(min).ConvertValue(nil)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestBirthdateInTypeWithTime(t *testing.T) {
+	tp := mp.NewType(
+		mp.NewField("birthdate", mp.Birthdate(13, 0, mp.FixedClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))),
+		mp.NewField("joined_at", mp.Time(time.RFC3339), mp.NotBefore(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), mp.NotInFuture()),
+	)
+
+	record := tp.Parse(map[string]any{"birthdate": "2000-01-01", "joined_at": "2021-06-01T00:00:00Z"})
+	require.NoError(t, record.Errors())
+
+	record = tp.Parse(map[string]any{"birthdate": "2020-01-01", "joined_at": "2019-06-01T00:00:00Z"})
+	msgs := errorMessages(record.Errors())
+	assert.Equal(t, "must be at least 13 years old", msgs["birthdate"])
+	assert.Equal(t, "cannot be before 2020-01-01T00:00:00Z", msgs["joined_at"])
+}
@@ -0,0 +1,117 @@
+package mp
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// AllOptions configures the converter returned by AllWithOptions.
+type AllOptions struct {
+	// CollectErrors runs every converter even after one fails and returns all the errors. A converter that fails does
+	// not change the value passed to the next converter.
+	CollectErrors bool
+}
+
+// All returns a ValueConverter that applies converters in order and stops at the first error. It allows a common group
+// of converters to be defined once and reused across fields. The converters are visible to introspection such as
+// FieldIsRequired and FieldConverterParams as if they were listed directly on the field.
+func All(converters ...ValueConverter) ValueConverter {
+	return AllWithOptions(AllOptions{}, converters...)
+}
+
+// AllWithOptions is like All but with options.
+func AllWithOptions(options AllOptions, converters ...ValueConverter) ValueConverter {
+	return &allValueConverter{options: options, valueConverters: converters}
+}
+
+type allValueConverter struct {
+	options         AllOptions
+	valueConverters []ValueConverter
+}
+
+func (c *allValueConverter) ConvertValue(value any) (any, error) {
+	return c.convert(value, func(vc ValueConverter, v any) (any, error) {
+		return vc.ConvertValue(v)
+	})
+}
+
+// ConvertValueContext implements the ContextValueConverter interface. It passes ctx to the grouped converters. Like
+// Optional and IfNotNil, grouped converters that depend on other fields are skipped because their values are not
+// available.
+func (c *allValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	return c.convert(value, func(vc ValueConverter, v any) (any, error) {
+		return convertSliceWithoutDependencies(ctx, v, []ValueConverter{vc})
+	})
+}
+
+// convertWithDependencies is used instead of ConvertValue when All is a converter of a field. It passes deps and ctx to
+// the grouped converters.
+func (c *allValueConverter) convertWithDependencies(ctx context.Context, value any, deps map[string]any) (any, error) {
+	return c.convert(value, func(vc ValueConverter, v any) (any, error) {
+		return convertSliceWithDependencies(ctx, v, []ValueConverter{vc}, deps)
+	})
+}
+
+func (c *allValueConverter) convert(value any, apply func(vc ValueConverter, v any) (any, error)) (any, error) {
+	var errs allErrors
+	for _, vc := range c.valueConverters {
+		v, err := apply(vc, value)
+		if err != nil {
+			if !c.options.CollectErrors {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		value = v
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return value, nil
+}
+
+func (c *allValueConverter) ConvertedType() reflect.Type {
+	return lastConvertedType(c.valueConverters)
+}
+
+func (c *allValueConverter) converters() []ValueConverter {
+	return c.valueConverters
+}
+
+type allErrors []error
+
+func (e allErrors) Error() string {
+	sb := &strings.Builder{}
+	for i, err := range e {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+func (e allErrors) Unwrap() []error {
+	return e
+}
+
+// converterGroup is implemented by ValueConverters that are only a grouping of other converters. Introspection looks
+// through a converterGroup to the converters it contains.
+type converterGroup interface {
+	converters() []ValueConverter
+}
+
+func appendFlattenedConverters(dst []ValueConverter, converters []ValueConverter) []ValueConverter {
+	for _, vc := range converters {
+		if g, ok := vc.(converterGroup); ok {
+			dst = appendFlattenedConverters(dst, g.converters())
+		} else {
+			dst = append(dst, vc)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,56 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	name := mp.All(mp.SingleLineString(), mp.Require(), mp.MaxLen(5))
+	ft := mp.NewType(
+		mp.NewField("first", name),
+		mp.NewField("last", name),
+	)
+
+	record := ft.Parse(map[string]any{"first": " Adam ", "last": "Smith"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Adam", record.Get("first"))
+	assert.Equal(t, "Smith", record.Get("last"))
+
+	record = ft.Parse(map[string]any{"first": "Adam", "last": "Smithson"})
+	assert.EqualError(t, record.Errors(), "last too long")
+
+	assert.True(t, mp.FieldIsRequired(ft.Fields()[0]))
+	assert.Equal(t, map[string]any{"maxLen": 5}, mp.FieldConverterParams(ft.Fields()[0]))
+}
+
+func TestAllCollectErrors(t *testing.T) {
+	vc := mp.AllWithOptions(mp.AllOptions{CollectErrors: true}, mp.Int64(), mp.GreaterThan(10), mp.LessThan(5))
+
+	value, err := vc.ConvertValue("7")
+	assert.Nil(t, value)
+	assert.EqualError(t, err, "too small, too large")
+
+	value, err = vc.ConvertValue("abc")
+	assert.Nil(t, value)
+	assert.Error(t, err)
+}
+
+func TestAllWithDependencies(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("min", mp.Int64()),
+		mp.NewField("max", mp.All(mp.Int64(), mp.DependsOn([]string{"min"}, func(value any, deps map[string]any) (any, error) {
+			return mp.GreaterThanOrEqual(deps["min"]).ConvertValue(value)
+		}))),
+	)
+
+	record := ft.Parse(map[string]any{"min": "3", "max": "5"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, int64(5), record.Get("max"))
+
+	record = ft.Parse(map[string]any{"min": "3", "max": "1"})
+	assert.Error(t, record.Errors())
+}
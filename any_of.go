@@ -0,0 +1,91 @@
+package mp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Alternative is a named chain of converters used by AnyOf.
+type Alternative struct {
+	name       string
+	converters []ValueConverter
+}
+
+// NewAlternative returns an alternative named name that applies converters in order.
+func NewAlternative(name string, converters ...ValueConverter) *Alternative {
+	return &Alternative{name: name, converters: converters}
+}
+
+// Name returns the name of the alternative.
+func (a *Alternative) Name() string {
+	return a.name
+}
+
+// Match is the result of AnyOf. It records which alternative succeeded.
+type Match struct {
+	// Alternative is the name of the alternative that matched.
+	Alternative string
+
+	// Value is the value converted by the matching alternative.
+	Value any
+}
+
+// AnyOf returns a ValueConverter that tries alternatives in order and returns a Match for the first one that succeeds.
+// It is useful for fields that accept more than one kind of value such as a contact that is either an email or a
+// phone number. If value is nil then nil is returned.
+func AnyOf(alternatives ...*Alternative) ValueConverter {
+	if len(alternatives) == 0 {
+		panic("AnyOf requires at least one alternative")
+	}
+	return &anyOfValueConverter{alternatives: alternatives}
+}
+
+type anyOfValueConverter struct {
+	alternatives []*Alternative
+}
+
+func (c *anyOfValueConverter) ConvertValue(value any) (any, error) {
+	return c.convert(value, convertSlice)
+}
+
+// ConvertValueContext implements the ContextValueConverter interface. It passes ctx to the converters of each
+// alternative.
+func (c *anyOfValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	return c.convert(value, func(v any, converters []ValueConverter) (any, error) {
+		return convertSliceContext(ctx, v, converters)
+	})
+}
+
+func (c *anyOfValueConverter) convert(value any, apply func(v any, converters []ValueConverter) (any, error)) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	for _, a := range c.alternatives {
+		v, err := apply(value, a.converters)
+		if err == nil {
+			return Match{Alternative: a.name, Value: v}, nil
+		}
+	}
+
+	names := make([]string, len(c.alternatives))
+	for i, a := range c.alternatives {
+		names[i] = a.name
+	}
+
+	return nil, fmt.Errorf("not a valid %s", strings.Join(names, " or "))
+}
+
+func (c *anyOfValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf(Match{})
+}
+
+func (c *anyOfValueConverter) ConverterParams() map[string]any {
+	names := make([]string, len(c.alternatives))
+	for i, a := range c.alternatives {
+		names[i] = a.name
+	}
+	return map[string]any{"anyOf": names}
+}
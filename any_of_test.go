@@ -0,0 +1,39 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnyOf(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("id", mp.AnyOf(
+			mp.NewAlternative("uuid", mp.UUID()),
+			mp.NewAlternative("number", mp.Int64(), mp.GreaterThan(0)),
+		), mp.Require()),
+	)
+
+	tests := []struct {
+		value    any
+		expected mp.Match
+	}{
+		{"e3c2a5f2-3b5c-4f0b-9f5b-1c1f8c0a4b6d", mp.Match{Alternative: "uuid", Value: uuid.Must(uuid.FromString("e3c2a5f2-3b5c-4f0b-9f5b-1c1f8c0a4b6d"))}},
+		{"42", mp.Match{Alternative: "number", Value: int64(42)}},
+	}
+
+	for i, tt := range tests {
+		record := ft.Parse(map[string]any{"id": tt.value})
+		require.NoErrorf(t, record.Errors(), "%d", i)
+		assert.Equalf(t, tt.expected, record.Get("id"), "%d", i)
+	}
+
+	record := ft.Parse(map[string]any{"id": "-1"})
+	assert.EqualError(t, record.Errors(), "id not a valid uuid or number")
+
+	record = ft.Parse(map[string]any{"id": nil})
+	assert.EqualError(t, record.Errors(), "id cannot be nil or empty")
+}
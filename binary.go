@@ -0,0 +1,181 @@
+package mp
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	// Register the types produced by the built-in converters so they can be encoded in a map[string]any. Values of
+	// other types must be registered with gob.Register by the application.
+	for _, v := range []any{
+		time.Time{}, decimal.Decimal{}, uuid.UUID{}, json.Number(""), map[string]any{}, []any{},
+		[]string{}, []int64{}, []int32{}, []float64{}, []float32{}, []bool{}, []byte{},
+		[]time.Time{}, []decimal.Decimal{}, []uuid.UUID{}, []SortTerm{}, Content{}, Match{},
+	} {
+		gob.Register(v)
+	}
+}
+
+// encodedRecord is the gob representation of a Record. Nested Records are represented by their attrs.
+type encodedRecord struct {
+	Attrs   map[string]any
+	Partial bool
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It encodes the converted values of r with encoding/gob so r can
+// be cached or sent to another process and restored with Type.UnmarshalRecord without parsing the original input
+// again. Values of types other than those produced by the built-in converters must be registered with gob.Register.
+// Values collected by Rest and Pattern fields are encoded under their input keys. The original input, errors, and
+// warnings are not encoded. Records with errors cannot be encoded.
+func (r *Record) MarshalBinary() ([]byte, error) {
+	if r.Errors() != nil {
+		return nil, errors.New("cannot encode record with errors")
+	}
+
+	buf := &bytes.Buffer{}
+	err := gob.NewEncoder(buf).Encode(encodedRecord{Attrs: encodableAttrs(r), Partial: r.partial})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodableAttrs(r *Record) map[string]any {
+	attrs := r.Attrs()
+	for _, values := range r.patternValues {
+		for k, v := range values {
+			attrs[k] = v
+		}
+	}
+	for k, v := range r.rest {
+		attrs[k] = v
+	}
+	for k, v := range attrs {
+		switch v := v.(type) {
+		case *Record:
+			attrs[k] = encodableAttrs(v)
+		case []*Record:
+			elements := make([]any, len(v))
+			for i, record := range v {
+				if record != nil {
+					elements[i] = encodableAttrs(record)
+				}
+			}
+			attrs[k] = elements
+		}
+	}
+	return attrs
+}
+
+// UnmarshalRecord decodes a Record of type t from data produced by Record.MarshalBinary. The converters of t are not
+// applied. The caller is responsible for decoding with the same Type, or a compatible one, that the Record was
+// encoded with. The returned Record's original input is its decoded attrs.
+func (t *Type) UnmarshalRecord(data []byte) (*Record, error) {
+	var er encodedRecord
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&er)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := t.recordFromAttrs(er.Attrs)
+	if err != nil {
+		return nil, err
+	}
+	r.partial = er.Partial
+	return r, nil
+}
+
+func (t *Type) recordFromAttrs(attrs map[string]any) (*Record, error) {
+	r := &Record{
+		t:        t,
+		original: attrs,
+		values:   make([]fieldValue, len(t.fields)),
+	}
+	if t.rest != nil {
+		r.rest = make(map[string]any)
+	}
+	if len(t.patterns) > 0 {
+		r.patternValues = make([]map[string]any, len(t.patterns))
+		for i := range r.patternValues {
+			r.patternValues[i] = make(map[string]any)
+		}
+	}
+
+	for name, value := range attrs {
+		if idx, ok := t.fieldIndexes[name]; ok {
+			value, err := decodedFieldValue(describeField(t.fields[idx]).typer, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			r.values[idx] = fieldValue{value: value, set: true}
+			continue
+		}
+
+		var converters []ValueConverter
+		var values map[string]any
+		if idx := t.matchPattern(name); idx >= 0 {
+			converters, values = t.patterns[idx].valueConverters, r.patternValues[idx]
+		} else if t.rest != nil {
+			converters, values = t.rest.valueConverters, r.rest
+		} else {
+			return nil, fmt.Errorf("%q is not a field of type", name)
+		}
+
+		value, err := decodedFieldValue(describeField(NewField(name, converters...)).typer, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		values[name] = value
+	}
+
+	return r, nil
+}
+
+// decodedFieldValue restores nested Records in value using typer, the converter that determines the field's type.
+func decodedFieldValue(typer ValueConverter, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch vc := typer.(type) {
+	case *Type:
+		attrs, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode %T as record", value)
+		}
+		return vc.recordFromAttrs(attrs)
+	case *refValueConverter:
+		return decodedFieldValue(vc.resolvedType(), value)
+	case sliceConverter:
+		nestedType := vc.element()
+		if ref, ok := nestedType.(*refValueConverter); ok {
+			nestedType = ref.resolvedType()
+		}
+		if _, ok := nestedType.(*Type); !ok {
+			return value, nil
+		}
+		elements, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode %T as records", value)
+		}
+		records := make([]*Record, len(elements))
+		for i, element := range elements {
+			record, err := decodedFieldValue(nestedType, element)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			records[i], _ = record.(*Record)
+		}
+		return records, nil
+	}
+
+	return value, nil
+}
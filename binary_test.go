@@ -0,0 +1,134 @@
+package mp_test
+
+import (
+	"encoding"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/mp"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ encoding.BinaryMarshaler = (*mp.Record)(nil)
+
+func TestRecordMarshalBinary(t *testing.T) {
+	addressType := mp.NewType(
+		mp.NewField("city", mp.SingleLineString(), mp.Require()),
+	)
+	var commentType *mp.Type
+	commentType = mp.NewType(
+		mp.NewField("body", mp.SingleLineString()),
+		mp.NewField("replies", mp.Slice[*mp.Record](mp.Ref(func() *mp.Type { return commentType }))),
+	)
+	ft := mp.NewTypeWithOptions(
+		mp.TypeOptions{OmitMissing: true},
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64()),
+		mp.NewField("nickname", mp.SingleLineString()),
+		mp.NewField("balance", mp.Decimal()),
+		mp.NewField("born", mp.Time(time.RFC3339)),
+		mp.NewField("id", mp.UUID()),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString())),
+		mp.NewField("address", addressType),
+		mp.NewField("previous", mp.Slice[*mp.Record](addressType)),
+		mp.NewField("comment", commentType),
+	)
+
+	record := ft.Parse(map[string]any{
+		"name":     "Adam",
+		"age":      "30",
+		"balance":  "12.50",
+		"born":     "1990-01-02T03:04:05Z",
+		"id":       "9a2f0fbc-1f3c-4d5e-8a3b-1b2c3d4e5f60",
+		"tags":     []any{"a", "b"},
+		"address":  map[string]any{"city": "Dallas"},
+		"previous": []any{map[string]any{"city": "Austin"}},
+		"comment":  map[string]any{"body": "hi", "replies": []any{map[string]any{"body": "hello"}}},
+	})
+	require.NoError(t, record.Errors())
+
+	data, err := record.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded, err := ft.UnmarshalRecord(data)
+	require.NoError(t, err)
+	assert.True(t, record.Equal(decoded))
+	assert.Equal(t, record.String(), decoded.String())
+
+	_, ok := decoded.Attrs()["nickname"]
+	assert.False(t, ok)
+	assert.Equal(t, uuid.Must(uuid.FromString("9a2f0fbc-1f3c-4d5e-8a3b-1b2c3d4e5f60")), decoded.Get("id"))
+	assert.True(t, decimal.RequireFromString("12.5").Equal(decoded.Get("balance").(decimal.Decimal)))
+	assert.Equal(t, "Dallas", decoded.Get("address").(*mp.Record).Get("city"))
+	assert.Equal(t, "Austin", decoded.Get("previous").([]*mp.Record)[0].Get("city"))
+	replies := decoded.Get("comment").(*mp.Record).Get("replies").([]*mp.Record)
+	assert.Equal(t, "hello", replies[0].Get("body"))
+}
+
+func TestRecordMarshalBinaryPartial(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64()),
+	)
+
+	data, err := ft.ParsePartial(map[string]any{"age": 30}).MarshalBinary()
+	require.NoError(t, err)
+
+	decoded, err := ft.UnmarshalRecord(data)
+	require.NoError(t, err)
+	assert.True(t, decoded.Partial())
+	assert.Equal(t, map[string]any{"age": int64(30)}, decoded.Attrs())
+}
+
+func TestRecordMarshalBinaryRestAndPatterns(t *testing.T) {
+	metaType := mp.NewType(
+		mp.NewField("source", mp.SingleLineString()),
+		mp.Rest(mp.SingleLineString()),
+	)
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("meta", metaType),
+		mp.Pattern("custom_*", mp.Int64()),
+		mp.Rest(mp.SingleLineString()),
+	)
+
+	record := ft.Parse(map[string]any{
+		"name":     "Adam",
+		"meta":     map[string]any{"source": "web", "campaign": "spring"},
+		"custom_n": "7",
+		"extra":    "x",
+	})
+	require.NoError(t, record.Errors())
+
+	data, err := record.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded, err := ft.UnmarshalRecord(data)
+	require.NoError(t, err)
+	assert.Equal(t, "Adam", decoded.Get("name"))
+	assert.Equal(t, map[string]any{"custom_n": int64(7)}, decoded.PatternValues("custom_*"))
+	assert.Equal(t, map[string]any{"extra": "x"}, decoded.Rest())
+	assert.Equal(t, map[string]any{"campaign": "spring"}, decoded.Get("meta").(*mp.Record).Rest())
+}
+
+func TestRecordMarshalBinaryErrors(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+	)
+
+	_, err := ft.Parse(map[string]any{}).MarshalBinary()
+	assert.EqualError(t, err, "cannot encode record with errors")
+
+	data, err := ft.Parse(map[string]any{"name": "Adam"}).MarshalBinary()
+	require.NoError(t, err)
+
+	otherType := mp.NewType(mp.NewField("title"))
+	_, err = otherType.UnmarshalRecord(data)
+	assert.Error(t, err)
+
+	_, err = ft.UnmarshalRecord([]byte("garbage"))
+	assert.Error(t, err)
+}
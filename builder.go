@@ -0,0 +1,101 @@
+package mp
+
+// TypeBuilder builds a Type with a fluent API. It is an alternative to NewTypeWithOptions for Types whose fields have
+// many options. For example:
+//
+//	userType := mp.Build().
+//		Field("email").Converters(mp.SingleLineString()).Required().Alias("mail").Done().
+//		Field("password").Converters(mp.String(), mp.MinLen(12)).Required().Sensitive().Done().
+//		Type()
+type TypeBuilder struct {
+	options TypeOptions
+	fields  []Field
+}
+
+// Build returns a new TypeBuilder with the default TypeOptions.
+func Build() *TypeBuilder {
+	return &TypeBuilder{}
+}
+
+// Options sets the TypeOptions of the Type.
+func (b *TypeBuilder) Options(options TypeOptions) *TypeBuilder {
+	b.options = options
+	return b
+}
+
+// Field starts a new field named name. The field is added to the Type when FieldBuilder.Done is called.
+func (b *TypeBuilder) Field(name string) *FieldBuilder {
+	return &FieldBuilder{b: b, f: NewField(name)}
+}
+
+// AddField adds an existing field such as one created with NewField.
+func (b *TypeBuilder) AddField(f Field) *TypeBuilder {
+	b.fields = append(b.fields, f)
+	return b
+}
+
+// Type returns a new Type with the fields added so far in the order they were added. Like NewTypeWithOptions it
+// panics if the fields are inconsistent, e.g. if more than one field has the same name. b may continue to be used.
+func (b *TypeBuilder) Type() *Type {
+	return NewTypeWithOptions(b.options, b.fields...)
+}
+
+// FieldBuilder builds a field of a TypeBuilder. Converters are applied in the order they are added by Converters,
+// Required, and Deprecated.
+type FieldBuilder struct {
+	b *TypeBuilder
+	f *StandardField
+}
+
+// Converters adds converters to the field.
+func (fb *FieldBuilder) Converters(converters ...ValueConverter) *FieldBuilder {
+	fb.f.valueConverters = append(fb.f.valueConverters, converters...)
+	return fb
+}
+
+// Required adds Require to the converters of the field.
+func (fb *FieldBuilder) Required() *FieldBuilder {
+	return fb.Converters(Require())
+}
+
+// Deprecated adds Deprecated(message) to the converters of the field.
+func (fb *FieldBuilder) Deprecated(message string) *FieldBuilder {
+	return fb.Converters(Deprecated(message))
+}
+
+// Alias adds aliases to the field. See StandardField.WithAlias.
+func (fb *FieldBuilder) Alias(aliases ...string) *FieldBuilder {
+	fb.f = fb.f.WithAlias(aliases...)
+	return fb
+}
+
+// Example adds examples to the field. See StandardField.WithExample.
+func (fb *FieldBuilder) Example(examples ...any) *FieldBuilder {
+	for _, example := range examples {
+		fb.f = fb.f.WithExample(example)
+	}
+	return fb
+}
+
+// Sensitive marks the field as sensitive. See StandardField.WithSensitive.
+func (fb *FieldBuilder) Sensitive() *FieldBuilder {
+	fb.f = fb.f.WithSensitive()
+	return fb
+}
+
+// Meta stores value under key in the metadata of the field. See StandardField.WithMeta.
+func (fb *FieldBuilder) Meta(key string, value any) *FieldBuilder {
+	fb.f = fb.f.WithMeta(key, value)
+	return fb
+}
+
+// StringPolicy sets the StringPolicy of the field. See StandardField.WithStringPolicy.
+func (fb *FieldBuilder) StringPolicy(policy StringPolicy) *FieldBuilder {
+	fb.f = fb.f.WithStringPolicy(policy)
+	return fb
+}
+
+// Done adds the field to the TypeBuilder and returns the TypeBuilder.
+func (fb *FieldBuilder) Done() *TypeBuilder {
+	return fb.b.AddField(fb.f)
+}
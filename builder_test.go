@@ -0,0 +1,51 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	tp := mp.Build().
+		Options(mp.TypeOptions{TrackUnknownKeys: true}).
+		Field("email").Converters(mp.SingleLineString()).Required().Alias("mail", "e-mail").Example("jack@example.com").
+		Meta("label", "Email address").Done().
+		Field("password").Converters(mp.String(), mp.MinLen(8)).Required().Sensitive().Done().
+		Field("nickname").Converters(mp.SingleLineString()).Deprecated("use display_name").Done().
+		AddField(mp.NewField("age", mp.Int64())).
+		Type()
+
+	names := make([]string, len(tp.Fields()))
+	for i, f := range tp.Fields() {
+		names[i] = f.Name()
+	}
+	assert.Equal(t, []string{"email", "password", "nickname", "age"}, names)
+	assert.True(t, tp.Options().TrackUnknownKeys)
+
+	fields := tp.Fields()
+	email := fields[0]
+	assert.True(t, mp.FieldIsRequired(email))
+	assert.Equal(t, []string{"mail", "e-mail"}, mp.FieldAliases(email))
+	assert.Equal(t, []any{"jack@example.com"}, mp.FieldExamples(email))
+	assert.Equal(t, map[string]any{"label": "Email address"}, mp.FieldMeta(email))
+	assert.True(t, mp.FieldIsSensitive(fields[1]))
+	message, ok := mp.FieldDeprecation(fields[2])
+	assert.True(t, ok)
+	assert.Equal(t, "use display_name", message)
+
+	record := tp.Parse(map[string]any{"e-mail": "jack@example.com", "password": "secret123", "nickname": "jj", "x": 1})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "jack@example.com", record.Get("email"))
+	assert.Equal(t, []string{"x"}, record.UnknownKeys())
+	assert.Len(t, record.Warnings(), 1)
+
+	record = tp.Parse(map[string]any{"email": "jack@example.com", "password": "short"})
+	assert.EqualError(t, record.Errors(), "password too short")
+
+	assert.PanicsWithError(t, `field "a" is defined more than once`, func() {
+		mp.Build().Field("a").Done().Field("a").Done().Type()
+	})
+}
@@ -0,0 +1,99 @@
+package mp
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Bytes returns a ValueConverter that converts value to a []byte. A []byte is returned as is and a string is decoded
+// as standard or URL safe base64 with or without padding. If maxLen is greater than 0 then the decoded length must not
+// exceed maxLen. If value is nil or a blank string nil is returned.
+func Bytes(maxLen int) ValueConverter {
+	return &bytesValueConverter{maxLen: maxLen}
+}
+
+type bytesValueConverter struct {
+	maxLen int
+}
+
+var base64Encodings = []*base64.Encoding{
+	base64.StdEncoding,
+	base64.RawStdEncoding,
+	base64.URLEncoding,
+	base64.RawURLEncoding,
+}
+
+func (c *bytesValueConverter) ConvertValue(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var buf []byte
+	switch value := value.(type) {
+	case []byte:
+		buf = value
+	case string:
+		if c.maxLen > 0 && base64.RawStdEncoding.DecodedLen(len(value)) > c.maxLen+2 {
+			return nil, errTooLong
+		}
+		var err error
+		for _, encoding := range base64Encodings {
+			buf, err = encoding.DecodeString(value)
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, errors.New("not valid base64")
+		}
+	default:
+		return nil, fmt.Errorf("cannot convert %T to bytes", value)
+	}
+
+	if c.maxLen > 0 && len(buf) > c.maxLen {
+		return nil, errTooLong
+	}
+
+	return buf, nil
+}
+
+func (c *bytesValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf([]byte(nil))
+}
+
+func (c *bytesValueConverter) ConverterParams() map[string]any {
+	if c.maxLen <= 0 {
+		return nil
+	}
+	return map[string]any{"maxBytes": c.maxLen}
+}
+
+// Reader returns a ValueConverter that passes an io.Reader through unchanged so a field can carry a stream such as a
+// raw request body to the code that handles the Record. If value is nil nil is returned. Any other value that is not
+// an io.Reader is an error.
+func Reader() ValueConverter {
+	return readerValueConverter{}
+}
+
+type readerValueConverter struct{}
+
+func (c readerValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if _, ok := value.(io.Reader); !ok {
+		return nil, errors.New("not a reader")
+	}
+
+	return value, nil
+}
+
+func (c readerValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf((*io.Reader)(nil)).Elem()
+}
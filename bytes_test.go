@@ -0,0 +1,49 @@
+package mp_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{[]byte("hello"), []byte("hello"), true},
+		{"aGVsbG8=", []byte("hello"), true},
+		{"aGVsbG8", []byte("hello"), true},
+		{"-_8=", []byte{0xfb, 0xff}, true},
+		{nil, nil, true},
+		{"", nil, true},
+		{"not base64!", nil, false},
+		{[]byte("hello world"), nil, false},
+		{"aGVsbG8gd29ybGQ=", nil, false},
+		{42, nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.Bytes(8).ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestReader(t *testing.T) {
+	ft := mp.NewType(mp.NewField("body", mp.Reader(), mp.Require()))
+
+	record := ft.Parse(map[string]any{"body": strings.NewReader("hello")})
+	require.NoError(t, record.Errors())
+	buf, err := io.ReadAll(record.Get("body").(io.Reader))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+
+	record = ft.Parse(map[string]any{"body": "hello"})
+	assert.EqualError(t, record.Errors(), "body not a reader")
+}
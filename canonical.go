@@ -0,0 +1,168 @@
+package mp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Hash returns a hex encoded SHA-256 hash of the converted values of the named fields. If no fields are named then all
+// fields are hashed. Field order does not matter and values are normalized so equivalent submissions hash equally
+// (e.g. decimals with different trailing zeros or times in different time zones). It is intended for duplicate
+// submission detection and idempotency keys. Hash panics if a field is not a field of the Record's Type.
+func (r *Record) Hash(fields ...string) string {
+	if len(fields) == 0 {
+		fields = make([]string, len(r.t.fields))
+		for i, f := range r.t.fields {
+			fields[i] = f.Name()
+		}
+	} else {
+		fields = append([]string(nil), fields...)
+	}
+	sort.Strings(fields)
+
+	h := sha256.New()
+	for _, name := range fields {
+		writeCanonical(h, name)
+		io.WriteString(h, ":")
+		writeCanonical(h, r.Get(name))
+		io.WriteString(h, "\n")
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Canonical returns a deterministic, type annotated representation of the converted values of r. Fields and map keys
+// are sorted by name. It is intended for golden file tests and debugging. For example:
+//
+//	{"age": int64(30), "amount": decimal(1.5), "name": "Adam"}
+func (r *Record) Canonical() string {
+	sb := &strings.Builder{}
+	writeCanonical(sb, r)
+	return sb.String()
+}
+
+// String implements the fmt.Stringer interface. It returns the same value as Canonical except that the values of
+// sensitive fields, including those of nested Records, are replaced with [REDACTED] so records can be logged safely.
+func (r *Record) String() string {
+	sb := &strings.Builder{}
+	writeCanonical(redactingWriter{sb}, r)
+	return sb.String()
+}
+
+// redactingWriter is an io.Writer that causes writeCanonical to redact the values of sensitive fields.
+type redactingWriter struct {
+	io.Writer
+}
+
+// writeCanonical writes a deterministic, type annotated representation of value to w.
+func writeCanonical(w io.Writer, value any) {
+	switch value := value.(type) {
+	case nil:
+		io.WriteString(w, "nil")
+	case string:
+		io.WriteString(w, strconv.Quote(value))
+	case decimal.Decimal:
+		fmt.Fprintf(w, "decimal(%s)", value.String())
+	case time.Time:
+		fmt.Fprintf(w, "time(%s)", value.UTC().Format(time.RFC3339Nano))
+	case uuid.UUID:
+		fmt.Fprintf(w, "uuid(%s)", value.String())
+	case *Record:
+		if value == nil {
+			io.WriteString(w, "nil")
+			return
+		}
+		names := make([]string, len(value.t.fields))
+		for i, f := range value.t.fields {
+			names[i] = f.Name()
+		}
+		sort.Strings(names)
+		io.WriteString(w, "{")
+		for i, name := range names {
+			if i > 0 {
+				io.WriteString(w, ", ")
+			}
+			writeCanonical(w, name)
+			io.WriteString(w, ": ")
+			if _, ok := w.(redactingWriter); ok && FieldIsSensitive(value.t.fieldsByName[name]) {
+				io.WriteString(w, "[REDACTED]")
+				continue
+			}
+			writeCanonical(w, value.Get(name))
+		}
+		io.WriteString(w, "}")
+	case []byte:
+		fmt.Fprintf(w, "bytes(%s)", hex.EncodeToString(value))
+	default:
+		writeCanonicalReflect(w, reflect.ValueOf(value))
+	}
+}
+
+func writeCanonicalReflect(w io.Writer, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			io.WriteString(w, "nil")
+			return
+		}
+		writeCanonical(w, v.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			io.WriteString(w, "nil")
+			return
+		}
+		io.WriteString(w, "[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				io.WriteString(w, ", ")
+			}
+			writeCanonical(w, v.Index(i).Interface())
+		}
+		io.WriteString(w, "]")
+	case reflect.Map:
+		if v.IsNil() {
+			io.WriteString(w, "nil")
+			return
+		}
+		keys := v.MapKeys()
+		keyStrings := make([]string, len(keys))
+		for i, k := range keys {
+			keyStrings[i] = fmt.Sprint(k.Interface())
+		}
+		sort.Sort(canonicalKeys{keys: keys, strs: keyStrings})
+		io.WriteString(w, "{")
+		for i, k := range keys {
+			if i > 0 {
+				io.WriteString(w, ", ")
+			}
+			writeCanonical(w, k.Interface())
+			io.WriteString(w, ": ")
+			writeCanonical(w, v.MapIndex(k).Interface())
+		}
+		io.WriteString(w, "}")
+	default:
+		fmt.Fprintf(w, "%s(%v)", v.Type(), v.Interface())
+	}
+}
+
+type canonicalKeys struct {
+	keys []reflect.Value
+	strs []string
+}
+
+func (k canonicalKeys) Len() int           { return len(k.keys) }
+func (k canonicalKeys) Less(i, j int) bool { return k.strs[i] < k.strs[j] }
+func (k canonicalKeys) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.strs[i], k.strs[j] = k.strs[j], k.strs[i]
+}
@@ -0,0 +1,82 @@
+package mp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordHash(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("email", mp.SingleLineString()),
+		mp.NewField("amount", mp.Decimal()),
+		mp.NewField("at", mp.Time(time.RFC3339)),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString())),
+		mp.NewField("note", mp.String()),
+	)
+
+	a := ft.Parse(map[string]any{
+		"email":  "adam@example.com",
+		"amount": "1.50",
+		"at":     "2020-01-01T12:00:00Z",
+		"tags":   []any{"a", "b"},
+		"note":   "first",
+	})
+	b := ft.Parse(map[string]any{
+		"email":  " adam@example.com ",
+		"amount": "1.5",
+		"at":     "2020-01-01T07:00:00-05:00",
+		"tags":   []any{"a", "b"},
+		"note":   "second",
+	})
+
+	require.NoError(t, a.Errors())
+	require.NoError(t, b.Errors())
+
+	assert.Len(t, a.Hash(), 64)
+	assert.Equal(t, a.Hash("email", "amount", "at", "tags"), b.Hash("email", "amount", "at", "tags"))
+	assert.Equal(t, a.Hash("email", "amount"), b.Hash("amount", "email"))
+	assert.NotEqual(t, a.Hash(), b.Hash())
+
+	c := ft.Parse(map[string]any{"email": "adam@example.com", "tags": []any{"b", "a"}})
+	assert.NotEqual(t, a.Hash("email", "tags"), c.Hash("email", "tags"))
+
+	assert.Panics(t, func() { a.Hash("missing") })
+}
+
+func TestRecordCanonical(t *testing.T) {
+	addressType := mp.NewType(
+		mp.NewField("zip", mp.SingleLineString()),
+		mp.NewField("city", mp.SingleLineString()),
+	)
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("age", mp.Int64()),
+		mp.NewField("amount", mp.Decimal()),
+		mp.NewField("at", mp.Time(time.RFC3339)),
+		mp.NewField("address", addressType),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString())),
+		mp.NewField("meta"),
+	)
+
+	record := ft.Parse(map[string]any{
+		"name":    "Adam",
+		"age":     "30",
+		"amount":  "1.50",
+		"at":      "2020-01-01T07:00:00-05:00",
+		"address": map[string]any{"city": "Dallas", "zip": "75001"},
+		"tags":    []any{"b", "a"},
+		"meta":    map[string]any{"y": 2, "x": true},
+	})
+	require.NoError(t, record.Errors())
+
+	expected := `{"address": {"city": "Dallas", "zip": "75001"}, "age": int64(30), "amount": decimal(1.5), ` +
+		`"at": time(2020-01-01T12:00:00Z), "meta": {"x": bool(true), "y": int(2)}, "name": "Adam", "tags": ["b", "a"]}`
+	assert.Equal(t, expected, record.Canonical())
+	assert.Equal(t, expected, record.String())
+	assert.Equal(t, expected, fmt.Sprint(record))
+}
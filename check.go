@@ -0,0 +1,142 @@
+package mp
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Check validates v against t without parsing or coercing it. v must be a map[string]any or a struct (see
+// AttrsFromStruct) whose values already have the Go types produced by t. This allows the rules of a Type to be applied
+// to values constructed in code.
+//
+// A value fails if its type does not match the ConvertedType of a converter of its field. For example, the string "30"
+// fails for an Int64 field. Numeric values of any numeric type are accepted for numeric fields. Otherwise, the
+// converters of each field are applied as with Parse so validators such as Require or MinLen are run. Nested Types and
+// slice elements are checked recursively. FieldGroups are validated but AfterParse hooks are not called. Check returns
+// an Errors keyed by field name or nil if v is valid.
+func (t *Type) Check(v any) error {
+	attrs, ok := v.(map[string]any)
+	if !ok {
+		attrs = AttrsFromStruct(v)
+	}
+
+	var errs Errors
+	for _, idx := range t.parseOrder {
+		f := t.fields[idx]
+		if _, ok := t.droppedFields[f.Name()]; ok {
+			continue
+		}
+		value, present := attrs[f.Name()]
+		if !present {
+			if _, ok := t.optionalFields[f.Name()]; ok {
+				continue
+			}
+		}
+
+		var depValues map[string]any
+		if deps, ok := t.dependencies[f.Name()]; ok {
+			depValues = make(map[string]any, len(deps))
+			for _, name := range deps {
+				if _, failed := errs[name]; failed {
+					depValues = nil
+					break
+				}
+				depValues[name] = attrs[name]
+			}
+			if depValues == nil {
+				continue
+			}
+		}
+
+		err := checkValue(value, fieldConverters(f), depValues)
+		if err != nil {
+			if errs == nil {
+				errs = make(Errors)
+			}
+			errs[f.Name()] = err
+		}
+	}
+
+	errs = t.validateFieldGroups(errs, func(name string) bool {
+		return isPresentValue(attrs[name])
+	})
+
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+func checkValue(value any, converters []ValueConverter, deps map[string]any) error {
+	for _, vc := range converters {
+		var err error
+		switch vc := vc.(type) {
+		case *Type:
+			if value == nil {
+				continue
+			}
+			if record, ok := value.(*Record); ok {
+				value = record.Attrs()
+			}
+			err = vc.Check(value)
+		case *refValueConverter:
+			if value == nil {
+				continue
+			}
+			if inputDepthExceeds(value, vc.options.MaxDepth) {
+				return fmt.Errorf("exceeds maximum depth of %d", vc.options.MaxDepth)
+			}
+			err = checkValue(value, []ValueConverter{vc.resolvedType()}, nil)
+		case sliceConverter:
+			if value == nil {
+				continue
+			}
+			err = checkSlice(value, vc.element())
+		case DependentValueConverter:
+			value, err = vc.ConvertValueWithDependencies(value, deps)
+		default:
+			if typer, ok := vc.(ConvertedTyper); ok && value != nil {
+				if typ := typer.ConvertedType(); typ != nil && !hasCheckedType(value, typ) {
+					return fmt.Errorf("is %T, not %v", value, typ)
+				}
+			}
+			value, err = vc.ConvertValue(value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkSlice(value any, elementConverter ValueConverter) error {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("is %T, not a slice", value)
+	}
+
+	var elErrs sliceElementErrors
+	for i := 0; i < rv.Len(); i++ {
+		err := checkValue(rv.Index(i).Interface(), []ValueConverter{elementConverter}, nil)
+		if err != nil {
+			elErrs = append(elErrs, sliceElementError{Index: i, Err: err})
+		}
+	}
+
+	if elErrs != nil {
+		return elErrs
+	}
+	return nil
+}
+
+func hasCheckedType(value any, typ reflect.Type) bool {
+	vt := reflect.TypeOf(value)
+	if vt.AssignableTo(typ) {
+		return true
+	}
+	if typ.Kind() == reflect.Pointer && vt.AssignableTo(typ.Elem()) {
+		return true
+	}
+	return isNumericKind(vt.Kind()) && isNumericKind(typ.Kind())
+}
@@ -0,0 +1,94 @@
+package mp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withAttr(attrs map[string]any, key string, value any) map[string]any {
+	m := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		m[k] = v
+	}
+	m[key] = value
+	return m
+}
+
+func TestTypeCheck(t *testing.T) {
+	addressType := mp.NewType(
+		mp.NewField("city", mp.SingleLineString(), mp.Require()),
+	)
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require(), mp.MaxLen(10)),
+		mp.NewField("age", mp.Int64(), mp.GreaterThanOrEqual(18)),
+		mp.NewField("balance", mp.Decimal()),
+		mp.NewField("born", mp.Time(time.RFC3339)),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString()), mp.MaxLen(3)),
+		mp.NewField("address", addressType),
+		mp.NewField("previous", mp.Slice[*mp.Record](addressType)),
+	)
+
+	valid := map[string]any{
+		"name":     "Adam",
+		"age":      int64(30),
+		"balance":  decimal.RequireFromString("1.5"),
+		"born":     time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+		"tags":     []string{"a"},
+		"address":  map[string]any{"city": "Dallas"},
+		"previous": []any{map[string]any{"city": "Austin"}},
+	}
+
+	tests := []struct {
+		attrs    map[string]any
+		errField string
+		errMsg   string
+	}{
+		{valid, "", ""},
+		{withAttr(valid, "age", 30), "", ""},
+		{withAttr(valid, "age", "30"), "age", "is string, not int64"},
+		{withAttr(valid, "age", int64(12)), "age", "too small"},
+		{withAttr(valid, "name", nil), "name", "cannot be nil"},
+		{withAttr(valid, "name", "Adam Adamson"), "name", "too long"},
+		{withAttr(valid, "born", "1990-01-02T00:00:00Z"), "born", "is string, not time.Time"},
+		{withAttr(valid, "tags", []int{1}), "tags", "Element 0: is int, not string"},
+		{withAttr(valid, "tags", "a"), "tags", "is string, not a slice"},
+		{withAttr(valid, "address", map[string]any{}), "address", "city cannot be nil"},
+		{withAttr(valid, "previous", []any{map[string]any{"city": 1}}), "previous", "Element 0"},
+	}
+
+	for i, tt := range tests {
+		err := ft.Check(tt.attrs)
+		if tt.errField == "" {
+			assert.NoErrorf(t, err, "%d", i)
+			continue
+		}
+		var errs mp.Errors
+		require.ErrorAsf(t, err, &errs, "%d", i)
+		require.Containsf(t, errs, tt.errField, "%d", i)
+		assert.Containsf(t, errs[tt.errField].Error(), tt.errMsg, "%d", i)
+	}
+}
+
+func TestTypeCheckStruct(t *testing.T) {
+	type person struct {
+		Name string `mp:"name"`
+		Age  int    `mp:"age"`
+	}
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64(), mp.GreaterThanOrEqual(18)),
+	)
+
+	assert.NoError(t, ft.Check(person{Name: "Adam", Age: 30}))
+	assert.NoError(t, ft.Check(&person{Name: "Adam", Age: 30}))
+
+	err := ft.Check(person{Age: 12})
+	var errs mp.Errors
+	require.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 2)
+}
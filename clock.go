@@ -0,0 +1,57 @@
+package mp
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides the current time to time-based validation. It can be replaced in tests to freeze time.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a function that implements Clock.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is a Clock that returns time.Now().
+var SystemClock Clock = ClockFunc(time.Now)
+
+// FixedClock returns a Clock that always returns t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// DefaultClock is the Clock used by time-based converters such as NotInFuture, OlderThan, Birthdate, JWT, and Cursor
+// when no other Clock is configured. It is SystemClock by default. Tests may replace it to freeze time, but as it is
+// not synchronized it must not be changed while converters are in use.
+var DefaultClock Clock = SystemClock
+
+type clockContextKey struct{}
+
+// ContextWithClock returns a copy of ctx with clock. Time-based converters called by ParseCtx with the returned context
+// use clock instead of DefaultClock.
+func ContextWithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockContextKey{}, clock)
+}
+
+// ClockFromContext returns the Clock of ctx. If ctx has no Clock then DefaultClock is returned. The Clock of a Type set
+// with TypeOptions.Clock is added to the context by Parse.
+func ClockFromContext(ctx context.Context) Clock {
+	if clock, ok := FromContext[Clock](ctx, clockContextKey{}); ok && clock != nil {
+		return clock
+	}
+	return DefaultClock
+}
+
+// currentTime returns the current time from clock if it is not nil and from ctx otherwise.
+func currentTime(ctx context.Context, clock Clock) time.Time {
+	if clock != nil {
+		return clock.Now()
+	}
+	return ClockFromContext(ctx).Now()
+}
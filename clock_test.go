@@ -0,0 +1,70 @@
+package mp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultClock(t *testing.T) {
+	frozen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer func(clock mp.Clock) { mp.DefaultClock = clock }(mp.DefaultClock)
+	mp.DefaultClock = mp.FixedClock(frozen)
+
+	_, err := mp.NotInFuture().ConvertValue(frozen.Add(time.Second))
+	assert.EqualError(t, err, "cannot be in the future")
+
+	value, err := mp.NotInFuture().ConvertValue(frozen)
+	require.NoError(t, err)
+	assert.Equal(t, frozen, value)
+
+	assert.Equal(t, frozen, mp.ClockFromContext(context.Background()).Now())
+}
+
+func TestContextWithClock(t *testing.T) {
+	frozen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := mp.ContextWithClock(context.Background(), mp.FixedClock(frozen))
+	assert.Equal(t, frozen, mp.ClockFromContext(ctx).Now())
+
+	tp := mp.NewType(mp.NewField("at", mp.Time(time.RFC3339), mp.OlderThan(time.Hour)))
+
+	record := tp.ParseCtx(ctx, map[string]any{"at": "2023-12-31T22:59:59Z"})
+	require.NoError(t, record.Errors())
+
+	record = tp.ParseCtx(ctx, map[string]any{"at": "2023-12-31T23:30:00Z"})
+	assert.EqualError(t, record.Errors(), "at must be older than 1h0m0s")
+}
+
+func TestTypeOptionsClock(t *testing.T) {
+	frozen := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	inner := mp.NewType(mp.NewField("at", mp.Time(time.RFC3339), mp.NotInFuture()))
+	tp := mp.NewTypeWithOptions(mp.TypeOptions{Clock: mp.FixedClock(frozen)},
+		mp.NewField("at", mp.Time(time.RFC3339), mp.NotInFuture()),
+		mp.NewField("birthdate", mp.Birthdate(18, 0, nil)),
+		mp.NewField("inner", inner),
+	)
+
+	record := tp.Parse(map[string]any{
+		"at":        "2000-01-02T00:00:00Z",
+		"birthdate": "1990-01-01",
+		"inner":     map[string]any{"at": "2000-01-02T00:00:00Z"},
+	})
+	msgs := errorMessages(record.Errors())
+	assert.Equal(t, "cannot be in the future", msgs["at"])
+	assert.Equal(t, "must be at least 18 years old", msgs["birthdate"])
+	assert.Equal(t, "at cannot be in the future", msgs["inner"])
+
+	// A Type without a Clock uses DefaultClock.
+	record = inner.Parse(map[string]any{"at": "2000-01-02T00:00:00Z"})
+	require.NoError(t, record.Errors())
+
+	// The Clock of the Type takes precedence over the Clock of the context.
+	ctx := mp.ContextWithClock(context.Background(), mp.FixedClock(frozen.AddDate(1, 0, 0)))
+	record = tp.ParseCtx(ctx, map[string]any{"at": "2000-01-02T00:00:00Z"})
+	assert.EqualError(t, record.Errors(), "at cannot be in the future")
+}
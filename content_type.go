@@ -0,0 +1,76 @@
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Content is binary data with its detected MIME type. It is the result of DetectContentType.
+type Content struct {
+	// ContentType is the MIME type detected from the data without parameters (e.g. "image/png").
+	ContentType string
+
+	Data []byte
+}
+
+// DetectContentType returns a ValueConverter that detects the MIME type of a []byte from its content and fails unless
+// it is one of allowed. An allowed type may end with "/*" to allow any subtype (e.g. "image/*"). The content type
+// claimed by the client such as the file extension is ignored. The result is a Content. If value is nil then nil is
+// returned. DetectContentType is typically used after Bytes.
+func DetectContentType(allowed ...string) ValueConverter {
+	return &detectContentTypeValueConverter{allowed: newStringSet(allowed)}
+}
+
+type detectContentTypeValueConverter struct {
+	allowed stringSet
+}
+
+func (c *detectContentTypeValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	data, ok := value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("cannot detect content type of %T", value)
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return nil, errors.New("unknown content type")
+	}
+
+	if !c.isAllowed(contentType) {
+		return nil, newKindError(ErrNotAllowed, fmt.Sprintf("content type %s is not allowed", contentType))
+	}
+
+	return Content{ContentType: contentType, Data: data}, nil
+}
+
+func (c *detectContentTypeValueConverter) isAllowed(contentType string) bool {
+	if _, ok := c.allowed.set[contentType]; ok {
+		return true
+	}
+
+	if mainType, _, ok := strings.Cut(contentType, "/"); ok {
+		if _, ok := c.allowed.set[mainType+"/*"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *detectContentTypeValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf(Content{})
+}
+
+func (c *detectContentTypeValueConverter) ConverterParams() map[string]any {
+	items := make([]string, len(c.allowed.list))
+	copy(items, c.allowed.list)
+	return map[string]any{"contentTypes": items}
+}
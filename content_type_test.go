@@ -0,0 +1,33 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestDetectContentType(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("avatar", mp.Bytes(1024), mp.DetectContentType("image/png", "image/jpeg")),
+		mp.NewField("attachment", mp.Bytes(1024), mp.DetectContentType("image/*", "application/pdf")),
+	)
+
+	record := ft.Parse(map[string]any{"avatar": pngHeader, "attachment": []byte("%PDF-1.7\n")})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, mp.Content{ContentType: "image/png", Data: pngHeader}, record.Get("avatar"))
+	assert.Equal(t, "application/pdf", record.Get("attachment").(mp.Content).ContentType)
+
+	record = ft.Parse(map[string]any{"avatar": []byte("MZ\x90\x00\x03\x00\x00\x00")})
+	assert.EqualError(t, record.Errors(), "avatar content type application/octet-stream is not allowed")
+
+	record = ft.Parse(map[string]any{"attachment": pngHeader})
+	require.NoError(t, record.Errors())
+	assert.Nil(t, record.Get("avatar"))
+
+	_, err := mp.DetectContentType("image/png").ConvertValue("abc")
+	require.Error(t, err)
+}
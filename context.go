@@ -0,0 +1,87 @@
+package mp
+
+import "context"
+
+// ContextValueConverter is a ValueConverter that needs request-scoped data such as the current user, tenant, or locale.
+// ParseCtx passes its context to ConvertValueContext. The context is passed to the converters of a field, to
+// converters wrapped by All, Preset, Optional, or IfNotNil, to nested Types, and to the element converter of Slice.
+// Parse and ConvertValue use context.Background.
+type ContextValueConverter interface {
+	ValueConverter
+
+	// ConvertValueContext converts value. ctx is the context passed to ParseCtx.
+	ConvertValueContext(ctx context.Context, value any) (any, error)
+}
+
+// WithContext returns a ValueConverter that converts value with convert. convert receives the context passed to
+// ParseCtx. It allows validators such as "must belong to the current tenant" to be expressed as converters. Use
+// FromContext to read request-scoped data from ctx.
+func WithContext(convert func(ctx context.Context, value any) (any, error)) ValueConverter {
+	return &contextValueConverter{convert: convert}
+}
+
+type contextValueConverter struct {
+	convert func(ctx context.Context, value any) (any, error)
+}
+
+func (c *contextValueConverter) ConvertValue(value any) (any, error) {
+	return c.convert(context.Background(), value)
+}
+
+func (c *contextValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	return c.convert(ctx, value)
+}
+
+// FromContext returns the value of ctx for key if it is a T. ok is false if ctx has no value for key or the value is
+// not a T.
+func FromContext[T any](ctx context.Context, key any) (value T, ok bool) {
+	value, ok = ctx.Value(key).(T)
+	return value, ok
+}
+
+// ParseCtx is like Parse but passes ctx to any ContextValueConverter.
+func (t *Type) ParseCtx(ctx context.Context, attrs map[string]any) *Record {
+	return t.parse(ctx, attrs, false)
+}
+
+// ConvertValueContext implements the ContextValueConverter interface. It is like ConvertValue but parses v with
+// ParseCtx.
+func (t *Type) ConvertValueContext(ctx context.Context, v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	if m, ok := v.(map[string]any); ok {
+		record := t.ParseCtx(ctx, m)
+		if record.Errors() != nil {
+			return nil, record.Errors()
+		}
+
+		return record, nil
+	}
+
+	return nil, errCannotConvertToRecord
+}
+
+// convertValueContext converts value with vc passing ctx if vc is a ContextValueConverter.
+func convertValueContext(ctx context.Context, vc ValueConverter, value any) (any, error) {
+	if cvc, ok := vc.(ContextValueConverter); ok {
+		return cvc.ConvertValueContext(ctx, value)
+	}
+	return vc.ConvertValue(value)
+}
+
+// convertSliceContext is like convertSlice but passes ctx to any ContextValueConverter.
+func convertSliceContext(ctx context.Context, value any, converters []ValueConverter) (any, error) {
+	v := value
+	var err error
+
+	for _, vc := range converters {
+		v, err = convertValueContext(ctx, vc, v)
+		if err != nil {
+			break
+		}
+	}
+
+	return v, err
+}
@@ -0,0 +1,126 @@
+package mp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantKey struct{}
+
+func TestParseCtx(t *testing.T) {
+	sameTenant := mp.WithContext(func(ctx context.Context, value any) (any, error) {
+		tenant, ok := mp.FromContext[string](ctx, tenantKey{})
+		if !ok {
+			return nil, errors.New("no tenant")
+		}
+		if value != nil && value != tenant {
+			return nil, errors.New("must belong to current tenant")
+		}
+		return value, nil
+	})
+
+	itemType := mp.NewType(
+		mp.NewField("tenant", mp.String(), sameTenant),
+	)
+	ft := mp.NewType(
+		mp.NewField("tenant", mp.All(mp.String(), sameTenant)),
+		mp.NewField("items", mp.Slice[*mp.Record](itemType)),
+		mp.NewField("owner", mp.Optional(itemType)),
+	)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	attrs := map[string]any{
+		"tenant": "acme",
+		"items":  []any{map[string]any{"tenant": "acme"}},
+		"owner":  map[string]any{"tenant": "acme"},
+	}
+
+	record := ft.ParseCtx(ctx, attrs)
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "acme", record.Get("tenant"))
+
+	attrs["items"] = []any{map[string]any{"tenant": "other"}}
+	record = ft.ParseCtx(ctx, attrs)
+	assert.EqualError(t, record.Errors(), "items Element 0: tenant must belong to current tenant")
+
+	record = ft.Parse(map[string]any{"tenant": "acme"})
+	assert.EqualError(t, record.Errors(), "tenant no tenant")
+}
+
+func TestParseCtxNestedInSlice(t *testing.T) {
+	tenantOnly := mp.WithContext(func(ctx context.Context, value any) (any, error) {
+		tenant, _ := mp.FromContext[string](ctx, tenantKey{})
+		if value != tenant {
+			return nil, errors.New("must belong to current tenant")
+		}
+		return value, nil
+	})
+
+	ft := mp.NewType(
+		mp.NewField("all", mp.Slice[string](mp.All(mp.String(), tenantOnly))),
+		mp.NewField("preset", mp.Slice[string](mp.Preset("tenant", mp.String(), tenantOnly))),
+		mp.NewField("anyOf", mp.Slice[mp.Match](mp.AnyOf(mp.NewAlternative("tenant", mp.String(), tenantOnly)))),
+	)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	record := ft.ParseCtx(ctx, map[string]any{
+		"all":    []any{"acme"},
+		"preset": []any{"acme"},
+		"anyOf":  []any{"acme"},
+	})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, []string{"acme"}, record.Get("all"))
+	assert.Equal(t, []string{"acme"}, record.Get("preset"))
+	assert.Equal(t, []mp.Match{{Alternative: "tenant", Value: "acme"}}, record.Get("anyOf"))
+
+	record = ft.ParseCtx(ctx, map[string]any{
+		"all":    []any{"other"},
+		"preset": []any{"other"},
+		"anyOf":  []any{"other"},
+	})
+	assert.EqualError(t, record.Errors(), "all Element 0: must belong to current tenant, anyOf Element 0: not a valid tenant, preset Element 0: must belong to current tenant")
+}
+
+func TestParseCtxThroughRef(t *testing.T) {
+	tenant := mp.WithContext(func(ctx context.Context, value any) (any, error) {
+		tenant, ok := mp.FromContext[string](ctx, tenantKey{})
+		if !ok {
+			return nil, errors.New("no tenant")
+		}
+		return tenant, nil
+	})
+
+	var nodeType *mp.Type
+	nodeType = mp.NewType(
+		mp.NewField("tenant", tenant),
+		mp.NewField("children", mp.Slice[*mp.Record](mp.Ref(func() *mp.Type { return nodeType }))),
+	)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	record := nodeType.ParseCtx(ctx, map[string]any{
+		"children": []any{map[string]any{"children": []any{map[string]any{}}}},
+	})
+	require.NoError(t, record.Errors())
+	child := record.Get("children").([]*mp.Record)[0]
+	assert.Equal(t, "acme", child.Get("tenant"))
+	assert.Equal(t, "acme", child.Get("children").([]*mp.Record)[0].Get("tenant"))
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	tenant, ok := mp.FromContext[string](ctx, tenantKey{})
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+
+	_, ok = mp.FromContext[int](ctx, tenantKey{})
+	assert.False(t, ok)
+
+	_, ok = mp.FromContext[string](context.Background(), tenantKey{})
+	assert.False(t, ok)
+}
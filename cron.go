@@ -0,0 +1,242 @@
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression.
+type CronSchedule struct {
+	expr string
+
+	// Each field is a bit set of the values that match.
+	second, minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	// dayOfMonthStar and dayOfWeekStar record whether the day fields were unrestricted. When both day fields are
+	// restricted a time matches if either matches.
+	dayOfMonthStar, dayOfWeekStar bool
+}
+
+// String returns the expression s was parsed from.
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next returns the first time after t that matches s in the location of t. It returns the zero time if there is no
+// match within five years, e.g. for "0 0 30 2 *".
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Add(time.Second - time.Duration(t.Nanosecond())).Truncate(0)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, t.Location())
+			continue
+		}
+		if s.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dow := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond     = cronField{name: "second", min: 0, max: 59}
+	cronMinute     = cronField{name: "minute", min: 0, max: 59}
+	cronHour       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonth = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth      = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11,
+		"DEC": 12,
+	}}
+	cronDayOfWeek = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a cron expression. It accepts 5 fields (minute, hour, day of month, month, day of week) or 6 fields
+// with a leading second field. Each field may be "*", a value, a range such as "1-5", a step such as "*/15" or "1-30/5",
+// or a comma separated list of these. Months and days of week may be given by their three letter English names. Sunday
+// is 0 or 7. "?" is a synonym for "*" in the day fields. The macros @yearly, @annually, @monthly, @weekly, @daily,
+// @midnight, and @hourly are also accepted.
+func ParseCron(expr string) (*CronSchedule, error) {
+	s := &CronSchedule{expr: expr}
+
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "@") {
+		macro, ok := cronMacros[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unknown macro %s", spec)
+		}
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("expected 5 or 6 fields, got %d", len(fields))
+	}
+
+	var err error
+	if s.second, _, err = parseCronField(fields[0], cronSecond); err != nil {
+		return nil, err
+	}
+	if s.minute, _, err = parseCronField(fields[1], cronMinute); err != nil {
+		return nil, err
+	}
+	if s.hour, _, err = parseCronField(fields[2], cronHour); err != nil {
+		return nil, err
+	}
+	if s.dayOfMonth, s.dayOfMonthStar, err = parseCronField(fields[3], cronDayOfMonth); err != nil {
+		return nil, err
+	}
+	if s.month, _, err = parseCronField(fields[4], cronMonth); err != nil {
+		return nil, err
+	}
+	if s.dayOfWeek, s.dayOfWeekStar, err = parseCronField(fields[5], cronDayOfWeek); err != nil {
+		return nil, err
+	}
+
+	// 7 is an alias for Sunday.
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek = s.dayOfWeek&^(1<<7) | 1
+	}
+
+	return s, nil
+}
+
+// parseCronField returns the bit set of the values matched by field and whether field is unrestricted.
+func parseCronField(field string, f cronField) (bits uint64, star bool, err error) {
+	for _, term := range strings.Split(field, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(term, "/")
+
+		step := 1
+		if hasStep {
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step < 1 {
+				return 0, false, fmt.Errorf("invalid step %q in %s field", stepExpr, f.name)
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?" && (f.name == cronDayOfMonth.name || f.name == cronDayOfWeek.name):
+			lo, hi = f.min, f.max
+			if f.name == cronDayOfWeek.name {
+				hi = 6
+			}
+			star = star || !hasStep
+		default:
+			loExpr, hiExpr, isRange := strings.Cut(rangeExpr, "-")
+			if lo, err = parseCronValue(loExpr, f); err != nil {
+				return 0, false, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = parseCronValue(hiExpr, f); err != nil {
+					return 0, false, err
+				}
+				if hi < lo {
+					return 0, false, fmt.Errorf("invalid range %q in %s field", rangeExpr, f.name)
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, star, nil
+}
+
+func parseCronValue(s string, f cronField) (int, error) {
+	if v, ok := f.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, f.name)
+	}
+	return v, nil
+}
+
+// CronExpr returns a ValueConverter that converts value to a *CronSchedule with ParseCron. If value is nil or a blank
+// string nil is returned.
+func CronExpr() ValueConverter {
+	return cronExprValueConverter{}
+}
+
+type cronExprValueConverter struct{}
+
+func (c cronExprValueConverter) ConvertValue(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case *CronSchedule:
+		return value, nil
+	case string:
+		s, err := ParseCron(value)
+		if err != nil {
+			return nil, newKindError(ErrInvalidFormat, err.Error())
+		}
+		return s, nil
+	}
+
+	return nil, errors.New("not a string")
+}
+
+func (c cronExprValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf((*CronSchedule)(nil))
+}
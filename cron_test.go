@@ -0,0 +1,128 @@
+package mp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr     string
+		now      string
+		expected string
+		errMsg   string
+	}{
+		{"*/15 * * * *", "2024-03-10T10:07:30Z", "2024-03-10T10:15:00Z", ""},
+		{"0 9 * * MON-FRI", "2024-03-09T12:00:00Z", "2024-03-11T09:00:00Z", ""},
+		{"30 0 9 * * 1-5", "2024-03-11T09:00:00Z", "2024-03-11T09:00:30Z", ""},
+		{"0 0 1 jan *", "2024-03-10T00:00:00Z", "2025-01-01T00:00:00Z", ""},
+		{"0 0 * * 7", "2024-03-10T00:00:00Z", "2024-03-17T00:00:00Z", ""},
+		{"0 0 13 * 5", "2024-03-10T00:00:00Z", "2024-03-13T00:00:00Z", ""},
+		{"0 0 ? * 5", "2024-03-10T00:00:00Z", "2024-03-15T00:00:00Z", ""},
+		{"0 12 1,15 * *", "2024-03-10T00:00:00Z", "2024-03-15T12:00:00Z", ""},
+		{"5-50/20 * * * *", "2024-03-10T00:26:00Z", "2024-03-10T00:45:00Z", ""},
+		{"@daily", "2024-03-10T10:00:00Z", "2024-03-11T00:00:00Z", ""},
+		{"@weekly", "2024-03-10T10:00:00Z", "2024-03-17T00:00:00Z", ""},
+		{"0 0 30 2 *", "2024-03-10T10:00:00Z", "", ""},
+		{"* * * *", "", "", "expected 5 or 6 fields, got 4"},
+		{"60 * * * *", "", "", `invalid value "60" in minute field`},
+		{"* * 0 * *", "", "", `invalid value "0" in day of month field`},
+		{"* * * FOO *", "", "", `invalid value "FOO" in month field`},
+		{"10-5 * * * *", "", "", `invalid range "10-5" in minute field`},
+		{"*/0 * * * *", "", "", `invalid step "0" in minute field`},
+		{"@often", "", "", "unknown macro @often"},
+	}
+
+	for i, tt := range tests {
+		s, err := mp.ParseCron(tt.expr)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expr, s.String(), "%d", i)
+
+		now, err := time.Parse(time.RFC3339, tt.now)
+		require.NoError(t, err)
+		next := s.Next(now)
+		if tt.expected == "" {
+			assert.Truef(t, next.IsZero(), "%d", i)
+		} else {
+			assert.Equalf(t, tt.expected, next.Format(time.RFC3339), "%d", i)
+		}
+	}
+}
+
+func TestCronExpr(t *testing.T) {
+	value, err := mp.CronExpr().ConvertValue(" 0 0 * * * ")
+	require.NoError(t, err)
+	require.IsType(t, &mp.CronSchedule{}, value)
+
+	value, err = mp.CronExpr().ConvertValue("")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, err = mp.CronExpr().ConvertValue("bogus")
+	assert.EqualError(t, err, "expected 5 or 6 fields, got 1")
+	assert.ErrorIs(t, err, mp.ErrInvalidFormat)
+
+	_, err = mp.CronExpr().ConvertValue(42)
+	assert.EqualError(t, err, "not a string")
+}
+
+func TestParseRRule(t *testing.T) {
+	rr, err := mp.ParseRRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,-1FR;WKST=SU;UNTIL=20241231T235959Z")
+	require.NoError(t, err)
+	assert.Equal(t, "WEEKLY", rr.Freq)
+	assert.Equal(t, 2, rr.Interval)
+	assert.Equal(t, []string{"MO", "-1FR"}, rr.ByDay)
+	assert.Equal(t, "SU", rr.WeekStart)
+	assert.Equal(t, time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC), rr.Until)
+
+	rr, err = mp.ParseRRule("FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=12")
+	require.NoError(t, err)
+	assert.Equal(t, 1, rr.Interval)
+	assert.Equal(t, 12, rr.Count)
+	assert.Equal(t, []int{1, -1}, rr.ByMonthDay)
+
+	tests := []struct {
+		rule   string
+		errMsg string
+	}{
+		{"", "missing FREQ"},
+		{"INTERVAL=2", "missing FREQ"},
+		{"FREQ=FORTNIGHTLY", `invalid FREQ "FORTNIGHTLY"`},
+		{"FREQ=DAILY;INTERVAL=0", `invalid INTERVAL "0"`},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20240101", "COUNT and UNTIL cannot both be given"},
+		{"FREQ=DAILY;UNTIL=tomorrow", `invalid UNTIL "tomorrow"`},
+		{"FREQ=DAILY;BYMONTH=13", `invalid BYMONTH "13"`},
+		{"FREQ=DAILY;BYHOUR=-1", `invalid BYHOUR "-1"`},
+		{"FREQ=DAILY;BYDAY=XX", `invalid BYDAY "XX"`},
+		{"FREQ=DAILY;FREQ=WEEKLY", "FREQ given more than once"},
+		{"FREQ=DAILY;COLOR=RED", "unknown rule part COLOR"},
+		{"FREQ=DAILY;COUNT", `invalid rule part "COUNT"`},
+	}
+
+	for i, tt := range tests {
+		_, err := mp.ParseRRule(tt.rule)
+		assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+	}
+}
+
+func TestRRule(t *testing.T) {
+	value, err := mp.RRule().ConvertValue("FREQ=DAILY")
+	require.NoError(t, err)
+	require.IsType(t, &mp.RecurrenceRule{}, value)
+	assert.Equal(t, "FREQ=DAILY", value.(*mp.RecurrenceRule).String())
+
+	value, err = mp.RRule().ConvertValue(nil)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, err = mp.RRule().ConvertValue("FREQ=NEVER")
+	assert.ErrorIs(t, err, mp.ErrInvalidFormat)
+}
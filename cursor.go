@@ -0,0 +1,152 @@
+package mp
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CursorCodec encodes and decodes opaque pagination cursors. A cursor is a base64 encoded JSON payload signed with
+// HMAC-SHA256 so clients cannot forge or modify it.
+type CursorCodec struct {
+	// Key is the HMAC key. It must be kept secret.
+	Key []byte
+
+	// TTL is how long a cursor is valid after it is encoded. If TTL is 0 cursors never expire.
+	TTL time.Duration
+
+	// Clock is used to set and check the expiration time. If Clock is nil then DefaultClock is used, or when decoding
+	// with Cursor, the Clock of the context passed to ParseCtx.
+	Clock Clock
+}
+
+type cursorPayload struct {
+	Values    any   `json:"v"`
+	ExpiresAt int64 `json:"e,omitempty"`
+}
+
+// Encode encodes values into a signed cursor. values must be encodable as JSON. It is typically a map[string]any or a
+// struct with the values needed to resume a listing (e.g. the sort key of the last item).
+func (c *CursorCodec) Encode(values any) (string, error) {
+	payload := cursorPayload{Values: values}
+	if c.TTL != 0 {
+		payload.ExpiresAt = currentTime(context.Background(), c.Clock).Add(c.TTL).UnixMilli()
+	}
+
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return signToken(c.Key, buf), nil
+}
+
+// Decode verifies and decodes token into the values it was encoded with. JSON objects are decoded as map[string]any
+// and numbers are decoded as json.Number.
+func (c *CursorCodec) Decode(token string) (map[string]any, error) {
+	return c.decode(context.Background(), token)
+}
+
+func (c *CursorCodec) decode(ctx context.Context, token string) (map[string]any, error) {
+	buf, err := verifyToken(c.Key, token)
+	if err != nil {
+		return nil, errors.New("not a valid cursor")
+	}
+
+	var payload struct {
+		Values    map[string]any `json:"v"`
+		ExpiresAt int64          `json:"e"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.UseNumber()
+	err = decoder.Decode(&payload)
+	if err != nil {
+		return nil, errors.New("not a valid cursor")
+	}
+
+	if payload.ExpiresAt != 0 && currentTime(ctx, c.Clock).UnixMilli() > payload.ExpiresAt {
+		return nil, errors.New("cursor has expired")
+	}
+
+	return payload.Values, nil
+}
+
+// Cursor returns a ValueConverter that decodes a cursor encoded by codec into a map[string]any. The result can be
+// further validated by following Cursor with a Type. If value is nil or a blank string nil is returned.
+func Cursor(codec *CursorCodec) ValueConverter {
+	return &cursorValueConverter{codec: codec}
+}
+
+type cursorValueConverter struct {
+	codec *CursorCodec
+}
+
+func (c *cursorValueConverter) ConvertValue(value any) (any, error) {
+	return c.ConvertValueContext(context.Background(), value)
+}
+
+func (c *cursorValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a valid cursor")
+	}
+
+	values, err := c.codec.decode(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (c *cursorValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf(map[string]any{})
+}
+
+// signToken returns payload and its HMAC-SHA256 signature as a token of the form base64(payload).base64(signature).
+func signToken(key, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	signature := mac.Sum(nil)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// verifyToken verifies a token created by signToken and returns its payload.
+func verifyToken(key []byte, token string) ([]byte, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, errors.New("malformed token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("invalid signature")
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,72 @@
+package mp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	codec := &mp.CursorCodec{Key: []byte("secret")}
+
+	token, err := codec.Encode(map[string]any{"id": 42, "name": "Adam"})
+	require.NoError(t, err)
+
+	cursorType := mp.NewType(
+		mp.NewField("id", mp.Int64(), mp.Require()),
+		mp.NewField("name", mp.SingleLineString()),
+	)
+	ft := mp.NewType(
+		mp.NewField("cursor", mp.Cursor(codec), cursorType),
+	)
+
+	record := ft.Parse(map[string]any{"cursor": token})
+	require.NoError(t, record.Errors())
+	cursor := record.Get("cursor").(*mp.Record)
+	assert.Equal(t, int64(42), cursor.Get("id"))
+	assert.Equal(t, "Adam", cursor.Get("name"))
+
+	record = ft.Parse(map[string]any{"cursor": ""})
+	require.NoError(t, record.Errors())
+	assert.Nil(t, record.Get("cursor"))
+}
+
+func TestCursorRejectsTamperedToken(t *testing.T) {
+	codec := &mp.CursorCodec{Key: []byte("secret")}
+
+	token, err := codec.Encode(map[string]any{"id": 42})
+	require.NoError(t, err)
+
+	otherCodec := &mp.CursorCodec{Key: []byte("other")}
+	_, err = mp.Cursor(otherCodec).ConvertValue(token)
+	require.EqualError(t, err, "not a valid cursor")
+
+	tampered := "x" + token[1:]
+	_, err = mp.Cursor(codec).ConvertValue(tampered)
+	require.EqualError(t, err, "not a valid cursor")
+
+	_, err = mp.Cursor(codec).ConvertValue("garbage")
+	require.EqualError(t, err, "not a valid cursor")
+
+	_, err = mp.Cursor(codec).ConvertValue(42)
+	require.EqualError(t, err, "not a valid cursor")
+}
+
+func TestCursorExpires(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	codec := &mp.CursorCodec{Key: []byte("secret"), TTL: time.Minute, Clock: mp.ClockFunc(func() time.Time { return now })}
+
+	token, err := codec.Encode(map[string]any{"id": 42})
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	_, err = codec.Decode(token)
+	require.NoError(t, err)
+
+	now = now.Add(time.Millisecond)
+	_, err = codec.Decode(token)
+	require.EqualError(t, err, "cursor has expired")
+}
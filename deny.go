@@ -0,0 +1,146 @@
+package mp
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DenyMode is what DenyWords does with a denied word.
+type DenyMode int
+
+const (
+	// DenyReject fails with an error.
+	DenyReject DenyMode = iota
+
+	// DenyMask replaces each letter of a denied word with '*'.
+	DenyMask
+
+	// DenyWarn accepts the value unchanged but adds a Warning to the Record.
+	DenyWarn
+)
+
+var errDeniedWord = newKindError(ErrNotAllowed, "contains a denied word")
+
+// DenyWords returns a ValueConverter that screens free text for the words in list. Words are matched as whole words
+// under Unicode case folding so "Darn" and "DARN" match "darn" but "darning" does not. An entry ending with '*' matches
+// any word that starts with the rest of the entry, e.g. "darn*" matches "darning". mode determines whether a value with
+// a denied word is rejected, masked, or accepted with a warning. It should be used after a string converter. Values
+// that are not strings are returned unmodified. If value is nil then nil is returned.
+func DenyWords(list []string, mode DenyMode) ValueConverter {
+	c := &denyWordsValueConverter{words: make(map[string]struct{}, len(list)), mode: mode}
+	for _, w := range list {
+		if prefix, ok := strings.CutSuffix(w, "*"); ok {
+			c.prefixes = append(c.prefixes, foldString(prefix))
+		} else {
+			c.words[foldString(w)] = struct{}{}
+		}
+	}
+	c.list = make([]string, len(list))
+	copy(c.list, list)
+	return c
+}
+
+type denyWordsValueConverter struct {
+	list     []string
+	words    map[string]struct{}
+	prefixes []string
+	mode     DenyMode
+}
+
+func (c *denyWordsValueConverter) ConvertValue(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	switch c.mode {
+	case DenyReject:
+		if c.contains(s) {
+			return nil, errDeniedWord
+		}
+	case DenyMask:
+		return c.mask(s), nil
+	}
+
+	return value, nil
+}
+
+// warning implements valueWarner.
+func (c *denyWordsValueConverter) warning(value any) (string, bool) {
+	if s, ok := value.(string); ok && c.mode == DenyWarn && c.contains(s) {
+		return "contains a denied word", true
+	}
+	return "", false
+}
+
+func (c *denyWordsValueConverter) contains(s string) bool {
+	found := false
+	eachWord(s, func(start, end int) bool {
+		found = c.denied(s[start:end])
+		return !found
+	})
+	return found
+}
+
+func (c *denyWordsValueConverter) mask(s string) string {
+	var sb *strings.Builder
+	last := 0
+	eachWord(s, func(start, end int) bool {
+		if c.denied(s[start:end]) {
+			if sb == nil {
+				sb = &strings.Builder{}
+				sb.Grow(len(s))
+			}
+			sb.WriteString(s[last:start])
+			sb.WriteString(strings.Repeat("*", utf8.RuneCountInString(s[start:end])))
+			last = end
+		}
+		return true
+	})
+
+	if sb == nil {
+		return s
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+func (c *denyWordsValueConverter) denied(word string) bool {
+	word = foldString(word)
+	if _, ok := c.words[word]; ok {
+		return true
+	}
+	for _, prefix := range c.prefixes {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *denyWordsValueConverter) ConverterParams() map[string]any {
+	list := make([]string, len(c.list))
+	copy(list, c.list)
+	return map[string]any{"denyWords": list}
+}
+
+// eachWord calls fn with the byte offsets of each word of s until fn returns false. A word is a run of letters,
+// digits, and marks.
+func eachWord(s string, fn func(start, end int) bool) {
+	start := -1
+	for i, r := range s {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+		if inWord && start < 0 {
+			start = i
+		} else if !inWord && start >= 0 {
+			if !fn(start, i) {
+				return
+			}
+			start = -1
+		}
+	}
+	if start >= 0 {
+		fn(start, len(s))
+	}
+}
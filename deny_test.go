@@ -0,0 +1,59 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenyWords(t *testing.T) {
+	list := []string{"darn", "heck*", "straße"}
+
+	tests := []struct {
+		mode     mp.DenyMode
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{mp.DenyReject, "hello world", "hello world", ""},
+		{mp.DenyReject, "well DARN it", nil, "contains a denied word"},
+		{mp.DenyReject, "darning socks", "darning socks", ""},
+		{mp.DenyReject, "what the Heckin'", nil, "contains a denied word"},
+		{mp.DenyReject, "STRASSE", "STRASSE", ""},
+		{mp.DenyReject, "STRAẞE", nil, "contains a denied word"},
+		{mp.DenyReject, 42, 42, ""},
+		{mp.DenyReject, nil, nil, ""},
+		{mp.DenyMask, "well Darn it, heckity darn", "well **** it, ******* ****", ""},
+		{mp.DenyMask, "STRAẞE ahead", "****** ahead", ""},
+		{mp.DenyMask, "clean", "clean", ""},
+		{mp.DenyWarn, "well darn", "well darn", ""},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.DenyWords(list, tt.mode).ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			assert.ErrorIsf(t, err, mp.ErrNotAllowed, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+}
+
+func TestDenyWordsWarn(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("bio", mp.MultiLineString(), mp.DenyWords([]string{"darn"}, mp.DenyWarn)),
+	)
+
+	record := ft.Parse(map[string]any{"bio": "Darn good coder"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Darn good coder", record.Get("bio"))
+	assert.Equal(t, []mp.Warning{{Field: "bio", Message: "contains a denied word"}}, record.Warnings())
+
+	record = ft.Parse(map[string]any{"bio": "Good coder"})
+	require.NoError(t, record.Errors())
+	assert.Nil(t, record.Warnings())
+}
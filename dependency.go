@@ -0,0 +1,187 @@
+package mp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DependentValueConverter is a ValueConverter that needs the converted values of other fields of the same Type. Parse
+// converts the fields it depends on first and passes their converted values to ConvertValueWithDependencies.
+type DependentValueConverter interface {
+	ValueConverter
+
+	// DependsOn returns the names of the fields whose converted values are needed.
+	DependsOn() []string
+
+	// ConvertValueWithDependencies converts value. deps contains the converted values of the fields named by
+	// DependsOn.
+	ConvertValueWithDependencies(value any, deps map[string]any) (any, error)
+}
+
+// DependsOn returns a ValueConverter that converts value with convert. convert receives the converted values of the
+// fields named by fieldNames. If any of those fields fail to convert then the field is skipped.
+//
+// The returned ValueConverter can only be used as a field converter of a Type. Calling ConvertValue directly returns
+// an error because the dependencies are not available.
+func DependsOn(fieldNames []string, convert func(value any, deps map[string]any) (any, error)) ValueConverter {
+	names := make([]string, len(fieldNames))
+	copy(names, fieldNames)
+	return &dependentValueConverter{fieldNames: names, convert: convert}
+}
+
+type dependentValueConverter struct {
+	fieldNames []string
+	convert    func(value any, deps map[string]any) (any, error)
+}
+
+func (c *dependentValueConverter) ConvertValue(value any) (any, error) {
+	return nil, errors.New("dependencies are not available")
+}
+
+func (c *dependentValueConverter) DependsOn() []string {
+	return c.fieldNames
+}
+
+func (c *dependentValueConverter) ConvertValueWithDependencies(value any, deps map[string]any) (any, error) {
+	return c.convert(value, deps)
+}
+
+func (c *dependentValueConverter) ConverterParams() map[string]any {
+	names := make([]string, len(c.fieldNames))
+	copy(names, c.fieldNames)
+	return map[string]any{"dependsOn": names}
+}
+
+// FieldDependencies returns the names of the fields that f depends on. Dependencies of converters wrapped by Optional
+// or IfNotNil are included.
+func FieldDependencies(f Field) []string {
+	return appendDependencies(nil, make(map[string]struct{}), fieldConverters(f))
+}
+
+func appendDependencies(names []string, seen map[string]struct{}, converters []ValueConverter) []string {
+	for _, vc := range converters {
+		switch vc := vc.(type) {
+		case DependentValueConverter:
+			for _, name := range vc.DependsOn() {
+				if _, ok := seen[name]; !ok {
+					seen[name] = struct{}{}
+					names = append(names, name)
+				}
+			}
+		case converterWrapper:
+			names = appendDependencies(names, seen, appendFlattenedConverters(nil, vc.wrappedConverters()))
+		}
+	}
+	return names
+}
+
+// convertSliceWithDependencies is like convertSlice but it passes deps to any DependentValueConverter and ctx to any
+// ContextValueConverter.
+func convertSliceWithDependencies(ctx context.Context, value any, converters []ValueConverter, deps map[string]any) (any, error) {
+	v := value
+	var err error
+
+	for _, vc := range converters {
+		switch vc := vc.(type) {
+		case DependentValueConverter:
+			v, err = vc.ConvertValueWithDependencies(v, deps)
+		case dependencyForwarder:
+			v, err = vc.convertWithDependencies(ctx, v, deps)
+		default:
+			v, err = convertValueContext(ctx, vc, v)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	return v, err
+}
+
+// convertSliceWithoutDependencies converts value with converters skipping any DependentValueConverter including those
+// nested in All, Preset, Optional, and IfNotNil. It is used when the values of the dependencies are not available.
+func convertSliceWithoutDependencies(ctx context.Context, value any, converters []ValueConverter) (any, error) {
+	v := value
+	var err error
+
+	for _, vc := range converters {
+		if _, ok := vc.(DependentValueConverter); ok {
+			continue
+		}
+		v, err = convertValueContext(ctx, vc, v)
+		if err != nil {
+			break
+		}
+	}
+
+	return v, err
+}
+
+// dependencyForwarder is implemented by converter groups that may contain a DependentValueConverter.
+type dependencyForwarder interface {
+	convertWithDependencies(ctx context.Context, value any, deps map[string]any) (any, error)
+}
+
+// converterWrapper is implemented by ValueConverters such as Optional and IfNotNil that conditionally apply other
+// converters. Unlike a converterGroup they are not flattened for introspection because they change its meaning, e.g. a
+// Require wrapped by Optional does not make the field required. They are only looked through to find dependencies.
+type converterWrapper interface {
+	wrappedConverters() []ValueConverter
+}
+
+// sortFieldsByDependencies returns fields ordered such that every field comes after the fields it depends on. Fields
+// without dependencies between them keep their original order. It panics if a dependency is not a field or if there is
+// a dependency cycle.
+func sortFieldsByDependencies(fields []Field, fieldsByName map[string]Field, dependencies map[string][]string) []Field {
+	if len(dependencies) == 0 {
+		return fields
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(fields))
+	sorted := make([]Field, 0, len(fields))
+	var path []string
+
+	var visit func(f Field)
+	visit = func(f Field) {
+		switch state[f.Name()] {
+		case visited:
+			return
+		case visiting:
+			start := 0
+			for i, name := range path {
+				if name == f.Name() {
+					start = i
+				}
+			}
+			cycle := append(path[start:], f.Name())
+			panic(fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> ")))
+		}
+
+		state[f.Name()] = visiting
+		path = append(path, f.Name())
+		for _, name := range dependencies[f.Name()] {
+			dep, ok := fieldsByName[name]
+			if !ok {
+				panic(fmt.Errorf("%q depends on %q which is not a field of type", f.Name(), name))
+			}
+			visit(dep)
+		}
+		path = path[:len(path)-1]
+		state[f.Name()] = visited
+		sorted = append(sorted, f)
+	}
+
+	for _, f := range fields {
+		visit(f)
+	}
+
+	return sorted
+}
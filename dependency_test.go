@@ -0,0 +1,137 @@
+package mp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependsOn(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("local_time", mp.SingleLineString(), mp.DependsOn([]string{"timezone"}, func(value any, deps map[string]any) (any, error) {
+			if value == nil {
+				return nil, nil
+			}
+			loc, ok := deps["timezone"].(*time.Location)
+			if !ok {
+				loc = time.UTC
+			}
+			return time.ParseInLocation("2006-01-02 15:04", value.(string), loc)
+		})),
+		mp.NewField("timezone", mp.ValueConverterFunc(func(value any) (any, error) {
+			if value == nil {
+				return nil, nil
+			}
+			return time.LoadLocation(fmt.Sprint(value))
+		})),
+	)
+
+	record := ft.Parse(map[string]any{"local_time": "2023-06-24 12:00", "timezone": "America/Chicago"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "America/Chicago", record.Get("local_time").(time.Time).Location().String())
+
+	record = ft.Parse(map[string]any{"local_time": "2023-06-24 12:00", "timezone": "Nowhere/Invalid"})
+	errs := record.Errors().(mp.Errors)
+	assert.Contains(t, errs, "timezone")
+	assert.NotContains(t, errs, "local_time")
+}
+
+func TestDependsOnConvertValueWithoutType(t *testing.T) {
+	vc := mp.DependsOn([]string{"a"}, func(value any, deps map[string]any) (any, error) { return value, nil })
+	_, err := vc.ConvertValue("x")
+	require.Error(t, err)
+}
+
+func TestFieldDependencies(t *testing.T) {
+	noop := func(value any, deps map[string]any) (any, error) { return value, nil }
+	f := mp.NewField("c", mp.DependsOn([]string{"a", "b"}, noop), mp.DependsOn([]string{"b"}, noop))
+	assert.Equal(t, []string{"a", "b"}, mp.FieldDependencies(f))
+	assert.Nil(t, mp.FieldDependencies(mp.NewField("a")))
+}
+
+func TestNewTypeDependencyCycle(t *testing.T) {
+	noop := func(value any, deps map[string]any) (any, error) { return value, nil }
+	assert.PanicsWithError(t, "dependency cycle: a -> b -> a", func() {
+		mp.NewType(
+			mp.NewField("a", mp.DependsOn([]string{"b"}, noop)),
+			mp.NewField("b", mp.DependsOn([]string{"a"}, noop)),
+		)
+	})
+}
+
+func TestNewTypeUnknownDependency(t *testing.T) {
+	noop := func(value any, deps map[string]any) (any, error) { return value, nil }
+	assert.PanicsWithError(t, `"a" depends on "z" which is not a field of type`, func() {
+		mp.NewType(
+			mp.NewField("a", mp.DependsOn([]string{"z"}, noop)),
+		)
+	})
+}
+
+func TestDependsOnWrappedByOptionalAndIfNotNil(t *testing.T) {
+	addBase := func(value any, deps map[string]any) (any, error) {
+		base, ok := deps["base"].(int64)
+		if !ok {
+			return nil, fmt.Errorf("base is missing")
+		}
+		return value.(int64) + base, nil
+	}
+
+	tests := []struct {
+		name    string
+		wrapper func(...mp.ValueConverter) mp.ValueConverter
+	}{
+		{"Optional", mp.Optional},
+		{"IfNotNil", mp.IfNotNil},
+	}
+
+	for _, tt := range tests {
+		// total is declared before base so it is only correct if the dependency is found inside the wrapper.
+		f := mp.NewField("total", mp.Int64(), tt.wrapper(mp.DependsOn([]string{"base"}, addBase)))
+		assert.Equalf(t, []string{"base"}, mp.FieldDependencies(f), "%s", tt.name)
+
+		ft := mp.NewType(f, mp.NewField("base", mp.Int64()))
+		record := ft.Parse(map[string]any{"total": "2", "base": "40"})
+		require.NoErrorf(t, record.Errors(), "%s", tt.name)
+		assert.Equalf(t, int64(42), record.Get("total"), "%s", tt.name)
+
+		// A dependency that fails to convert skips the field.
+		record = ft.Parse(map[string]any{"total": "2", "base": "x"})
+		errs := record.Errors().(mp.Errors)
+		assert.Containsf(t, errs, "base", "%s", tt.name)
+		assert.NotContainsf(t, errs, "total", "%s", tt.name)
+	}
+}
+
+func TestDependsOnInAllWithoutDependencies(t *testing.T) {
+	addBase := func(value any, deps map[string]any) (any, error) {
+		return value.(int64) + deps["base"].(int64), nil
+	}
+
+	tests := []struct {
+		name      string
+		converter mp.ValueConverter
+	}{
+		{"All", mp.All(mp.Int64(), mp.DependsOn([]string{"base"}, addBase))},
+		{"Preset", mp.Preset("total", mp.Int64(), mp.DependsOn([]string{"base"}, addBase))},
+	}
+
+	for _, tt := range tests {
+		ft := mp.NewType(mp.NewField("total", tt.converter), mp.NewField("base", mp.Int64()))
+
+		record := ft.Parse(map[string]any{"total": "2", "base": "40"})
+		require.NoErrorf(t, record.Errors(), "%s", tt.name)
+		assert.Equalf(t, int64(42), record.Get("total"), "%s", tt.name)
+
+		record = ft.ParsePartial(map[string]any{"total": "2"})
+		require.NoErrorf(t, record.Errors(), "%s", tt.name)
+		assert.Equalf(t, int64(2), record.Get("total"), "%s", tt.name)
+
+		assert.NoErrorf(t, ft.ValidateField("total", "2"), "%s", tt.name)
+		assert.Errorf(t, ft.ValidateField("total", "x"), "%s", tt.name)
+	}
+}
@@ -0,0 +1,72 @@
+package mp
+
+import "fmt"
+
+// Deprecated returns a ValueConverter that marks a field as deprecated. The value is accepted unchanged but when the
+// field is present in the input a Warning with message is added to the Record. The deprecation is reported by
+// FieldDeprecation and by ConverterParams as "deprecated".
+func Deprecated(message string) ValueConverter {
+	return &deprecatedValueConverter{message: message}
+}
+
+// DeprecatedReplacedBy is like Deprecated but also uses the value of the deprecated field as the input of replacement
+// when replacement is missing from the input. replacement must be a field of the same Type. This allows a field to be
+// renamed without breaking existing clients.
+func DeprecatedReplacedBy(message, replacement string) ValueConverter {
+	return &deprecatedValueConverter{message: message, replacement: replacement}
+}
+
+type deprecatedValueConverter struct {
+	message     string
+	replacement string
+}
+
+func (c *deprecatedValueConverter) ConvertValue(value any) (any, error) {
+	return value, nil
+}
+
+func (c *deprecatedValueConverter) ConverterParams() map[string]any {
+	params := map[string]any{"deprecated": c.message}
+	if c.replacement != "" {
+		params["replacedBy"] = c.replacement
+	}
+	return params
+}
+
+// FieldDeprecation returns the deprecation message of f and true if f is deprecated.
+func FieldDeprecation(f Field) (message string, ok bool) {
+	if d := fieldDeprecation(f); d != nil {
+		return d.message, true
+	}
+	return "", false
+}
+
+func fieldDeprecation(f Field) *deprecatedValueConverter {
+	for _, vc := range fieldConverters(f) {
+		if d, ok := vc.(*deprecatedValueConverter); ok {
+			return d
+		}
+	}
+	return nil
+}
+
+// valueWarner is implemented by ValueConverters that add a Warning to the Record for some converted values instead of
+// failing. warning is called by Parse with the converted value of the field.
+type valueWarner interface {
+	warning(value any) (message string, ok bool)
+}
+
+// Warning is a non-fatal problem with the input of a Record such as the use of a deprecated field.
+type Warning struct {
+	Field   string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Field, w.Message)
+}
+
+// Warnings returns the warnings recorded while parsing r. The returned slice must not be modified.
+func (r *Record) Warnings() []Warning {
+	return r.warnings
+}
@@ -0,0 +1,49 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecated(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("fax", mp.SingleLineString(), mp.Deprecated("fax is no longer used")),
+		mp.NewField("mail", mp.SingleLineString(), mp.DeprecatedReplacedBy("use email", "email")),
+		mp.NewField("email", mp.SingleLineString()),
+	)
+
+	record := ft.Parse(map[string]any{"name": "Adam"})
+	require.NoError(t, record.Errors())
+	assert.Empty(t, record.Warnings())
+
+	record = ft.Parse(map[string]any{"name": "Adam", "fax": "555-1234", "mail": " adam@example.com "})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "555-1234", record.Get("fax"))
+	assert.Equal(t, "adam@example.com", record.Get("email"))
+	assert.Equal(t, []mp.Warning{
+		{Field: "fax", Message: "fax is no longer used"},
+		{Field: "mail", Message: "use email"},
+	}, record.Warnings())
+	assert.Equal(t, record.Warnings(), record.Clone().Warnings())
+
+	record = ft.Parse(map[string]any{"name": "Adam", "mail": "old@example.com", "email": "new@example.com"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "new@example.com", record.Get("email"))
+
+	message, ok := mp.FieldDeprecation(ft.Fields()[2])
+	assert.True(t, ok)
+	assert.Equal(t, "use email", message)
+	_, ok = mp.FieldDeprecation(ft.Fields()[0])
+	assert.False(t, ok)
+	assert.Equal(t, map[string]any{"deprecated": "use email", "replacedBy": "email"}, mp.FieldConverterParams(ft.Fields()[2]))
+}
+
+func TestDeprecatedReplacedByPanicsWhenReplacementIsNotAField(t *testing.T) {
+	assert.PanicsWithError(t, `"mail" is replaced by "email" which is not a field of type`, func() {
+		mp.NewType(mp.NewField("mail", mp.DeprecatedReplacedBy("use email", "email")))
+	})
+}
@@ -0,0 +1,214 @@
+package mp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// ChangeKind is the kind of a TypeChange.
+type ChangeKind int
+
+const (
+	FieldAdded ChangeKind = iota
+	FieldRemoved
+	FieldChanged
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case FieldAdded:
+		return "added"
+	case FieldRemoved:
+		return "removed"
+	case FieldChanged:
+		return "changed"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// TypeChange is a difference between two versions of a Type.
+type TypeChange struct {
+	// Field is the name of the field. Fields of nested Types are named with a dotted path such as "address.city".
+	Field string
+
+	Kind ChangeKind
+
+	// Breaking is true if input that was valid for the old Type may be invalid for the new Type.
+	Breaking bool
+
+	// Description is a human readable description of the change.
+	Description string
+}
+
+func (c TypeChange) String() string {
+	s := fmt.Sprintf("%s %s: %s", c.Field, c.Kind, c.Description)
+	if c.Breaking {
+		s += " (breaking)"
+	}
+	return s
+}
+
+// DiffTypes returns the differences between oldType and newType. It compares the fields, their converted types, whether
+// they are required, and their converter parameters. Nested Types are compared recursively. It is intended to detect
+// breaking changes to an API in CI.
+func DiffTypes(oldType, newType *Type) []TypeChange {
+	return diffTypes(nil, "", oldType, newType)
+}
+
+func diffTypes(changes []TypeChange, prefix string, oldType, newType *Type) []TypeChange {
+	for _, of := range oldType.fields {
+		if _, ok := newType.fieldsByName[of.Name()]; !ok {
+			changes = append(changes, TypeChange{
+				Field:       prefix + of.Name(),
+				Kind:        FieldRemoved,
+				Breaking:    true,
+				Description: "field was removed",
+			})
+		}
+	}
+
+	for _, nf := range newType.fields {
+		name := prefix + nf.Name()
+		of, ok := oldType.fieldsByName[nf.Name()]
+		if !ok {
+			required := FieldIsRequired(nf)
+			description := "optional field was added"
+			if required {
+				description = "required field was added"
+			}
+			changes = append(changes, TypeChange{Field: name, Kind: FieldAdded, Breaking: required, Description: description})
+			continue
+		}
+
+		changes = diffFields(changes, name, of, nf)
+	}
+
+	return changes
+}
+
+func diffFields(changes []TypeChange, name string, oldField, newField Field) []TypeChange {
+	changed := func(breaking bool, format string, args ...any) {
+		changes = append(changes, TypeChange{
+			Field:       name,
+			Kind:        FieldChanged,
+			Breaking:    breaking,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	oldRequired, newRequired := FieldIsRequired(oldField), FieldIsRequired(newField)
+	if !oldRequired && newRequired {
+		changed(true, "field became required")
+	} else if oldRequired && !newRequired {
+		changed(false, "field is no longer required")
+	}
+
+	oldSF, newSF := describeField(oldField), describeField(newField)
+	oldNested, oldIsType := oldSF.typer.(*Type)
+	newNested, newIsType := newSF.typer.(*Type)
+	if oldIsType && newIsType {
+		changes = diffTypes(changes, name+".", oldNested, newNested)
+	} else {
+		oldType, newType := schemaFieldType(oldSF), schemaFieldType(newSF)
+		if oldType != newType {
+			changed(true, "type changed from %v to %v", oldType, newType)
+		}
+	}
+
+	paramNames := make([]string, 0, len(oldSF.params)+len(newSF.params))
+	for k := range oldSF.params {
+		paramNames = append(paramNames, k)
+	}
+	for k := range newSF.params {
+		if _, ok := oldSF.params[k]; !ok {
+			paramNames = append(paramNames, k)
+		}
+	}
+	sort.Strings(paramNames)
+
+	for _, param := range paramNames {
+		oldValue, oldOK := oldSF.params[param]
+		newValue, newOK := newSF.params[param]
+		switch {
+		case !oldOK:
+			changed(paramChangeIsBreaking(param, nil, newValue), "%s %v was added", param, newValue)
+		case !newOK:
+			changed(false, "%s %v was removed", param, oldValue)
+		case !paramValuesEqual(oldValue, newValue):
+			changed(paramChangeIsBreaking(param, oldValue, newValue), "%s changed from %v to %v", param, oldValue, newValue)
+		}
+	}
+
+	return changes
+}
+
+func schemaFieldType(sf schemaField) reflect.Type {
+	if sf.typer == nil {
+		return anyType
+	}
+	return sf.typer.(ConvertedTyper).ConvertedType()
+}
+
+func paramValuesEqual(a, b any) bool {
+	if a, ok := a.(decimal.Decimal); ok {
+		b, ok := b.(decimal.Decimal)
+		return ok && a.Equal(b)
+	}
+	if _, ok := a.([]ValueConverter); ok {
+		// Nested converters are compared through the fields that use them.
+		return true
+	}
+	if _, ok := a.(ValueConverter); ok {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// paramChangeIsBreaking returns true if changing param from oldValue to newValue can reject previously valid input.
+// oldValue is nil when param was added.
+func paramChangeIsBreaking(param string, oldValue, newValue any) bool {
+	switch param {
+	case "minLen", "minWords", "minAge":
+		o, ok1 := oldValue.(int)
+		n, ok2 := newValue.(int)
+		return !(ok1 && ok2 && n <= o)
+	case "maxLen", "maxBytes", "maxWords", "maxLines", "maxEmoji", "maxAge":
+		o, ok1 := oldValue.(int)
+		n, ok2 := newValue.(int)
+		return !(ok1 && ok2 && n >= o)
+	case "greaterThan", "greaterThanOrEqual":
+		o, ok1 := oldValue.(decimal.Decimal)
+		n, ok2 := newValue.(decimal.Decimal)
+		return !(ok1 && ok2 && n.LessThanOrEqual(o))
+	case "lessThan", "lessThanOrEqual":
+		o, ok1 := oldValue.(decimal.Decimal)
+		n, ok2 := newValue.(decimal.Decimal)
+		return !(ok1 && ok2 && n.GreaterThanOrEqual(o))
+	case "default", "deprecated":
+		return false
+	case "allowStrings", "formats", "contentTypes", "anyOf", "sortFields", "allowExtensions":
+		return !stringsSubset(oldValue, newValue)
+	case "excludeStrings":
+		return !stringsSubset(newValue, oldValue)
+	}
+	return true
+}
+
+// stringsSubset returns true if a and b are []string and every element of a is in b.
+func stringsSubset(a, b any) bool {
+	as, ok1 := a.([]string)
+	bs, ok2 := b.([]string)
+	if !ok1 || !ok2 {
+		return false
+	}
+	set := newStringSet(bs)
+	for _, s := range as {
+		if _, ok := set.set[s]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTypes(t *testing.T) {
+	oldType := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require(), mp.MaxLen(30)),
+		mp.NewField("age", mp.Int32(), mp.GreaterThanOrEqual(18)),
+		mp.NewField("status", mp.SingleLineString(), mp.AllowStrings("active", "inactive")),
+		mp.NewField("nickname", mp.SingleLineString()),
+		mp.NewField("address", mp.NewType(mp.NewField("city", mp.SingleLineString()))),
+		mp.NewField("removed", mp.SingleLineString()),
+	)
+	newType := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require(), mp.MaxLen(20)),
+		mp.NewField("age", mp.Int64(), mp.GreaterThanOrEqual(16)),
+		mp.NewField("status", mp.SingleLineString(), mp.AllowStrings("active", "inactive", "pending")),
+		mp.NewField("nickname", mp.SingleLineString(), mp.Require()),
+		mp.NewField("address", mp.NewType(
+			mp.NewField("city", mp.SingleLineString()),
+			mp.NewField("zip", mp.SingleLineString(), mp.Require()),
+		)),
+		mp.NewField("email", mp.SingleLineString()),
+	)
+
+	changes := mp.DiffTypes(oldType, newType)
+	descriptions := make([]string, len(changes))
+	for i, c := range changes {
+		descriptions[i] = c.String()
+	}
+
+	assert.Equal(t, []string{
+		"removed removed: field was removed (breaking)",
+		"name changed: maxLen changed from 30 to 20 (breaking)",
+		"age changed: type changed from int32 to int64 (breaking)",
+		"age changed: greaterThanOrEqual changed from 18 to 16",
+		"status changed: allowStrings changed from [active inactive] to [active inactive pending]",
+		"nickname changed: field became required (breaking)",
+		"address.zip added: required field was added (breaking)",
+		"email added: optional field was added",
+	}, descriptions)
+
+	assert.Empty(t, mp.DiffTypes(oldType, oldType))
+}
+
+func TestDiffTypesAddedParams(t *testing.T) {
+	oldType := mp.NewType(
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("fax", mp.SingleLineString()),
+		mp.NewField("age", mp.Int64()),
+	)
+	newType := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Default("anonymous")),
+		mp.NewField("fax", mp.SingleLineString(), mp.Deprecated("fax is no longer used")),
+		mp.NewField("age", mp.Int64(), mp.LessThan(200)),
+	)
+
+	changes := mp.DiffTypes(oldType, newType)
+	descriptions := make([]string, len(changes))
+	for i, c := range changes {
+		descriptions[i] = c.String()
+	}
+
+	assert.Equal(t, []string{
+		"name changed: default anonymous was added",
+		"fax changed: deprecated fax is no longer used was added",
+		"age changed: lessThan 200 was added (breaking)",
+	}, descriptions)
+}
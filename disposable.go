@@ -0,0 +1,152 @@
+package mp
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+//go:embed disposable_email_domains.txt
+var disposableEmailDomainsText string
+
+// Blocklist is a set of blocked domains. It is implemented by *DomainBlocklist. Implementations must be safe for
+// concurrent use.
+type Blocklist interface {
+	// Contains returns true if domain is blocked.
+	Contains(domain string) bool
+}
+
+// DomainBlocklist is a Blocklist of domains and their subdomains. Domains are matched case-insensitively. The domains
+// can be replaced with Replace or Load while the DomainBlocklist is in use so a list can be refreshed periodically
+// without rebuilding the Types that use it.
+type DomainBlocklist struct {
+	domains atomic.Pointer[map[string]struct{}]
+}
+
+// NewDomainBlocklist returns a DomainBlocklist of domains.
+func NewDomainBlocklist(domains ...string) *DomainBlocklist {
+	b := &DomainBlocklist{}
+	b.Replace(domains)
+	return b
+}
+
+// Replace replaces the domains of b.
+func (b *DomainBlocklist) Replace(domains []string) {
+	set := make(map[string]struct{}, len(domains))
+	for _, d := range domains {
+		set[strings.ToLower(strings.TrimSuffix(d, "."))] = struct{}{}
+	}
+	b.domains.Store(&set)
+}
+
+// Load replaces the domains of b with those read from r. r must contain one domain per line. Blank lines and lines
+// starting with # are ignored. If reading fails then b is not modified.
+func (b *DomainBlocklist) Load(r io.Reader) error {
+	domains, err := readDomains(r)
+	if err != nil {
+		return err
+	}
+	b.Replace(domains)
+	return nil
+}
+
+// Contains returns true if domain or any of its parent domains is in b.
+func (b *DomainBlocklist) Contains(domain string) bool {
+	domains := *b.domains.Load()
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	for {
+		if _, ok := domains[domain]; ok {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+func readDomains(r io.Reader) ([]string, error) {
+	var domains []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	return domains, scanner.Err()
+}
+
+// DisposableEmailDomains returns the embedded list of domains of well-known disposable email services. It is used by
+// NotDisposableEmail when no Blocklist is given. It is not exhaustive. Use a DomainBlocklist refreshed from a
+// maintained source for stronger protection.
+func DisposableEmailDomains() []string {
+	domains, _ := readDomains(strings.NewReader(disposableEmailDomainsText))
+	return domains
+}
+
+var defaultDisposableEmailBlocklist = NewDomainBlocklist(DisposableEmailDomains()...)
+
+// DisposableEmailOptions configures the converter returned by NotDisposableEmailWithOptions.
+type DisposableEmailOptions struct {
+	// Warn accepts addresses of blocked domains but adds a Warning to the Record instead of failing. This allows such
+	// addresses to be flagged for review.
+	Warn bool
+}
+
+// NotDisposableEmail returns a ValueConverter that returns an error if value is an email address of a domain in
+// blocklist. If blocklist is nil then the embedded DisposableEmailDomains are used. It should be used after a string
+// converter. Values that are not email addresses are returned unmodified. If value is nil then nil is returned.
+func NotDisposableEmail(blocklist Blocklist) ValueConverter {
+	return NotDisposableEmailWithOptions(blocklist, DisposableEmailOptions{})
+}
+
+// NotDisposableEmailWithOptions is like NotDisposableEmail but with options.
+func NotDisposableEmailWithOptions(blocklist Blocklist, options DisposableEmailOptions) ValueConverter {
+	if blocklist == nil {
+		blocklist = defaultDisposableEmailBlocklist
+	}
+	return &disposableEmailValueConverter{blocklist: blocklist, options: options}
+}
+
+var errDisposableEmail = newKindError(ErrNotAllowed, "disposable email address not allowed")
+
+type disposableEmailValueConverter struct {
+	blocklist Blocklist
+	options   DisposableEmailOptions
+}
+
+func (c *disposableEmailValueConverter) ConvertValue(value any) (any, error) {
+	if !c.options.Warn && c.isDisposable(value) {
+		return nil, errDisposableEmail
+	}
+	return value, nil
+}
+
+// warning implements valueWarner.
+func (c *disposableEmailValueConverter) warning(value any) (string, bool) {
+	if c.options.Warn && c.isDisposable(value) {
+		return "disposable email address", true
+	}
+	return "", false
+}
+
+func (c *disposableEmailValueConverter) isDisposable(value any) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return false
+	}
+	return c.blocklist.Contains(s[at+1:])
+}
+
+func (c *disposableEmailValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"notDisposableEmail": true}
+}
@@ -0,0 +1,95 @@
+package mp_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainBlocklist(t *testing.T) {
+	b := mp.NewDomainBlocklist("Mailinator.com", "yopmail.fr.")
+
+	tests := []struct {
+		domain   string
+		expected bool
+	}{
+		{"mailinator.com", true},
+		{"MAILINATOR.COM", true},
+		{"eu.mailinator.com", true},
+		{"yopmail.fr", true},
+		{"notmailinator.com", false},
+		{"com", false},
+		{"example.com", false},
+	}
+
+	for i, tt := range tests {
+		assert.Equalf(t, tt.expected, b.Contains(tt.domain), "%d", i)
+	}
+
+	err := b.Load(strings.NewReader("# refreshed\n\nexample.com\n  trashmail.com  \n"))
+	require.NoError(t, err)
+	assert.True(t, b.Contains("example.com"))
+	assert.True(t, b.Contains("trashmail.com"))
+	assert.False(t, b.Contains("mailinator.com"))
+
+	err = b.Load(iotest.ErrReader(errors.New("boom")))
+	assert.EqualError(t, err, "boom")
+	assert.True(t, b.Contains("example.com"))
+}
+
+func TestDisposableEmailDomains(t *testing.T) {
+	domains := mp.DisposableEmailDomains()
+	assert.Contains(t, domains, "mailinator.com")
+	for _, d := range domains {
+		assert.NotContains(t, d, "#")
+		assert.Equal(t, strings.TrimSpace(d), d)
+	}
+}
+
+func TestNotDisposableEmail(t *testing.T) {
+	tests := []struct {
+		value  any
+		errMsg string
+	}{
+		{"jack@example.com", ""},
+		{"jack@mailinator.com", "disposable email address not allowed"},
+		{"jack@Guerrillamail.com", "disposable email address not allowed"},
+		{"not an email", ""},
+		{nil, ""},
+	}
+
+	vc := mp.NotDisposableEmail(nil)
+	for i, tt := range tests {
+		value, err := vc.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			assert.ErrorIsf(t, err, mp.ErrNotAllowed, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.value, value, "%d", i)
+	}
+
+	_, err := mp.NotDisposableEmail(mp.NewDomainBlocklist("example.com")).ConvertValue("jack@example.com")
+	assert.Error(t, err)
+}
+
+func TestNotDisposableEmailWarn(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("email", mp.SingleLineString(), mp.NotDisposableEmailWithOptions(nil, mp.DisposableEmailOptions{Warn: true})),
+	)
+
+	record := ft.Parse(map[string]any{"email": "jack@mailinator.com"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "jack@mailinator.com", record.Get("email"))
+	assert.Equal(t, []mp.Warning{{Field: "email", Message: "disposable email address"}}, record.Warnings())
+
+	record = ft.Parse(map[string]any{"email": "jack@example.com"})
+	require.NoError(t, record.Errors())
+	assert.Nil(t, record.Warnings())
+}
@@ -0,0 +1,154 @@
+package mp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+// Resolver is the subset of *net.Resolver used by DomainExists and EmailDeliverable.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// DefaultDNSTimeout is the timeout used by DomainExists and EmailDeliverable when DNSOptions.Timeout is 0.
+const DefaultDNSTimeout = 5 * time.Second
+
+// DNSOptions configures the converters returned by DomainExistsWithOptions and EmailDeliverableWithOptions.
+type DNSOptions struct {
+	// Timeout limits the time spent on the DNS lookups of a single value. If Timeout is 0 then DefaultDNSTimeout is
+	// used.
+	Timeout time.Duration
+
+	// AllowLookupFailure accepts values whose lookups fail for a reason other than the domain not existing, such as a
+	// timeout or an unreachable DNS server. By default, such values are rejected because they could not be verified.
+	AllowLookupFailure bool
+}
+
+var (
+	errDomainDoesNotExist  = errors.New("domain does not exist")
+	errDomainDoesNotAccept = errors.New("domain does not accept email")
+	errCannotVerifyDomain  = errors.New("cannot verify domain")
+	errNotAnEmailAddress   = newKindError(ErrInvalidFormat, "not an email address")
+)
+
+// DomainExists returns a ValueConverter that returns an error unless value is a domain name that has MX or address
+// records. It should be used after a string converter. If resolver is nil then net.DefaultResolver is used. The
+// lookups use the context passed to ParseCtx. If value is nil then nil is returned.
+func DomainExists(resolver Resolver) ValueConverter {
+	return DomainExistsWithOptions(resolver, DNSOptions{})
+}
+
+// DomainExistsWithOptions is like DomainExists but with options.
+func DomainExistsWithOptions(resolver Resolver, options DNSOptions) ValueConverter {
+	return &dnsValueConverter{resolver: resolver, options: options}
+}
+
+// EmailDeliverable returns a ValueConverter that returns an error unless the domain of the email address value can
+// receive email. The domain must have MX records or, if it has none, address records. A domain that publishes a null
+// MX record (RFC 7505) is rejected. Only the domain is checked. The mailbox is not. It should be used after a string
+// converter. If resolver is nil then net.DefaultResolver is used. The lookups use the context passed to ParseCtx. If
+// value is nil then nil is returned.
+func EmailDeliverable(resolver Resolver) ValueConverter {
+	return EmailDeliverableWithOptions(resolver, DNSOptions{})
+}
+
+// EmailDeliverableWithOptions is like EmailDeliverable but with options.
+func EmailDeliverableWithOptions(resolver Resolver, options DNSOptions) ValueConverter {
+	return &dnsValueConverter{resolver: resolver, options: options, email: true}
+}
+
+type dnsValueConverter struct {
+	resolver Resolver
+	options  DNSOptions
+	email    bool
+}
+
+func (c *dnsValueConverter) ConvertValue(value any) (any, error) {
+	return c.ConvertValueContext(context.Background(), value)
+}
+
+func (c *dnsValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	domain := s
+	if c.email {
+		at := strings.LastIndexByte(s, '@')
+		if at <= 0 || at == len(s)-1 {
+			return nil, errNotAnEmailAddress
+		}
+		domain = s[at+1:]
+	}
+
+	timeout := c.options.Timeout
+	if timeout == 0 {
+		timeout = DefaultDNSTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := c.lookup(ctx, domain)
+	if err != nil {
+		if err == errCannotVerifyDomain && c.options.AllowLookupFailure {
+			return value, nil
+		}
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// lookup returns nil if domain exists or can receive email.
+func (c *dnsValueConverter) lookup(ctx context.Context, domain string) error {
+	resolver := c.resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	mxs, err := resolver.LookupMX(ctx, domain)
+	if err != nil && !isDNSNotFound(err) {
+		return errCannotVerifyDomain
+	}
+	if len(mxs) > 0 {
+		if c.email && len(mxs) == 1 && (mxs[0].Host == "." || mxs[0].Host == "") {
+			return errDomainDoesNotAccept
+		}
+		return nil
+	}
+
+	hosts, err := resolver.LookupHost(ctx, domain)
+	if err != nil && !isDNSNotFound(err) {
+		return errCannotVerifyDomain
+	}
+	if len(hosts) > 0 {
+		return nil
+	}
+
+	if c.email {
+		return errDomainDoesNotAccept
+	}
+	return errDomainDoesNotExist
+}
+
+func (c *dnsValueConverter) ConverterParams() map[string]any {
+	if c.email {
+		return map[string]any{"emailDeliverable": true}
+	}
+	return map[string]any{"domainExists": true}
+}
+
+// isDNSNotFound returns true if err means that the name has no records of the requested type.
+func isDNSNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,116 @@
+package mp_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	err   error
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if mxs, ok := r.mx[name]; ok {
+		return mxs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if hosts, ok := r.hosts[host]; ok {
+		return hosts, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+var testResolver = &fakeResolver{
+	mx: map[string][]*net.MX{
+		"example.com": {{Host: "mail.example.com.", Pref: 10}},
+		"nomail.com":  {{Host: ".", Pref: 0}},
+	},
+	hosts: map[string][]string{
+		"example.com":  {"93.184.216.34"},
+		"hostonly.com": {"192.0.2.1"},
+		"nomail.com":   {"192.0.2.2"},
+		"missing.com":  {},
+	},
+}
+
+func TestDomainExists(t *testing.T) {
+	tests := []struct {
+		value  any
+		errMsg string
+	}{
+		{"example.com", ""},
+		{"hostonly.com", ""},
+		{"nomail.com", ""},
+		{"missing.com", "domain does not exist"},
+		{"slow.com", "cannot verify domain"},
+		{nil, ""},
+		{42, "not a string"},
+	}
+
+	vc := mp.DomainExistsWithOptions(testResolver, mp.DNSOptions{Timeout: time.Millisecond})
+	for i, tt := range tests {
+		value, err := vc.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.value, value, "%d", i)
+	}
+}
+
+func TestEmailDeliverable(t *testing.T) {
+	tests := []struct {
+		value  any
+		errMsg string
+	}{
+		{"jack@example.com", ""},
+		{"jack@hostonly.com", ""},
+		{"jack@nomail.com", "domain does not accept email"},
+		{"jack@missing.com", "domain does not accept email"},
+		{"jack", "not an email address"},
+		{"@example.com", "not an email address"},
+		{"jack@", "not an email address"},
+		{nil, ""},
+	}
+
+	ft := mp.NewType(mp.NewField("email", mp.SingleLineString(), mp.EmailDeliverable(testResolver)))
+	for i, tt := range tests {
+		record := ft.ParseCtx(context.Background(), map[string]any{"email": tt.value})
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, record.Errors(), "email "+tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, record.Errors(), "%d", i)
+	}
+}
+
+func TestDNSOptionsAllowLookupFailure(t *testing.T) {
+	resolver := &fakeResolver{err: errors.New("connection refused")}
+
+	_, err := mp.EmailDeliverable(resolver).ConvertValue("jack@example.com")
+	assert.EqualError(t, err, "cannot verify domain")
+
+	value, err := mp.EmailDeliverableWithOptions(resolver, mp.DNSOptions{AllowLookupFailure: true}).ConvertValue("jack@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "jack@example.com", value)
+}
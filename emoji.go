@@ -0,0 +1,196 @@
+package mp
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	errContainsEmoji = newKindError(ErrNotAllowed, "cannot contain emoji")
+	errTooManyEmoji  = newKindError(ErrTooLong, "too many emoji")
+)
+
+// NoEmoji returns a ValueConverter that fails if value contains an emoji. It is intended for fields such as legal
+// names and invoice references where emoji break downstream systems. value must be a string. nil is returned
+// unmodified.
+func NoEmoji() ValueConverter {
+	return maxEmojiValueConverter{max: 0}
+}
+
+// MaxEmoji returns a ValueConverter that fails if value contains more than max emoji. A sequence that is displayed as a
+// single emoji, such as a flag or a family joined with zero width joiners, counts as one. value must be a string. nil
+// is returned unmodified.
+func MaxEmoji(max int) ValueConverter {
+	return maxEmojiValueConverter{max: max}
+}
+
+type maxEmojiValueConverter struct {
+	max int
+}
+
+func (c maxEmojiValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	n := 0
+	eachEmoji(s, func(start, end int) bool {
+		n++
+		return n <= c.max
+	})
+	if n > c.max {
+		if c.max == 0 {
+			return nil, errContainsEmoji
+		}
+		return nil, errTooManyEmoji
+	}
+
+	return value, nil
+}
+
+func (c maxEmojiValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"maxEmoji": c.max}
+}
+
+// StripEmoji returns a ValueConverter that removes emoji from value. value must be a string. nil is returned
+// unmodified.
+func StripEmoji() ValueConverter {
+	return stripEmojiValueConverter{}
+}
+
+type stripEmojiValueConverter struct{}
+
+func (c stripEmojiValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	var sb *strings.Builder
+	last := 0
+	eachEmoji(s, func(start, end int) bool {
+		if sb == nil {
+			sb = &strings.Builder{}
+			sb.Grow(len(s))
+		}
+		sb.WriteString(s[last:start])
+		last = end
+		return true
+	})
+
+	if sb == nil {
+		return s, nil
+	}
+	sb.WriteString(s[last:])
+	return sb.String(), nil
+}
+
+func (c stripEmojiValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf("")
+}
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	variationSelector = '\ufe0f'
+	combiningKeycap   = '\u20e3'
+)
+
+// eachEmoji calls fn with the byte offsets of each emoji of s until fn returns false. Modifiers, variation selectors,
+// tags, and zero width joined sequences are included in the emoji they modify.
+func eachEmoji(s string, fn func(start, end int) bool) {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		start := i
+		i += size
+
+		next, nextSize := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case isRegionalIndicator(r):
+			if isRegionalIndicator(next) {
+				i += nextSize
+			}
+		case isKeycapBase(r):
+			// A keycap such as "1️⃣" is a digit, '#', or '*' followed by an optional variation selector and a combining
+			// keycap.
+			end := i
+			if next == variationSelector {
+				end += nextSize
+			}
+			if keycap, keycapSize := utf8.DecodeRuneInString(s[end:]); keycap == combiningKeycap {
+				i = end + keycapSize
+			} else {
+				continue
+			}
+		case isPictographic(r) || next == variationSelector && r >= 0x80:
+			i = emojiSequenceEnd(s, i)
+		default:
+			continue
+		}
+
+		if !fn(start, i) {
+			return
+		}
+	}
+}
+
+// emojiSequenceEnd returns the offset of the end of the emoji sequence whose first rune ends at i.
+func emojiSequenceEnd(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == variationSelector || r == combiningKeycap || isEmojiModifier(r) || isTag(r):
+			i += size
+		case r == zeroWidthJoiner:
+			joined, joinedSize := utf8.DecodeRuneInString(s[i+size:])
+			if !isPictographic(joined) {
+				return i
+			}
+			i += size + joinedSize
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// isPictographic returns true if r is a character that is displayed as an emoji by default.
+func isPictographic(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r == 0x231A || r == 0x231B || r >= 0x23E9 && r <= 0x23F3 || r >= 0x23F8 && r <= 0x23FA:
+		return true
+	case r == 0x2B1B || r == 0x2B1C || r == 0x2B50 || r == 0x2B55:
+		return true
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isTag(r rune) bool {
+	return r >= 0xE0020 && r <= 0xE007F
+}
+
+func isKeycapBase(r rune) bool {
+	return r >= '0' && r <= '9' || r == '#' || r == '*'
+}
@@ -0,0 +1,85 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoEmoji(t *testing.T) {
+	tests := []struct {
+		value  any
+		errMsg string
+	}{
+		{"Jack Christensen", ""},
+		{"José Ñúñez 李小龍", ""},
+		{"Invoice #123 © 2024 → paid", ""},
+		{"Jack 😀", "cannot contain emoji"},
+		{"Thumbs 👍🏽", "cannot contain emoji"},
+		{"Heart ❤️", "cannot contain emoji"},
+		{"Flag 🇺🇸", "cannot contain emoji"},
+		{"Key 1️⃣", "cannot contain emoji"},
+		{nil, ""},
+		{42, "not a string"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.NoEmoji().ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.value, value, "%d", i)
+	}
+
+	_, err := mp.NoEmoji().ConvertValue("😀")
+	assert.ErrorIs(t, err, mp.ErrNotAllowed)
+}
+
+func TestMaxEmoji(t *testing.T) {
+	tests := []struct {
+		value  string
+		errMsg string
+	}{
+		{"no emoji", ""},
+		{"👨‍👩‍👧‍👦 family", ""},
+		{"🇺🇸🇨🇦", ""},
+		{"🏴󠁧󠁢󠁳󠁣󠁴󠁿 and 😀", ""},
+		{"😀😀😀", "too many emoji"},
+		{"👍🏽 ❤️ #️⃣", "too many emoji"},
+	}
+
+	for i, tt := range tests {
+		_, err := mp.MaxEmoji(2).ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			assert.ErrorIsf(t, err, mp.ErrTooLong, "%d", i)
+			continue
+		}
+		assert.NoErrorf(t, err, "%d", i)
+	}
+}
+
+func TestStripEmoji(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+	}{
+		{"plain text", "plain text"},
+		{"Jack 😀", "Jack "},
+		{"👨‍👩‍👧‍👦Family👍🏽", "Family"},
+		{"🇺🇸 USA", " USA"},
+		{"Room 1 1️⃣ ❤️", "Room 1  "},
+		{"Ref-42 ✅", "Ref-42 "},
+		{nil, nil},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.StripEmoji().ConvertValue(tt.value)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+}
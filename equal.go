@@ -0,0 +1,93 @@
+package mp
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Equal returns true if the converted values of the named fields of r and other are equal. If no fields are named then
+// all fields are compared and r and other must be of the same Type. Values are compared by type: decimals with
+// decimal.Decimal.Equal, times with time.Time.Equal, and nested records, slices, and maps element by element. Other
+// values are compared with reflect.DeepEqual. Equal panics if a field is not a field of r's Type. If a field is not a
+// field of other's Type then Equal returns false.
+func (r *Record) Equal(other *Record, fields ...string) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(fields) == 0 {
+		if r.t != other.t {
+			return false
+		}
+		for _, f := range r.t.fields {
+			if !valuesEqual(r.Get(f.Name()), other.Get(f.Name())) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, name := range fields {
+		otherValue, ok := other.TryGet(name)
+		if !ok {
+			r.Get(name) // panics if name is not a field of r's Type.
+			return false
+		}
+		if !valuesEqual(r.Get(name), otherValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func valuesEqual(a, b any) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case decimal.Decimal:
+		b, ok := b.(decimal.Decimal)
+		return ok && a.Equal(b)
+	case time.Time:
+		b, ok := b.(time.Time)
+		return ok && a.Equal(b)
+	case *Record:
+		b, ok := b.(*Record)
+		return ok && a.Equal(b)
+	}
+
+	av := reflect.ValueOf(a)
+	bv := reflect.ValueOf(b)
+	if !bv.IsValid() || av.Type() != bv.Type() {
+		return false
+	}
+
+	switch av.Kind() {
+	case reflect.Slice:
+		if av.IsNil() != bv.IsNil() || av.Len() != bv.Len() {
+			return false
+		}
+		for i := 0; i < av.Len(); i++ {
+			if !valuesEqual(av.Index(i).Interface(), bv.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if av.IsNil() != bv.IsNil() || av.Len() != bv.Len() {
+			return false
+		}
+		iter := av.MapRange()
+		for iter.Next() {
+			bElem := bv.MapIndex(iter.Key())
+			if !bElem.IsValid() || !valuesEqual(iter.Value().Interface(), bElem.Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,58 @@
+package mp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEqual(t *testing.T) {
+	addressType := mp.NewType(mp.NewField("city", mp.SingleLineString()))
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("amount", mp.Decimal()),
+		mp.NewField("at", mp.Time(time.RFC3339)),
+		mp.NewField("address", addressType),
+		mp.NewField("scores", mp.Slice[decimal.Decimal](mp.Decimal())),
+	)
+
+	a := ft.Parse(map[string]any{
+		"name":    "Adam",
+		"amount":  "1.50",
+		"at":      "2020-01-01T12:00:00Z",
+		"address": map[string]any{"city": "Dallas"},
+		"scores":  []any{"1.0", "2"},
+	})
+	b := ft.Parse(map[string]any{
+		"name":    "Adam",
+		"amount":  "1.5",
+		"at":      "2020-01-01T07:00:00-05:00",
+		"address": map[string]any{"city": "Dallas"},
+		"scores":  []any{"1", "2.00"},
+	})
+	c := ft.Parse(map[string]any{
+		"name":    "Bob",
+		"amount":  "1.5",
+		"address": map[string]any{"city": "Austin"},
+	})
+
+	require.NoError(t, a.Errors())
+	require.NoError(t, b.Errors())
+	require.NoError(t, c.Errors())
+
+	assert.True(t, a.Equal(b))
+	assert.False(t, a.Equal(c))
+	assert.True(t, a.Equal(c, "amount"))
+	assert.False(t, a.Equal(c, "amount", "address"))
+	assert.False(t, a.Equal(nil))
+
+	otherType := mp.NewType(mp.NewField("name", mp.SingleLineString()))
+	d := otherType.Parse(map[string]any{"name": "Adam"})
+	assert.False(t, a.Equal(d))
+	assert.True(t, a.Equal(d, "name"))
+	assert.Panics(t, func() { d.Equal(a, "amount") })
+}
@@ -0,0 +1,90 @@
+package mp
+
+import (
+	"errors"
+	"sort"
+)
+
+// Sentinel errors that identify the kind of a conversion failure. Errors returned by the built-in converters match
+// them with errors.Is. The error messages are more specific than the sentinels, e.g. Require fails with "cannot be nil
+// or empty" which matches ErrRequired.
+var (
+	ErrRequired      = errors.New("required")
+	ErrNotANumber    = errors.New("not a number")
+	ErrOutOfRange    = errors.New("out of range")
+	ErrTooShort      = errors.New("too short")
+	ErrTooLong       = errors.New("too long")
+	ErrTooSmall      = errors.New("too small")
+	ErrTooLarge      = errors.New("too large")
+	ErrNotAllowed    = errors.New("not allowed")
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+// kindError is an error with a specific message that matches a sentinel error with errors.Is.
+type kindError struct {
+	message string
+	kind    error
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+func newKindError(kind error, message string) error {
+	return &kindError{message: message, kind: kind}
+}
+
+var (
+	errCannotBeNil         = newKindError(ErrRequired, "cannot be nil")
+	errCannotBeNilOrEmpty  = newKindError(ErrRequired, "cannot be nil or empty")
+	errNotAValidNumber     = newKindError(ErrNotANumber, "not a valid number")
+	errNotANumber          = newKindError(ErrNotANumber, "not a number")
+	errGreaterThanMaxInt   = newKindError(ErrOutOfRange, "greater than maximum allowed number")
+	errLessThanMinInt      = newKindError(ErrOutOfRange, "less than minimum allowed number")
+	errGreaterThanMaxFloat = newKindError(ErrOutOfRange, "greater than maximum allowed float")
+	errLessThanMinFloat    = newKindError(ErrOutOfRange, "less than minimum allowed float")
+	errTooShort            = newKindError(ErrTooShort, "too short")
+	errTooLong             = newKindError(ErrTooLong, "too long")
+	errTooSmall            = newKindError(ErrTooSmall, "too small")
+	errTooLarge            = newKindError(ErrTooLarge, "too large")
+	errNotAllowedValue     = newKindError(ErrNotAllowed, "not allowed value")
+	errInvalidFormat       = newKindError(ErrInvalidFormat, "invalid format")
+	errForbidden           = newKindError(ErrNotAllowed, "is not allowed")
+	errInputTooLarge       = newKindError(ErrTooLarge, "input is too large")
+)
+
+// Unwrap returns the field errors sorted by field name. It allows errors.Is and errors.As to find an error of any
+// field.
+func (e Errors) Unwrap() []error {
+	keys := e.fieldNames()
+	errs := make([]error, len(keys))
+	for i, k := range keys {
+		errs[i] = e[k]
+	}
+	return errs
+}
+
+// fieldNames returns the field names of e in sorted order.
+func (e Errors) fieldNames() []string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Unwrap returns the element errors.
+func (e sliceElementErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, ee := range e {
+		if ee.Err != nil {
+			errs = append(errs, ee.Err)
+		}
+	}
+	return errs
+}
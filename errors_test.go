@@ -0,0 +1,69 @@
+package mp_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	tests := []struct {
+		converter mp.ValueConverter
+		value     any
+		sentinel  error
+	}{
+		{mp.Require(), nil, mp.ErrRequired},
+		{mp.NotNil(), nil, mp.ErrRequired},
+		{mp.Int64(), "abc", mp.ErrNotANumber},
+		{mp.Int64(), true, mp.ErrNotANumber},
+		{mp.Int32(), "3000000000", mp.ErrOutOfRange},
+		{mp.MinLen(3), "ab", mp.ErrTooShort},
+		{mp.MaxLen(3), "abcd", mp.ErrTooLong},
+		{mp.GreaterThan(3), 2, mp.ErrTooSmall},
+		{mp.LessThan(3), 4, mp.ErrTooLarge},
+		{mp.AllowStrings("a"), "b", mp.ErrNotAllowed},
+	}
+
+	for i, tt := range tests {
+		_, err := tt.converter.ConvertValue(tt.value)
+		assert.ErrorIsf(t, err, tt.sentinel, "%d", i)
+	}
+
+	_, err := mp.Require().ConvertValue(nil)
+	assert.EqualError(t, err, "cannot be nil or empty")
+	assert.NotErrorIs(t, err, mp.ErrTooLong)
+}
+
+func TestErrorsUnwrap(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64()),
+		mp.NewField("scores", mp.Slice[int64](mp.Int64()), mp.MaxLen(3)),
+	)
+
+	err := ft.Parse(map[string]any{"age": "abc", "scores": []any{"1", "x"}}).Errors()
+	assert.ErrorIs(t, err, mp.ErrRequired)
+	assert.ErrorIs(t, err, mp.ErrNotANumber)
+	assert.NotErrorIs(t, err, mp.ErrTooLong)
+
+	var errs mp.Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 3)
+
+	err = ft.Parse(map[string]any{"name": "Adam", "scores": []any{"1", "x"}}).Errors()
+	assert.ErrorIs(t, err, mp.ErrNotANumber)
+}
+
+func TestErrorsErrorIsSortedByFieldName(t *testing.T) {
+	errs := mp.Errors{
+		"zip":   errors.New("too long"),
+		"age":   errors.New("not a number"),
+		"email": errors.New("invalid format"),
+	}
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "age not a number, email invalid format, zip too long", errs.Error())
+	}
+}
@@ -0,0 +1,27 @@
+package mp
+
+// Example returns an example input for t built from the first example of each field. A field without an example whose
+// converters include a Type, or a Slice of a Type, is filled with that Type's example. Other fields without an example
+// are omitted. The result can be used in documentation and as a valid base input in tests.
+func (t *Type) Example() map[string]any {
+	example := make(map[string]any, len(t.fields))
+	for _, f := range t.fields {
+		if examples := FieldExamples(f); len(examples) > 0 {
+			example[f.Name()] = examples[0]
+			continue
+		}
+
+		for _, vc := range fieldConverters(f) {
+			switch vc := vc.(type) {
+			case *Type:
+				example[f.Name()] = vc.Example()
+			case sliceConverter:
+				if nt, ok := vc.element().(*Type); ok {
+					example[f.Name()] = []any{nt.Example()}
+				}
+			}
+		}
+	}
+
+	return example
+}
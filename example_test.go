@@ -0,0 +1,41 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldExamples(t *testing.T) {
+	f := mp.NewField("email", mp.SingleLineString())
+	withExample := f.WithExample("a@example.com").WithExample("b@example.com")
+
+	assert.Empty(t, mp.FieldExamples(f))
+	assert.Equal(t, []any{"a@example.com", "b@example.com"}, mp.FieldExamples(withExample))
+}
+
+func TestTypeExample(t *testing.T) {
+	addressType := mp.NewType(
+		mp.NewField("city", mp.SingleLineString(), mp.Require()).WithExample("Dallas"),
+	)
+	ft := mp.NewType(
+		mp.NewField("email", mp.SingleLineString(), mp.Require()).WithExample("a@example.com"),
+		mp.NewField("age", mp.Int64()).WithExample(30),
+		mp.NewField("address", addressType),
+		mp.NewField("previousAddresses", mp.Slice[*mp.Record](addressType)),
+		mp.NewField("notes", mp.String()),
+	)
+
+	example := ft.Example()
+	assert.Equal(t, map[string]any{
+		"email":             "a@example.com",
+		"age":               30,
+		"address":           map[string]any{"city": "Dallas"},
+		"previousAddresses": []any{map[string]any{"city": "Dallas"}},
+	}, example)
+
+	record := ft.Parse(example)
+	require.NoError(t, record.Errors())
+}
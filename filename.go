@@ -0,0 +1,182 @@
+package mp
+
+import (
+	"errors"
+	"path"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// SafeFilenameOptions configures the converter returned by SafeFilenameWithOptions.
+type SafeFilenameOptions struct {
+	// AllowedExtensions are the allowed file extensions including the dot, e.g. ".png". Extensions are matched
+	// case-insensitively. If AllowedExtensions is empty then any extension is allowed.
+	AllowedExtensions []string
+
+	// MaxLen is the maximum length of the file name in bytes. If MaxLen is 0 then 255 is used.
+	MaxLen int
+}
+
+var (
+	errNotAFilename         = newKindError(ErrInvalidFormat, "not a valid file name")
+	errExtensionNotAllowed  = newKindError(ErrNotAllowed, "file extension not allowed")
+	errPathEscapesBase      = newKindError(ErrNotAllowed, "path escapes base directory")
+	errPathMustBeRelative   = newKindError(ErrInvalidFormat, "path must be relative")
+	errPathHasInvalidSymbol = newKindError(ErrInvalidFormat, "path contains invalid characters")
+)
+
+// SafeFilename returns a ValueConverter that converts a client supplied file name into one that is safe to use on
+// common file systems. If value is nil then nil is returned. If value is not a string then an error is returned.
+//
+// It performs the following operations:
+//   - Remove any directory, e.g. "../../etc/passwd" to "passwd" and "C:\Users\a.txt" to "a.txt"
+//   - Remove control characters
+//   - Replace characters that are not allowed on Windows (<>:"|?*) with '_'
+//   - Trim spaces and trailing dots
+//   - Prefix names reserved on Windows such as "CON" and "nul.txt" with '_'
+//
+// It fails if nothing remains or the name is "." or "..".
+func SafeFilename() ValueConverter {
+	return SafeFilenameWithOptions(SafeFilenameOptions{})
+}
+
+// SafeFilenameWithOptions is like SafeFilename but with options.
+func SafeFilenameWithOptions(options SafeFilenameOptions) ValueConverter {
+	c := &safeFilenameValueConverter{maxLen: options.MaxLen}
+	if c.maxLen == 0 {
+		c.maxLen = 255
+	}
+	if len(options.AllowedExtensions) > 0 {
+		c.allowedExtensions = newStringSetWithMatching(options.AllowedExtensions, MatchCaseInsensitive)
+	}
+	return c
+}
+
+type safeFilenameValueConverter struct {
+	allowedExtensions stringSet
+	maxLen            int
+}
+
+func (c *safeFilenameValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	if i := strings.LastIndexAny(s, `/\`); i >= 0 {
+		s = s[i+1:]
+	}
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsControl(r) || r == unicode.ReplacementChar:
+			return -1
+		case strings.ContainsRune(`<>:"|?*`, r):
+			return '_'
+		}
+		return r
+	}, strings.ToValidUTF8(s, ""))
+	s = strings.TrimRight(strings.TrimSpace(s), ". ")
+
+	if s == "" || s == "." || s == ".." {
+		return nil, errNotAFilename
+	}
+	if isReservedFilename(s) {
+		s = "_" + s
+	}
+	if len(s) > c.maxLen {
+		return nil, errTooLong
+	}
+	if c.allowedExtensions.set != nil {
+		if _, ok := c.allowedExtensions.set[c.allowedExtensions.key(path.Ext(s))]; !ok {
+			return nil, errExtensionNotAllowed
+		}
+	}
+
+	return s, nil
+}
+
+func (c *safeFilenameValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf("")
+}
+
+func (c *safeFilenameValueConverter) ConverterParams() map[string]any {
+	params := map[string]any{"maxBytes": c.maxLen}
+	if c.allowedExtensions.set != nil {
+		extensions := make([]string, len(c.allowedExtensions.list))
+		copy(extensions, c.allowedExtensions.list)
+		params["allowExtensions"] = extensions
+	}
+	return params
+}
+
+// isReservedFilename returns true if name is a device name reserved on Windows with or without an extension.
+func isReservedFilename(name string) bool {
+	stem, _, _ := strings.Cut(name, ".")
+	stem = strings.ToUpper(strings.TrimSpace(stem))
+	switch stem {
+	case "CON", "PRN", "AUX", "NUL":
+		return true
+	}
+	if len(stem) != 4 || stem[3] < '1' || stem[3] > '9' {
+		return false
+	}
+	return strings.HasPrefix(stem, "COM") || strings.HasPrefix(stem, "LPT")
+}
+
+// CleanRelPath returns a ValueConverter that converts a client supplied relative path such as an object key to a
+// cleaned path inside base. Backslashes are treated as separators and the result uses forward slashes. It fails if the
+// path is absolute, contains control characters, or refers to a location outside of base such as "../secret". The
+// result is the cleaned path joined to base, e.g. CleanRelPath("uploads") converts "a//b/../c.txt" to
+// "uploads/a/c.txt". If base is "" then the cleaned relative path is returned. If value is nil then nil is returned.
+func CleanRelPath(base string) ValueConverter {
+	return cleanRelPathValueConverter{base: base}
+}
+
+type cleanRelPathValueConverter struct {
+	base string
+}
+
+func (c cleanRelPathValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	for _, r := range s {
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return nil, errPathHasInvalidSymbol
+		}
+	}
+
+	s = strings.ReplaceAll(s, `\`, "/")
+	if strings.HasPrefix(s, "/") || len(s) >= 2 && s[1] == ':' {
+		return nil, errPathMustBeRelative
+	}
+
+	s = path.Clean(s)
+	if s == ".." || strings.HasPrefix(s, "../") {
+		return nil, errPathEscapesBase
+	}
+	if s == "." {
+		return nil, errNotAFilename
+	}
+
+	if c.base != "" {
+		s = path.Join(c.base, s)
+	}
+
+	return s, nil
+}
+
+func (c cleanRelPathValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf("")
+}
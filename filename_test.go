@@ -0,0 +1,109 @@
+package mp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeFilename(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{"report.pdf", "report.pdf", ""},
+		{"../../etc/passwd", "passwd", ""},
+		{`C:\Users\jack\photo.jpg`, "photo.jpg", ""},
+		{"a\x00b\nc.txt", "abc.txt", ""},
+		{`what?<is>"this":*|.txt`, "what__is__this____.txt", ""},
+		{"  notes.txt. . ", "notes.txt", ""},
+		{"CON", "_CON", ""},
+		{"nul.txt", "_nul.txt", ""},
+		{"com1.log", "_com1.log", ""},
+		{"com0.log", "com0.log", ""},
+		{"console.log", "console.log", ""},
+		{"..", nil, "not a valid file name"},
+		{"dir/", nil, "not a valid file name"},
+		{"\x01\x02", nil, "not a valid file name"},
+		{strings.Repeat("a", 256), nil, "too long"},
+		{nil, nil, ""},
+		{42, nil, "not a string"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.SafeFilename().ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+}
+
+func TestSafeFilenameWithOptions(t *testing.T) {
+	vc := mp.SafeFilenameWithOptions(mp.SafeFilenameOptions{AllowedExtensions: []string{".png", ".jpg"}, MaxLen: 12})
+
+	tests := []struct {
+		value    string
+		expected any
+		errMsg   string
+	}{
+		{"photo.png", "photo.png", ""},
+		{"photo.JPG", "photo.JPG", ""},
+		{"a.png.exe", nil, "file extension not allowed"},
+		{"photo", nil, "file extension not allowed"},
+		{"longphoto.png", nil, "too long"},
+	}
+
+	for i, tt := range tests {
+		value, err := vc.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+
+	_, err := vc.ConvertValue("photo.gif")
+	assert.ErrorIs(t, err, mp.ErrNotAllowed)
+}
+
+func TestCleanRelPath(t *testing.T) {
+	tests := []struct {
+		base     string
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{"", "a/b/c.txt", "a/b/c.txt", ""},
+		{"", "a//b/../c.txt", "a/c.txt", ""},
+		{"", `a\b\c.txt`, "a/b/c.txt", ""},
+		{"", "./a/./b", "a/b", ""},
+		{"uploads/user-1", "a/c.txt", "uploads/user-1/a/c.txt", ""},
+		{"uploads", "a/../../b", nil, "path escapes base directory"},
+		{"uploads", "..", nil, "path escapes base directory"},
+		{"", `..\secret`, nil, "path escapes base directory"},
+		{"", "/etc/passwd", nil, "path must be relative"},
+		{"", `C:\Windows`, nil, "path must be relative"},
+		{"", "a\x00b", nil, "path contains invalid characters"},
+		{"", ".", nil, "not a valid file name"},
+		{"", nil, nil, ""},
+		{"", 42, nil, "not a string"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.CleanRelPath(tt.base).ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+}
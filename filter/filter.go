@@ -0,0 +1,243 @@
+// Package filter parses filter parameters for list endpoints.
+//
+// A Definition declares which fields can be filtered, which operators each field supports, and the mp.ValueConverters
+// that convert and validate filter values. Query parameters are written as field[operator]=value. A parameter without
+// an operator uses Eq. For example:
+//
+//	?age[gte]=21&status[in]=active,pending&name[like]=adam
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/jackc/mp"
+)
+
+// Operator is a filter comparison operator.
+type Operator string
+
+const (
+	Eq   Operator = "eq"
+	Ne   Operator = "ne"
+	Lt   Operator = "lt"
+	Lte  Operator = "lte"
+	Gt   Operator = "gt"
+	Gte  Operator = "gte"
+	In   Operator = "in"
+	Like Operator = "like"
+)
+
+// operatorOrder is used to order conditions deterministically.
+var operatorOrder = map[Operator]int{Eq: 0, Ne: 1, Lt: 2, Lte: 3, Gt: 4, Gte: 5, In: 6, Like: 7}
+
+// Field is a filterable field.
+type Field struct {
+	name            string
+	operators       map[Operator]struct{}
+	valueConverters []mp.ValueConverter
+}
+
+// NewField creates a new field with the given name, allowed operators, and valueConverters. valueConverters are
+// applied to each filter value. For the In operator they are applied to each element of the list. Like values are
+// only required to be non-blank strings.
+func NewField(name string, operators []Operator, valueConverters ...mp.ValueConverter) *Field {
+	f := &Field{
+		name:            name,
+		operators:       make(map[Operator]struct{}, len(operators)),
+		valueConverters: valueConverters,
+	}
+	for _, op := range operators {
+		f.operators[op] = struct{}{}
+	}
+	return f
+}
+
+// Name returns the name of the field.
+func (f *Field) Name() string {
+	return f.name
+}
+
+// Allows returns true if op is allowed for f.
+func (f *Field) Allows(op Operator) bool {
+	_, ok := f.operators[op]
+	return ok
+}
+
+func (f *Field) convert(op Operator, raw string) (any, error) {
+	switch op {
+	case In:
+		parts := strings.Split(raw, ",")
+		values := make([]any, 0, len(parts))
+		for _, part := range parts {
+			v, err := f.convertOne(part)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	case Like:
+		s := strings.TrimSpace(raw)
+		if s == "" {
+			return nil, errors.New("cannot be empty")
+		}
+		return s, nil
+	default:
+		return f.convertOne(raw)
+	}
+}
+
+func (f *Field) convertOne(raw string) (any, error) {
+	var v any = raw
+	var err error
+	for _, vc := range f.valueConverters {
+		v, err = vc.ConvertValue(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v == nil {
+		return nil, errors.New("cannot be empty")
+	}
+
+	return v, nil
+}
+
+// Definition is the set of fields that can be filtered.
+type Definition struct {
+	fields       []*Field
+	fieldsByName map[string]*Field
+}
+
+// NewDefinition creates a new Definition with fields.
+func NewDefinition(fields ...*Field) *Definition {
+	d := &Definition{
+		fields:       append([]*Field(nil), fields...),
+		fieldsByName: make(map[string]*Field, len(fields)),
+	}
+	for _, f := range fields {
+		d.fieldsByName[f.name] = f
+	}
+	return d
+}
+
+// Condition is a single validated filter condition.
+type Condition struct {
+	Field    string
+	Operator Operator
+
+	// Value is the converted value. For the In operator it is a []any of converted values.
+	Value any
+}
+
+// Filter is the result of parsing filter parameters.
+type Filter struct {
+	// Conditions are ordered by field definition order and then by operator.
+	Conditions []Condition
+}
+
+// For returns the conditions for the field named name.
+func (f *Filter) For(name string) []Condition {
+	var conditions []Condition
+	for _, c := range f.Conditions {
+		if c.Field == name {
+			conditions = append(conditions, c)
+		}
+	}
+	return conditions
+}
+
+// Parse parses filter parameters from values. Parameters whose field name is not in d are ignored so the same query
+// string can contain other parameters such as pagination (e.g. "page[size]"). If any filter is invalid then the error is an mp.Errors
+// keyed by the parameter name (e.g. "age[gte]").
+func (d *Definition) Parse(values url.Values) (*Filter, error) {
+	filter := &Filter{}
+	errs := mp.Errors{}
+
+	for key, rawValues := range values {
+		f, ok := d.fieldsByName[keyName(key)]
+		if !ok {
+			continue
+		}
+
+		name, op, ok := parseKey(key)
+		if !ok {
+			errs[key] = errors.New("invalid filter")
+			continue
+		}
+
+		if !f.Allows(op) {
+			errs[key] = fmt.Errorf("operator %s is not allowed", op)
+			continue
+		}
+
+		for _, raw := range rawValues {
+			value, err := f.convert(op, raw)
+			if err != nil {
+				errs[key] = err
+				break
+			}
+			filter.Conditions = append(filter.Conditions, Condition{Field: name, Operator: op, Value: value})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	fieldOrder := make(map[string]int, len(d.fields))
+	for i, f := range d.fields {
+		fieldOrder[f.name] = i
+	}
+	sort.SliceStable(filter.Conditions, func(i, j int) bool {
+		a, b := filter.Conditions[i], filter.Conditions[j]
+		if a.Field != b.Field {
+			return fieldOrder[a.Field] < fieldOrder[b.Field]
+		}
+		return operatorOrder[a.Operator] < operatorOrder[b.Operator]
+	})
+
+	return filter, nil
+}
+
+// ParseQuery parses filter parameters from a URL query string.
+func (d *Definition) ParseQuery(query string) (*Filter, error) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Parse(values)
+}
+
+// keyName returns the field name part of a key of the form name[op].
+func keyName(key string) string {
+	if open := strings.IndexByte(key, '['); open != -1 {
+		return key[:open]
+	}
+	return key
+}
+
+// parseKey splits a key of the form name[op] into name and op. A key without brackets uses Eq.
+func parseKey(key string) (name string, op Operator, ok bool) {
+	open := strings.IndexByte(key, '[')
+	if open == -1 {
+		return key, Eq, true
+	}
+
+	if !strings.HasSuffix(key, "]") || open == 0 {
+		return "", "", false
+	}
+
+	op = Operator(key[open+1 : len(key)-1])
+	if _, ok := operatorOrder[op]; !ok {
+		return "", "", false
+	}
+
+	return key[:open], op, true
+}
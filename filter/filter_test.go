@@ -0,0 +1,68 @@
+package filter_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDefinition() *filter.Definition {
+	return filter.NewDefinition(
+		filter.NewField("age", []filter.Operator{filter.Eq, filter.Lt, filter.Gte}, mp.Int32()),
+		filter.NewField("status", []filter.Operator{filter.Eq, filter.In}, mp.SingleLineString(), mp.AllowStrings("active", "pending", "closed")),
+		filter.NewField("name", []filter.Operator{filter.Like}),
+	)
+}
+
+func TestDefinitionParseQuery(t *testing.T) {
+	d := newDefinition()
+
+	f, err := d.ParseQuery("name[like]=adam&age[gte]=21&status[in]=active,pending&age[lt]=65&page=2&page[size]=10&sort[]=name")
+	require.NoError(t, err)
+
+	assert.Equal(t, []filter.Condition{
+		{Field: "age", Operator: filter.Lt, Value: int32(65)},
+		{Field: "age", Operator: filter.Gte, Value: int32(21)},
+		{Field: "status", Operator: filter.In, Value: []any{"active", "pending"}},
+		{Field: "name", Operator: filter.Like, Value: "adam"},
+	}, f.Conditions)
+
+	assert.Equal(t, []filter.Condition{{Field: "name", Operator: filter.Like, Value: "adam"}}, f.For("name"))
+}
+
+func TestDefinitionParseDefaultOperator(t *testing.T) {
+	d := newDefinition()
+
+	f, err := d.Parse(url.Values{"age": {"30"}})
+	require.NoError(t, err)
+	assert.Equal(t, []filter.Condition{{Field: "age", Operator: filter.Eq, Value: int32(30)}}, f.Conditions)
+}
+
+func TestDefinitionParseErrors(t *testing.T) {
+	d := newDefinition()
+
+	tests := []struct {
+		query string
+		key   string
+	}{
+		{"age[gte]=abc", "age[gte]"},
+		{"age[like]=3", "age[like]"},
+		{"status[in]=active,deleted", "status[in]"},
+		{"age[between]=1", "age[between]"},
+		{"name[like]=%20", "name[like]"},
+		{"age=", "age"},
+	}
+
+	for i, tt := range tests {
+		f, err := d.ParseQuery(tt.query)
+		assert.Nilf(t, f, "%d", i)
+		var errs mp.Errors
+		if assert.ErrorAsf(t, err, &errs, "%d", i) {
+			assert.Containsf(t, errs, tt.key, "%d", i)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package mp
+
+import "fmt"
+
+// FormField is the state of a single field for re-rendering an HTML form.
+type FormField struct {
+	Name string
+
+	// Value is the value to echo back in the form. It is the original input if present so the user sees what they
+	// entered even if it was invalid. Otherwise, it is the converted value (e.g. from Default). nil is rendered as an
+	// empty string.
+	Value string
+
+	// Converted is the converted value. It is nil if the field has an error.
+	Converted any
+
+	// Error is the error message. It is empty if the field has no error.
+	Error string
+
+	// HasError is true if the field has an error.
+	HasError bool
+}
+
+// Form is a Record prepared for re-rendering an HTML form with html/template.
+type Form struct {
+	// Fields contains a FormField for each field of the Type in field order.
+	Fields []FormField
+
+	// BaseError is the error message for errors that do not belong to a specific field. It is empty if there is no
+	// such error.
+	BaseError string
+
+	fieldIndexes map[string]int
+}
+
+// NewForm returns a Form for r.
+func NewForm(r *Record) *Form {
+	form := &Form{
+		Fields:       make([]FormField, len(r.t.fields)),
+		fieldIndexes: r.t.fieldIndexes,
+	}
+
+	for i, f := range r.t.fields {
+		name := f.Name()
+		ff := FormField{Name: name, Converted: r.values[i].value}
+
+		if original, ok := r.original[name]; ok {
+			ff.Value = formValue(original)
+		} else {
+			ff.Value = formValue(ff.Converted)
+		}
+
+		if err, ok := r.errors[name]; ok {
+			ff.Error = err.Error()
+			ff.HasError = true
+			ff.Converted = nil
+		}
+
+		form.Fields[i] = ff
+	}
+
+	if err, ok := r.errors[BaseErrorKey]; ok {
+		if _, isField := r.t.fieldIndexes[BaseErrorKey]; !isField {
+			form.BaseError = err.Error()
+		}
+	}
+
+	return form
+}
+
+// Field returns the FormField named name. It panics if name is not a field of the Type. It is intended to be called
+// from a template, e.g. {{with .Form.Field "email"}}{{.Value}}{{end}}.
+func (f *Form) Field(name string) FormField {
+	idx, ok := f.fieldIndexes[name]
+	if !ok {
+		panic(fmt.Errorf("%q is not a field of type", name))
+	}
+	return f.Fields[idx]
+}
+
+// HasErrors returns true if any field has an error or if there is a base error.
+func (f *Form) HasErrors() bool {
+	if f.BaseError != "" {
+		return true
+	}
+	for _, ff := range f.Fields {
+		if ff.HasError {
+			return true
+		}
+	}
+	return false
+}
+
+func formValue(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []string:
+		if len(value) > 0 {
+			return value[0]
+		}
+		return ""
+	case fmt.Stringer:
+		return value.String()
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,49 @@
+package mp_test
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewForm(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64()),
+		mp.NewField("role", mp.SingleLineString(), mp.Default("member")),
+	)
+
+	form := mp.NewForm(ft.Parse(map[string]any{"name": " Adam ", "age": "abc"}))
+	assert.True(t, form.HasErrors())
+	assert.Equal(t, []mp.FormField{
+		{Name: "name", Value: " Adam ", Converted: "Adam"},
+		{Name: "age", Value: "abc", Error: "not a valid number", HasError: true},
+		{Name: "role", Value: "member", Converted: "member"},
+	}, form.Fields)
+	assert.Equal(t, "not a valid number", form.Field("age").Error)
+	assert.Panics(t, func() { form.Field("missing") })
+
+	tmpl := template.Must(template.New("form").Parse(
+		`{{with .Field "age"}}<input name="age" value="{{.Value}}">{{if .HasError}}<span>{{.Error}}</span>{{end}}{{end}}`,
+	))
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, form))
+	assert.Equal(t, `<input name="age" value="abc"><span>not a valid number</span>`, buf.String())
+}
+
+func TestNewFormBaseError(t *testing.T) {
+	ft := mp.NewType(mp.NewField("name", mp.SingleLineString()))
+	ft.AfterParse(func(r *mp.Record) error {
+		return errors.New("something went wrong")
+	})
+
+	form := mp.NewForm(ft.Parse(map[string]any{"name": "Adam"}))
+	assert.True(t, form.HasErrors())
+	assert.Equal(t, "something went wrong", form.BaseError)
+	assert.False(t, form.Field("name").HasError)
+}
@@ -0,0 +1,226 @@
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/shopspring/decimal"
+)
+
+// generateAttempts is the number of times Generate tries to produce input that t parses without errors.
+const generateAttempts = 100
+
+// Generate returns random input that t parses without errors. Values are generated from the converted type of each
+// field and respect the parameters reported by ConverterParamser such as allowStrings, minLen, maxLen, lessThan,
+// greaterThan, and time formats. Field examples are used when present. Fields that are not required are sometimes
+// omitted.
+//
+// Constraints that cannot be inferred from parameters such as Matches or custom converters are satisfied by retrying.
+// An error is returned if no valid input is found after a number of attempts or if the parameters cannot be satisfied
+// such as a negative maxLen.
+func Generate(t *Type, rng *rand.Rand) (map[string]any, error) {
+	var err error
+	for i := 0; i < generateAttempts; i++ {
+		var attrs map[string]any
+		attrs, err = generateAttrs(t, rng)
+		if err != nil {
+			return nil, err
+		}
+		err = t.Parse(attrs).Errors()
+		if err == nil {
+			return attrs, nil
+		}
+	}
+	return nil, fmt.Errorf("could not generate valid attrs after %d attempts: %w", generateAttempts, err)
+}
+
+func generateAttrs(t *Type, rng *rand.Rand) (map[string]any, error) {
+	attrs := make(map[string]any, len(t.fields))
+	for _, f := range t.fields {
+		if !FieldIsRequired(f) && rng.Intn(5) == 0 {
+			continue
+		}
+
+		if examples := FieldExamples(f); len(examples) > 0 {
+			attrs[f.Name()] = examples[rng.Intn(len(examples))]
+			continue
+		}
+
+		sf := describeField(f)
+		value, err := generateValue(rng, sf.typer, sf.params)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		if value != nil {
+			attrs[f.Name()] = value
+		}
+	}
+	return attrs, nil
+}
+
+func generateValue(rng *rand.Rand, typer ValueConverter, params map[string]any) (any, error) {
+	if items, ok := params["allowStrings"].([]string); ok && len(items) > 0 {
+		return items[rng.Intn(len(items))], nil
+	}
+
+	switch vc := typer.(type) {
+	case *Type:
+		return generateAttrs(vc, rng)
+	case sliceConverter:
+		minLen, maxLen, err := generateLenRange(params, 0, 3)
+		if err != nil {
+			return nil, err
+		}
+		n := minLen + rng.Intn(maxLen-minLen+1)
+		element := vc.element()
+		var elementParams map[string]any
+		if cp, ok := element.(ConverterParamser); ok {
+			elementParams = cp.ConverterParams()
+		}
+		values := make([]any, n)
+		for i := range values {
+			values[i], err = generateValue(rng, element, elementParams)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return values, nil
+	case ConvertedTyper:
+		return generateScalar(rng, vc.ConvertedType(), params)
+	}
+
+	return nil, nil
+}
+
+func generateScalar(rng *rand.Rand, t reflect.Type, params map[string]any) (any, error) {
+	switch t {
+	case timeType:
+		format := time.RFC3339
+		if formats, ok := params["formats"].([]string); ok && len(formats) > 0 {
+			format = formats[rng.Intn(len(formats))]
+		}
+		start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		return start.Add(time.Duration(rng.Int63n(int64(30 * 365 * 24 * time.Hour)))).Format(format), nil
+	case uuidType:
+		var u uuid.UUID
+		rng.Read(u[:])
+		u.SetVersion(uuid.V4)
+		u.SetVariant(uuid.VariantRFC4122)
+		return u.String(), nil
+	case decimalType:
+		return generateNumber(rng, params, false).String(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return generateNumber(rng, params, true).IntPart(), nil
+	case reflect.Float32, reflect.Float64:
+		f, _ := generateNumber(rng, params, false).Float64()
+		return f, nil
+	case reflect.Bool:
+		return rng.Intn(2) == 0, nil
+	case reflect.String:
+		minLen, maxLen, err := generateLenRange(params, 1, 12)
+		if err != nil {
+			return nil, err
+		}
+		return generateString(rng, minLen+rng.Intn(maxLen-minLen+1)), nil
+	case reflect.Pointer:
+		return generateScalar(rng, t.Elem(), params)
+	}
+
+	return nil, nil
+}
+
+var errGenerateNegativeLen = errors.New("cannot generate a value with a negative maxLen")
+
+// generateLenRange returns the length range allowed by the minLen and maxLen params. defaultMin and defaultMax are used
+// when a limit is not present. An error is returned if maxLen is negative.
+func generateLenRange(params map[string]any, defaultMin, defaultMax int) (int, int, error) {
+	minLen, maxLen := defaultMin, defaultMax
+	if n, ok := params["minLen"].(int); ok {
+		minLen = n
+		if maxLen < minLen {
+			maxLen = minLen + defaultMax
+		}
+	}
+	if n, ok := params["maxLen"].(int); ok {
+		maxLen = n
+		if maxLen < 0 {
+			return 0, 0, errGenerateNegativeLen
+		}
+		if minLen > maxLen {
+			minLen = maxLen
+		}
+	}
+	if minLen < 0 {
+		minLen = 0
+	}
+	return minLen, maxLen, nil
+}
+
+// generateNumber returns a random number allowed by the comparison params.
+func generateNumber(rng *rand.Rand, params map[string]any, integer bool) decimal.Decimal {
+	step := decimal.New(1, -2)
+	if integer {
+		step = decimal.NewFromInt(1)
+	}
+
+	low, hasLow := decimal.Zero, false
+	if n, ok := params["greaterThanOrEqual"].(decimal.Decimal); ok {
+		low, hasLow = n, true
+	}
+	if n, ok := params["greaterThan"].(decimal.Decimal); ok {
+		low, hasLow = n.Add(step), true
+	}
+	high, hasHigh := decimal.Zero, false
+	if n, ok := params["lessThanOrEqual"].(decimal.Decimal); ok {
+		high, hasHigh = n, true
+	}
+	if n, ok := params["lessThan"].(decimal.Decimal); ok {
+		high, hasHigh = n.Sub(step), true
+	}
+
+	switch {
+	case !hasLow && !hasHigh:
+		high = decimal.NewFromInt(1000)
+	case !hasLow:
+		low = high.Sub(decimal.NewFromInt(1000))
+	case !hasHigh:
+		high = low.Add(decimal.NewFromInt(1000))
+	}
+
+	if integer {
+		low = low.Ceil()
+		high = high.Floor()
+	}
+	if high.LessThanOrEqual(low) {
+		return low
+	}
+
+	// The number of steps may not fit in an int64 (e.g. the full range of Int64). In that case a random uint64 reduced
+	// modulo the number of choices is used instead of Int63n.
+	choices := high.Sub(low).Div(step).Floor().Add(decimal.NewFromInt(1))
+	if choices.LessThanOrEqual(decimal.NewFromInt(math.MaxInt64)) {
+		return low.Add(step.Mul(decimal.NewFromInt(rng.Int63n(choices.IntPart()))))
+	}
+	n := decimal.NewFromBigInt(new(big.Int).SetUint64(rng.Uint64()), 0).Mod(choices)
+	return low.Add(step.Mul(n))
+}
+
+const generateAlphabet = "abcdefghijklmnopqrstuvwxyz"
+
+func generateString(rng *rand.Rand, n int) string {
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = generateAlphabet[rng.Intn(len(generateAlphabet))]
+	}
+	return string(buf)
+}
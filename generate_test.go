@@ -0,0 +1,91 @@
+package mp_test
+
+import (
+	"math"
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	addressType := mp.NewType(
+		mp.NewField("city", mp.SingleLineString(), mp.Require(), mp.MaxLen(20)),
+		mp.NewField("zip", mp.SingleLineString(), mp.Matches(regexp.MustCompile(`^[a-z]`))),
+	)
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require(), mp.MinLen(3), mp.MaxLen(5)),
+		mp.NewField("status", mp.SingleLineString(), mp.Require(), mp.AllowStrings("active", "inactive")),
+		mp.NewField("age", mp.Int32(), mp.Require(), mp.GreaterThanOrEqual(18), mp.LessThan(21)),
+		mp.NewField("score", mp.Float64(), mp.Require(), mp.GreaterThan(0), mp.LessThanOrEqual(1)),
+		mp.NewField("balance", mp.Decimal(), mp.Require(), mp.LessThan(0)),
+		mp.NewField("admin", mp.Bool()),
+		mp.NewField("id", mp.UUID(), mp.Require()),
+		mp.NewField("born", mp.Time("2006-01-02"), mp.Require()),
+		mp.NewField("email", mp.SingleLineString(), mp.Require()).WithExample("a@example.com"),
+		mp.NewField("address", addressType, mp.Require()),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString()), mp.Require(), mp.MinLen(1), mp.MaxLen(2)),
+	)
+
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		attrs, err := mp.Generate(ft, rng)
+		require.NoErrorf(t, err, "%d", i)
+		record := ft.Parse(attrs)
+		require.NoErrorf(t, record.Errors(), "%d: %v", i, attrs)
+		assert.Equalf(t, "a@example.com", record.Get("email"), "%d", i)
+		assert.Containsf(t, []int32{18, 19, 20}, record.Get("age"), "%d", i)
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64()),
+	)
+
+	a, err := mp.Generate(ft, rand.New(rand.NewSource(42)))
+	require.NoError(t, err)
+	b, err := mp.Generate(ft, rand.New(rand.NewSource(42)))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+}
+
+func TestGenerateFullInt64Range(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("n", mp.Int64(), mp.Require(), mp.GreaterThanOrEqual(math.MinInt64), mp.LessThanOrEqual(math.MaxInt64)),
+		mp.NewField("d", mp.Decimal(), mp.Require(), mp.GreaterThan(math.MinInt64), mp.LessThan(math.MaxInt64)),
+	)
+
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		attrs, err := mp.Generate(ft, rng)
+		require.NoErrorf(t, err, "%d", i)
+		require.NoErrorf(t, ft.Parse(attrs).Errors(), "%d: %v", i, attrs)
+	}
+}
+
+func TestGenerateNegativeMaxLen(t *testing.T) {
+	tests := []mp.Field{
+		mp.NewField("name", mp.SingleLineString(), mp.Require(), mp.MaxLen(-1)),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString()), mp.Require(), mp.MaxLen(-1)),
+	}
+
+	for i, f := range tests {
+		_, err := mp.Generate(mp.NewType(f), rand.New(rand.NewSource(0)))
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestGenerateUnsatisfiable(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("code", mp.SingleLineString(), mp.Require(), mp.Matches(regexp.MustCompile(`^[0-9]{40}$`))),
+	)
+
+	attrs, err := mp.Generate(ft, rand.New(rand.NewSource(0)))
+	assert.EqualError(t, err, "could not generate valid attrs after 100 attempts: code invalid format")
+	assert.Nil(t, attrs)
+}
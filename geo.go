@@ -0,0 +1,189 @@
+package mp
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// GeoPoint is a geographic coordinate in decimal degrees.
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// String returns p in "lat,lng" format as accepted by LatLng.
+func (p GeoPoint) String() string {
+	return strconv.FormatFloat(p.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.Lng, 'f', -1, 64)
+}
+
+var (
+	errNotAGeoPoint        = newKindError(ErrInvalidFormat, "not a valid coordinate")
+	errLatitudeOutOfRange  = newKindError(ErrOutOfRange, "latitude must be between -90 and 90")
+	errLongitudeOutOfRange = newKindError(ErrOutOfRange, "longitude must be between -180 and 180")
+	errOutsideArea         = newKindError(ErrNotAllowed, "outside of allowed area")
+)
+
+// LatLng returns a ValueConverter that converts value to a GeoPoint. value may be a GeoPoint, a string in "lat,lng"
+// format, or a map with "lat" and "lng" keys. "latitude", "longitude", and "lon" are also accepted as keys. The
+// latitude must be between -90 and 90 and the longitude must be between -180 and 180. If value is nil or a blank string
+// nil is returned.
+func LatLng() ValueConverter {
+	return latLngValueConverter{}
+}
+
+type latLngValueConverter struct{}
+
+func (c latLngValueConverter) ConvertValue(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var p GeoPoint
+	switch value := value.(type) {
+	case GeoPoint:
+		p = value
+	case string:
+		latStr, lngStr, ok := strings.Cut(value, ",")
+		if !ok {
+			return nil, errNotAGeoPoint
+		}
+		var err1, err2 error
+		p.Lat, err1 = strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+		p.Lng, err2 = strconv.ParseFloat(strings.TrimSpace(lngStr), 64)
+		if err1 != nil || err2 != nil {
+			return nil, errNotAGeoPoint
+		}
+	case map[string]any:
+		lat, ok1 := geoCoordinate(value, "lat", "latitude")
+		lng, ok2 := geoCoordinate(value, "lng", "lon", "longitude")
+		if !ok1 || !ok2 {
+			return nil, errNotAGeoPoint
+		}
+		p = GeoPoint{Lat: lat, Lng: lng}
+	default:
+		return nil, errNotAGeoPoint
+	}
+
+	if !(p.Lat >= -90 && p.Lat <= 90) {
+		return nil, errLatitudeOutOfRange
+	}
+	if !(p.Lng >= -180 && p.Lng <= 180) {
+		return nil, errLongitudeOutOfRange
+	}
+
+	return p, nil
+}
+
+func (c latLngValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf(GeoPoint{})
+}
+
+// geoCoordinate returns the value of the first of keys in m as a float64.
+func geoCoordinate(m map[string]any, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		if v, ok := m[key]; ok {
+			f, err := convertFloat64(v)
+			return f, err == nil
+		}
+	}
+	return 0, false
+}
+
+// WithinBoundingBox returns a ValueConverter that returns an error unless value is a GeoPoint inside the box from min,
+// the south-west corner, to max, the north-east corner. Points on the edge are inside. If min.Lng is greater than
+// max.Lng then the box crosses the antimeridian. It should be used after LatLng. If value is nil then nil is returned.
+func WithinBoundingBox(min, max GeoPoint) ValueConverter {
+	return withinBoundingBoxValueConverter{min: min, max: max}
+}
+
+type withinBoundingBoxValueConverter struct {
+	min GeoPoint
+	max GeoPoint
+}
+
+func (c withinBoundingBoxValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	p, ok := value.(GeoPoint)
+	if !ok {
+		return nil, fmt.Errorf("cannot compare %T to a bounding box", value)
+	}
+
+	if p.Lat < c.min.Lat || p.Lat > c.max.Lat {
+		return nil, errOutsideArea
+	}
+	if c.min.Lng <= c.max.Lng {
+		if p.Lng < c.min.Lng || p.Lng > c.max.Lng {
+			return nil, errOutsideArea
+		}
+	} else if p.Lng < c.min.Lng && p.Lng > c.max.Lng {
+		return nil, errOutsideArea
+	}
+
+	return value, nil
+}
+
+func (c withinBoundingBoxValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"withinBoundingBox": []GeoPoint{c.min, c.max}}
+}
+
+// WithinPolygon returns a ValueConverter that returns an error unless value is a GeoPoint inside the polygon with
+// vertices points. The polygon is closed automatically and must have at least 3 points. Edges are straight lines in
+// latitude and longitude which is accurate enough for service areas the size of a city or region. Polygons that cross
+// the antimeridian are not supported. It should be used after LatLng. If value is nil then nil is returned.
+func WithinPolygon(points []GeoPoint) ValueConverter {
+	if len(points) < 3 {
+		panic("polygon must have at least 3 points")
+	}
+	polygon := make([]GeoPoint, len(points))
+	copy(polygon, points)
+	return withinPolygonValueConverter{points: polygon}
+}
+
+type withinPolygonValueConverter struct {
+	points []GeoPoint
+}
+
+func (c withinPolygonValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	p, ok := value.(GeoPoint)
+	if !ok {
+		return nil, fmt.Errorf("cannot compare %T to a polygon", value)
+	}
+
+	if !polygonContains(c.points, p) {
+		return nil, errOutsideArea
+	}
+
+	return value, nil
+}
+
+func (c withinPolygonValueConverter) ConverterParams() map[string]any {
+	points := make([]GeoPoint, len(c.points))
+	copy(points, c.points)
+	return map[string]any{"withinPolygon": points}
+}
+
+// polygonContains returns true if p is inside polygon using the even-odd rule.
+func polygonContains(polygon []GeoPoint, p GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) {
+			lng := a.Lng + (p.Lat-a.Lat)*(b.Lng-a.Lng)/(b.Lat-a.Lat)
+			if p.Lng < lng {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
@@ -0,0 +1,111 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatLng(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{"41.8781,-87.6298", mp.GeoPoint{Lat: 41.8781, Lng: -87.6298}, ""},
+		{" 41.8781 , -87.6298 ", mp.GeoPoint{Lat: 41.8781, Lng: -87.6298}, ""},
+		{map[string]any{"lat": 41.8781, "lng": "-87.6298"}, mp.GeoPoint{Lat: 41.8781, Lng: -87.6298}, ""},
+		{map[string]any{"latitude": 1, "longitude": 2}, mp.GeoPoint{Lat: 1, Lng: 2}, ""},
+		{map[string]any{"lat": 1, "lon": 2}, mp.GeoPoint{Lat: 1, Lng: 2}, ""},
+		{mp.GeoPoint{Lat: 1, Lng: 2}, mp.GeoPoint{Lat: 1, Lng: 2}, ""},
+		{"", nil, ""},
+		{nil, nil, ""},
+		{"41.8781", nil, "not a valid coordinate"},
+		{"abc,def", nil, "not a valid coordinate"},
+		{map[string]any{"lat": 1}, nil, "not a valid coordinate"},
+		{map[string]any{"lat": "x", "lng": 1}, nil, "not a valid coordinate"},
+		{42, nil, "not a valid coordinate"},
+		{"91,0", nil, "latitude must be between -90 and 90"},
+		{"0,-181", nil, "longitude must be between -180 and 180"},
+		{"NaN,0", nil, "latitude must be between -90 and 90"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.LatLng().ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+
+	assert.Equal(t, "41.8781,-87.6298", mp.GeoPoint{Lat: 41.8781, Lng: -87.6298}.String())
+}
+
+func TestWithinBoundingBox(t *testing.T) {
+	chicago := mp.WithinBoundingBox(mp.GeoPoint{Lat: 41.6, Lng: -87.95}, mp.GeoPoint{Lat: 42.05, Lng: -87.5})
+	fiji := mp.WithinBoundingBox(mp.GeoPoint{Lat: -21, Lng: 176}, mp.GeoPoint{Lat: -12, Lng: -178})
+
+	tests := []struct {
+		vc     mp.ValueConverter
+		value  any
+		errMsg string
+	}{
+		{chicago, mp.GeoPoint{Lat: 41.8781, Lng: -87.6298}, ""},
+		{chicago, mp.GeoPoint{Lat: 41.6, Lng: -87.95}, ""},
+		{chicago, mp.GeoPoint{Lat: 40.7128, Lng: -74.006}, "outside of allowed area"},
+		{chicago, mp.GeoPoint{Lat: 41.8781, Lng: -88}, "outside of allowed area"},
+		{fiji, mp.GeoPoint{Lat: -18, Lng: 178}, ""},
+		{fiji, mp.GeoPoint{Lat: -18, Lng: -179}, ""},
+		{fiji, mp.GeoPoint{Lat: -18, Lng: 0}, "outside of allowed area"},
+		{chicago, nil, ""},
+		{chicago, "41,-87", "cannot compare string to a bounding box"},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.vc.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.value, value, "%d", i)
+	}
+}
+
+func TestWithinPolygon(t *testing.T) {
+	// An L shaped service area.
+	area := []mp.GeoPoint{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 1, Lng: 2}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 1}, {Lat: 2, Lng: 0}}
+
+	ft := mp.NewType(
+		mp.NewField("location", mp.LatLng(), mp.WithinPolygon(area)),
+	)
+
+	tests := []struct {
+		value  any
+		errMsg string
+	}{
+		{"0.5,0.5", ""},
+		{"0.5,1.5", ""},
+		{"1.5,0.5", ""},
+		{"1.5,1.5", "location outside of allowed area"},
+		{"-0.5,0.5", "location outside of allowed area"},
+		{"0.5,2.5", "location outside of allowed area"},
+		{nil, ""},
+	}
+
+	for i, tt := range tests {
+		record := ft.Parse(map[string]any{"location": tt.value})
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, record.Errors(), tt.errMsg, "%d", i)
+			assert.ErrorIsf(t, record.Errors(), mp.ErrNotAllowed, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, record.Errors(), "%d", i)
+	}
+
+	assert.Panics(t, func() { mp.WithinPolygon(area[:2]) })
+}
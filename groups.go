@@ -0,0 +1,133 @@
+package mp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldGroupKind is the rule a FieldGroup applies to its fields.
+type FieldGroupKind int
+
+const (
+	// AtLeastOne requires at least one field of the group to be present.
+	AtLeastOne FieldGroupKind = iota
+
+	// ExactlyOne requires exactly one field of the group to be present.
+	ExactlyOne
+
+	// AllOrNone requires either all or none of the fields of the group to be present.
+	AllOrNone
+)
+
+// FieldGroup is a requirement that applies to a group of fields rather than a single field. A field is present if its
+// converted value is not nil or an empty string. FieldGroups are configured with TypeOptions.FieldGroups.
+type FieldGroup struct {
+	Kind   FieldGroupKind
+	Fields []string
+}
+
+// AtLeastOneOf returns a FieldGroup that requires at least one of fields to be present.
+func AtLeastOneOf(fields ...string) FieldGroup {
+	return FieldGroup{Kind: AtLeastOne, Fields: fields}
+}
+
+// ExactlyOneOf returns a FieldGroup that requires exactly one of fields to be present.
+func ExactlyOneOf(fields ...string) FieldGroup {
+	return FieldGroup{Kind: ExactlyOne, Fields: fields}
+}
+
+// AllOrNoneOf returns a FieldGroup that requires either all or none of fields to be present.
+func AllOrNoneOf(fields ...string) FieldGroup {
+	return FieldGroup{Kind: AllOrNone, Fields: fields}
+}
+
+// Key returns the key of the group's error in Errors. It is the names of the fields joined with commas.
+func (g FieldGroup) Key() string {
+	return strings.Join(g.Fields, ",")
+}
+
+// validate returns a *GroupError if the group is not satisfied. present reports whether a field is present.
+func (g FieldGroup) validate(present func(name string) bool) error {
+	var presentFields []string
+	for _, name := range g.Fields {
+		if present(name) {
+			presentFields = append(presentFields, name)
+		}
+	}
+
+	var ok bool
+	switch g.Kind {
+	case AtLeastOne:
+		ok = len(presentFields) > 0
+	case ExactlyOne:
+		ok = len(presentFields) == 1
+	case AllOrNone:
+		ok = len(presentFields) == 0 || len(presentFields) == len(g.Fields)
+	}
+	if ok {
+		return nil
+	}
+
+	return &GroupError{Group: g, Present: presentFields}
+}
+
+// GroupError is the error recorded when a FieldGroup is not satisfied.
+type GroupError struct {
+	Group FieldGroup
+
+	// Present is the names of the fields of the group that were present.
+	Present []string
+}
+
+func (e *GroupError) Error() string {
+	fields := strings.Join(e.Group.Fields, ", ")
+	switch e.Group.Kind {
+	case AtLeastOne:
+		return fmt.Sprintf("at least one of %s is required", fields)
+	case ExactlyOne:
+		return fmt.Sprintf("exactly one of %s is required", fields)
+	case AllOrNone:
+		return fmt.Sprintf("all or none of %s are required", fields)
+	}
+	return fmt.Sprintf("invalid field group %s", fields)
+}
+
+// Is returns true if target is ErrRequired and a field is missing or target is ErrNotAllowed and too many fields are
+// present.
+func (e *GroupError) Is(target error) bool {
+	switch target {
+	case ErrRequired:
+		return e.Group.Kind != ExactlyOne || len(e.Present) == 0
+	case ErrNotAllowed:
+		return e.Group.Kind == ExactlyOne && len(e.Present) > 1
+	}
+	return false
+}
+
+// isPresentValue returns true if value counts as present for a FieldGroup.
+func isPresentValue(value any) bool {
+	return value != nil && value != ""
+}
+
+// validateFieldGroups adds an error to errs for each group of t that is not satisfied. Groups that include a field
+// that already has an error are skipped. It returns errs or a new Errors if errs was nil.
+func (t *Type) validateFieldGroups(errs Errors, present func(name string) bool) Errors {
+groups:
+	for _, g := range t.options.FieldGroups {
+		for _, name := range g.Fields {
+			if _, ok := errs[name]; ok {
+				continue groups
+			}
+		}
+
+		err := g.validate(present)
+		if err != nil {
+			if errs == nil {
+				errs = make(Errors)
+			}
+			errs[g.Key()] = err
+		}
+	}
+
+	return errs
+}
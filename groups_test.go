@@ -0,0 +1,102 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldGroups(t *testing.T) {
+	ft := mp.NewTypeWithOptions(
+		mp.TypeOptions{FieldGroups: []mp.FieldGroup{
+			mp.AtLeastOneOf("email", "phone"),
+			mp.ExactlyOneOf("card_id", "bank_id"),
+			mp.AllOrNoneOf("street", "city", "zip"),
+		}},
+		mp.NewField("email", mp.SingleLineString()),
+		mp.NewField("phone", mp.SingleLineString()),
+		mp.NewField("card_id", mp.Int64()),
+		mp.NewField("bank_id", mp.Int64()),
+		mp.NewField("street", mp.SingleLineString()),
+		mp.NewField("city", mp.SingleLineString()),
+		mp.NewField("zip", mp.SingleLineString()),
+	)
+
+	tests := []struct {
+		attrs map[string]any
+		errs  map[string]string
+	}{
+		{map[string]any{"email": "a@example.com", "card_id": "1"}, nil},
+		{map[string]any{"phone": "555", "bank_id": "1", "street": "1 Main", "city": "Dallas", "zip": "75001"}, nil},
+		{
+			map[string]any{"email": " ", "card_id": "1", "bank_id": "2", "street": "1 Main"},
+			map[string]string{
+				"email,phone":     "at least one of email, phone is required",
+				"card_id,bank_id": "exactly one of card_id, bank_id is required",
+				"street,city,zip": "all or none of street, city, zip are required",
+			},
+		},
+		{map[string]any{"email": "a@example.com"}, map[string]string{"card_id,bank_id": "exactly one of card_id, bank_id is required"}},
+		{map[string]any{"email": "a@example.com", "card_id": "abc"}, map[string]string{"card_id": "not a valid number"}},
+	}
+
+	for i, tt := range tests {
+		record := ft.Parse(tt.attrs)
+		if tt.errs == nil {
+			assert.NoErrorf(t, record.Errors(), "%d", i)
+			continue
+		}
+
+		errs, ok := record.Errors().(mp.Errors)
+		require.Truef(t, ok, "%d", i)
+		actual := make(map[string]string, len(errs))
+		for k, v := range errs {
+			actual[k] = v.Error()
+		}
+		assert.Equalf(t, tt.errs, actual, "%d", i)
+	}
+}
+
+func TestGroupErrorIs(t *testing.T) {
+	ft := mp.NewTypeWithOptions(
+		mp.TypeOptions{FieldGroups: []mp.FieldGroup{mp.ExactlyOneOf("a", "b")}},
+		mp.NewField("a", mp.Int64()),
+		mp.NewField("b", mp.Int64()),
+	)
+
+	err := ft.Parse(map[string]any{}).Errors()
+	assert.ErrorIs(t, err, mp.ErrRequired)
+	assert.NotErrorIs(t, err, mp.ErrNotAllowed)
+
+	err = ft.Parse(map[string]any{"a": 1, "b": 2}).Errors()
+	assert.ErrorIs(t, err, mp.ErrNotAllowed)
+	assert.NotErrorIs(t, err, mp.ErrRequired)
+
+	var groupErr *mp.GroupError
+	require.ErrorAs(t, err, &groupErr)
+	assert.Equal(t, []string{"a", "b"}, groupErr.Present)
+}
+
+func TestFieldGroupsPartialAndCheck(t *testing.T) {
+	ft := mp.NewTypeWithOptions(
+		mp.TypeOptions{FieldGroups: []mp.FieldGroup{mp.AtLeastOneOf("email", "phone")}},
+		mp.NewField("email", mp.SingleLineString()),
+		mp.NewField("phone", mp.SingleLineString()),
+		mp.NewField("name", mp.SingleLineString()),
+	)
+
+	assert.NoError(t, ft.ParsePartial(map[string]any{"name": "Adam"}).Errors())
+	assert.Error(t, ft.Check(map[string]any{"name": "Adam"}))
+	assert.NoError(t, ft.Check(map[string]any{"phone": "555"}))
+}
+
+func TestFieldGroupsPanicsOnUnknownField(t *testing.T) {
+	assert.PanicsWithError(t, `"missing" is not a field of type`, func() {
+		mp.NewTypeWithOptions(
+			mp.TypeOptions{FieldGroups: []mp.FieldGroup{mp.AtLeastOneOf("a", "missing")}},
+			mp.NewField("a"),
+		)
+	})
+}
@@ -0,0 +1,170 @@
+package mp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DuplicateKeyError is returned by DecodeJSON when an object contains the same key more than once.
+type DuplicateKeyError struct {
+	// Paths are the JSON Pointers (RFC 6901) of the duplicated keys in the order they were found.
+	Paths []string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate keys: %s", strings.Join(e.Paths, ", "))
+}
+
+// JSONOptions configures DecodeJSONWithOptions.
+type JSONOptions struct {
+	// UseNumber decodes numbers as json.Number instead of float64. This preserves large integers and high precision
+	// decimals that cannot be represented exactly by a float64. The numeric converters such as Int64 and Decimal
+	// convert json.Number directly.
+	UseNumber bool
+
+	// AllowDuplicateKeys disables duplicate key detection. The last of duplicated keys is used as with encoding/json.
+	AllowDuplicateKeys bool
+
+	// Decoder decodes the input. If it is nil then StandardJSONDecoder is used.
+	Decoder JSONDecoder
+}
+
+// JSONDecoder decodes a JSON object into a map[string]any. It allows an alternative JSON implementation to be used by
+// DecodeJSONWithOptions and the packages built on it. An implementation should honor the UseNumber and
+// AllowDuplicateKeys fields of options where the underlying implementation supports them. The Decoder field of options
+// should be ignored.
+type JSONDecoder interface {
+	DecodeJSONObject(r io.Reader, options JSONOptions) (map[string]any, error)
+}
+
+// JSONDecoderFunc is a function that implements the JSONDecoder interface.
+type JSONDecoderFunc func(r io.Reader, options JSONOptions) (map[string]any, error)
+
+// DecodeJSONObject implements the JSONDecoder interface.
+func (f JSONDecoderFunc) DecodeJSONObject(r io.Reader, options JSONOptions) (map[string]any, error) {
+	return f(r, options)
+}
+
+// StandardJSONDecoder is the JSONDecoder based on encoding/json. It supports all JSONOptions.
+var StandardJSONDecoder JSONDecoder = JSONDecoderFunc(decodeStandardJSON)
+
+// DecodeJSON decodes a JSON object from r into a map[string]any suitable for Type.Parse. Unlike encoding/json, which
+// silently keeps the last of duplicated keys, DecodeJSON returns a *DuplicateKeyError with the paths of all duplicated
+// keys. Duplicate keys can be used to bypass validation when different layers of a system disagree on which value is
+// used. The input must contain a single JSON object and nothing else except white space. Numbers are decoded as
+// float64. Use DecodeJSONWithOptions to decode numbers as json.Number.
+func DecodeJSON(r io.Reader) (map[string]any, error) {
+	return DecodeJSONWithOptions(r, JSONOptions{})
+}
+
+// DecodeJSONWithOptions is like DecodeJSON but with options.
+func DecodeJSONWithOptions(r io.Reader, options JSONOptions) (map[string]any, error) {
+	decoder := options.Decoder
+	if decoder == nil {
+		decoder = StandardJSONDecoder
+	}
+	return decoder.DecodeJSONObject(r, options)
+}
+
+func decodeStandardJSON(r io.Reader, options JSONOptions) (map[string]any, error) {
+	d := &jsonDecoder{decoder: json.NewDecoder(r), allowDuplicateKeys: options.AllowDuplicateKeys}
+	if options.UseNumber {
+		d.decoder.UseNumber()
+	}
+
+	t, err := d.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if t != json.Delim('{') {
+		return nil, errors.New("JSON value is not an object")
+	}
+
+	m, err := d.decodeObject(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := d.decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON object")
+	}
+
+	if d.duplicates != nil {
+		return nil, &DuplicateKeyError{Paths: d.duplicates}
+	}
+
+	return m, nil
+}
+
+type jsonDecoder struct {
+	decoder            *json.Decoder
+	allowDuplicateKeys bool
+	duplicates         []string
+}
+
+// decodeObject decodes the members of an object whose opening delimiter has been read.
+func (d *jsonDecoder) decodeObject(path []string) (map[string]any, error) {
+	m := make(map[string]any)
+	for d.decoder.More() {
+		t, err := d.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := t.(string)
+		keyPath := append(path[:len(path):len(path)], key)
+
+		value, err := d.decodeValue(keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := m[key]; ok && !d.allowDuplicateKeys {
+			d.duplicates = append(d.duplicates, jsonPointer(keyPath))
+		}
+		m[key] = value
+	}
+
+	// Read the closing delimiter.
+	_, err := d.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (d *jsonDecoder) decodeValue(path []string) (any, error) {
+	t, err := d.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		return d.decodeObject(path)
+	case json.Delim('['):
+		var elements []any
+		for i := 0; d.decoder.More(); i++ {
+			element, err := d.decodeValue(append(path[:len(path):len(path)], strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element)
+		}
+		// Read the closing delimiter.
+		_, err := d.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if elements == nil {
+			elements = []any{}
+		}
+		return elements, nil
+	}
+
+	return t, nil
+}
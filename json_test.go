@@ -0,0 +1,149 @@
+package mp_test
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	m, err := mp.DecodeJSON(strings.NewReader(`{"name": "Adam", "age": 30, "admin": false, "note": null, "tags": ["a", "b"], "empty": [], "address": {"city": "Dallas"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":    "Adam",
+		"age":     float64(30),
+		"admin":   false,
+		"note":    nil,
+		"tags":    []any{"a", "b"},
+		"empty":   []any{},
+		"address": map[string]any{"city": "Dallas"},
+	}, m)
+}
+
+func TestDecodeJSONDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		input string
+		paths []string
+	}{
+		{`{"role": "user", "role": "admin"}`, []string{"/role"}},
+		{`{"a": {"b": 1, "b": 2}, "c": [{"d": 1, "d": 2}]}`, []string{"/a/b", "/c/0/d"}},
+		{`{"a/b": 1, "a/b": 2}`, []string{"/a~1b"}},
+	}
+
+	for i, tt := range tests {
+		_, err := mp.DecodeJSON(strings.NewReader(tt.input))
+		var dupErr *mp.DuplicateKeyError
+		require.ErrorAsf(t, err, &dupErr, "%d", i)
+		assert.Equalf(t, tt.paths, dupErr.Paths, "%d", i)
+	}
+
+	_, err := mp.DecodeJSON(strings.NewReader(`{"role": "user", "role": "admin"}`))
+	assert.EqualError(t, err, "duplicate keys: /role")
+}
+
+func TestDecodeJSONErrors(t *testing.T) {
+	inputs := []string{
+		``,
+		`[1, 2]`,
+		`"a"`,
+		`{"a": 1`,
+		`{"a": 1} {"b": 2}`,
+		`{"a": }`,
+	}
+
+	for i, input := range inputs {
+		_, err := mp.DecodeJSON(strings.NewReader(input))
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestDecodeJSONUseNumber(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("id", mp.Int64()),
+		mp.NewField("amount", mp.Decimal()),
+		mp.NewField("ratio", mp.Float64()),
+	)
+
+	m, err := mp.DecodeJSONWithOptions(
+		strings.NewReader(`{"id": 9007199254740993, "amount": 12345678901234567890.123456789, "ratio": 0.5}`),
+		mp.JSONOptions{UseNumber: true},
+	)
+	require.NoError(t, err)
+
+	record := ft.Parse(m)
+	require.NoError(t, record.Errors())
+	assert.Equal(t, int64(9007199254740993), record.Get("id"))
+	assert.Equal(t, "12345678901234567890.123456789", record.Get("amount").(decimal.Decimal).String())
+	assert.Equal(t, 0.5, record.Get("ratio"))
+}
+
+func TestJSONNumberConversion(t *testing.T) {
+	tests := []struct {
+		converter mp.ValueConverter
+		input     json.Number
+		expected  any
+		errMsg    string
+	}{
+		{mp.Int64(), "42", int64(42), ""},
+		{mp.Int64(), "-9223372036854775808", int64(math.MinInt64), ""},
+		{mp.Int64(), "1e3", int64(1000), ""},
+		{mp.Int64(), "30.0", int64(30), ""},
+		{mp.Int64(), "1.5", nil, "not a valid number"},
+		{mp.Int64(), "9223372036854775808", nil, "greater than maximum allowed number"},
+		{mp.Int64(), "-1e30", nil, "less than minimum allowed number"},
+		{mp.Int32(), "2147483648", nil, "greater than maximum allowed number"},
+		{mp.Float64(), "1.25", 1.25, ""},
+		{mp.Float32(), "1.25", float32(1.25), ""},
+		{mp.Decimal(), "0.1", decimal.RequireFromString("0.1"), ""},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.converter.ConvertValue(tt.input)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		if d, ok := tt.expected.(decimal.Decimal); ok {
+			assert.Truef(t, d.Equal(value.(decimal.Decimal)), "%d", i)
+		} else {
+			assert.Equalf(t, tt.expected, value, "%d", i)
+		}
+	}
+
+	_, err := mp.LessThan(10).ConvertValue(json.Number("12"))
+	assert.Error(t, err)
+}
+
+func TestDecodeJSONAllowDuplicateKeys(t *testing.T) {
+	m, err := mp.DecodeJSONWithOptions(strings.NewReader(`{"role": "user", "role": "admin"}`), mp.JSONOptions{AllowDuplicateKeys: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"role": "admin"}, m)
+}
+
+func TestDecodeJSONCustomDecoder(t *testing.T) {
+	var receivedOptions mp.JSONOptions
+	decoder := mp.JSONDecoderFunc(func(r io.Reader, options mp.JSONOptions) (map[string]any, error) {
+		receivedOptions = options
+		var m map[string]any
+		err := json.NewDecoder(r).Decode(&m)
+		return m, err
+	})
+
+	options := mp.JSONOptions{UseNumber: true, Decoder: decoder}
+	m, err := mp.DecodeJSONWithOptions(strings.NewReader(`{"a": "b"}`), options)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "b"}, m)
+	assert.True(t, receivedOptions.UseNumber)
+
+	m, err = mp.StandardJSONDecoder.DecodeJSONObject(strings.NewReader(`{"n": 1}`), mp.JSONOptions{UseNumber: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"n": json.Number("1")}, m)
+}
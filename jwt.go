@@ -0,0 +1,216 @@
+package mp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JWTOptions configures the JWT converter.
+type JWTOptions struct {
+	// KeyFunc returns the key used to verify a token with the given algorithm and key ID (kid header). The key must be a
+	// []byte for HS256, HS384, and HS512 and a *rsa.PublicKey for RS256, RS384, and RS512. KeyFunc is required.
+	KeyFunc func(alg, kid string) (any, error)
+
+	// Audience is the required audience. If Audience is not empty the aud claim must be or contain it.
+	Audience string
+
+	// Leeway is the allowed clock skew when checking the exp and nbf claims.
+	Leeway time.Duration
+
+	// Clock is used to check the exp and nbf claims. If Clock is nil then the Clock of the context passed to ParseCtx
+	// or DefaultClock is used.
+	Clock Clock
+}
+
+// JWT returns a ValueConverter that verifies a compact serialized JSON Web Token and returns its claims as a
+// map[string]any. The exp and nbf claims are checked if present. Numeric claims are returned as json.Number. The
+// claims can be further validated by following JWT with a Type. If value is nil or a blank string nil is returned.
+func JWT(options JWTOptions) ValueConverter {
+	if options.KeyFunc == nil {
+		panic("JWTOptions.KeyFunc is required")
+	}
+	return &jwtValueConverter{options: options}
+}
+
+type jwtValueConverter struct {
+	options JWTOptions
+}
+
+var errInvalidJWT = errors.New("not a valid token")
+
+func (c *jwtValueConverter) ConvertValue(value any) (any, error) {
+	return c.ConvertValueContext(context.Background(), value)
+}
+
+func (c *jwtValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errInvalidJWT
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidJWT
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	err := decodeJWTSegment(parts[0], &header)
+	if err != nil {
+		return nil, errInvalidJWT
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidJWT
+	}
+
+	key, err := c.options.KeyFunc(header.Alg, header.Kid)
+	if err != nil {
+		return nil, errInvalidJWT
+	}
+
+	err = verifyJWTSignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature)
+	if err != nil {
+		return nil, errInvalidJWT
+	}
+
+	var claims map[string]any
+	err = decodeJWTSegment(parts[1], &claims)
+	if err != nil || claims == nil {
+		return nil, errInvalidJWT
+	}
+
+	now := currentTime(ctx, c.options.Clock)
+	if exp, ok := claims["exp"]; ok {
+		t, err := jwtTime(exp)
+		if err != nil {
+			return nil, errInvalidJWT
+		}
+		if now.After(t.Add(c.options.Leeway)) {
+			return nil, errors.New("token has expired")
+		}
+	}
+	if nbf, ok := claims["nbf"]; ok {
+		t, err := jwtTime(nbf)
+		if err != nil {
+			return nil, errInvalidJWT
+		}
+		if now.Before(t.Add(-c.options.Leeway)) {
+			return nil, errors.New("token is not valid yet")
+		}
+	}
+
+	if c.options.Audience != "" && !jwtHasAudience(claims["aud"], c.options.Audience) {
+		return nil, errors.New("token has wrong audience")
+	}
+
+	return claims, nil
+}
+
+func (c *jwtValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf(map[string]any{})
+}
+
+func decodeJWTSegment(segment string, v any) error {
+	buf, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func verifyJWTSignature(alg string, key any, signingInput, signature []byte) error {
+	if len(alg) != 5 {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	var hashFunc func() hash.Hash
+	var cryptoHash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hashFunc, cryptoHash = sha256.New, crypto.SHA256
+	case "384":
+		hashFunc, cryptoHash = sha512.New384, crypto.SHA384
+	case "512":
+		hashFunc, cryptoHash = sha512.New, crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("%s requires []byte key", alg)
+		}
+		mac := hmac.New(hashFunc, secret)
+		mac.Write(signingInput)
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case "RS":
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires *rsa.PublicKey key", alg)
+		}
+		h := hashFunc()
+		h.Write(signingInput)
+		return rsa.VerifyPKCS1v15(publicKey, cryptoHash, h.Sum(nil), signature)
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+func jwtTime(value any) (time.Time, error) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, errors.New("not a number")
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, int64(f*float64(time.Second))), nil
+}
+
+func jwtHasAudience(aud any, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []any:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
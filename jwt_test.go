@@ -0,0 +1,124 @@
+package mp_test
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signJWT(t *testing.T, alg string, key any, claims map[string]any) string {
+	header, err := json.Marshal(map[string]any{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch key := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signingInput))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWT(t *testing.T) {
+	secret := []byte("secret")
+	jwt := mp.JWT(mp.JWTOptions{
+		KeyFunc: func(alg, kid string) (any, error) {
+			if alg != "HS256" {
+				return nil, errors.New("unexpected algorithm")
+			}
+			return secret, nil
+		},
+		Audience: "api",
+	})
+
+	claimsType := mp.NewType(
+		mp.NewField("sub", mp.SingleLineString(), mp.Require()),
+		mp.NewField("exp", mp.Int64()),
+	)
+	ft := mp.NewType(mp.NewField("token", jwt, claimsType, mp.Require()))
+
+	exp := time.Now().Add(time.Hour).Unix()
+	token := signJWT(t, "HS256", secret, map[string]any{"sub": "user-1", "exp": exp, "aud": []string{"api", "web"}})
+	record := ft.Parse(map[string]any{"token": token})
+	require.NoError(t, record.Errors())
+	claims := record.Get("token").(*mp.Record)
+	assert.Equal(t, "user-1", claims.Get("sub"))
+	assert.Equal(t, exp, claims.Get("exp"))
+
+	tests := []struct {
+		token string
+		err   string
+	}{
+		{signJWT(t, "HS256", []byte("other"), map[string]any{"sub": "user-1", "aud": "api"}), "not a valid token"},
+		{signJWT(t, "HS256", secret, map[string]any{"sub": "user-1", "aud": "api", "exp": time.Now().Add(-time.Hour).Unix()}), "token has expired"},
+		{signJWT(t, "HS256", secret, map[string]any{"sub": "user-1", "aud": "api", "nbf": time.Now().Add(time.Hour).Unix()}), "token is not valid yet"},
+		{signJWT(t, "HS256", secret, map[string]any{"sub": "user-1", "aud": "web"}), "token has wrong audience"},
+		{signJWT(t, "HS256", secret, map[string]any{"sub": "user-1"}), "token has wrong audience"},
+		{"abc.def", "not a valid token"},
+		{"abc.def.ghi", "not a valid token"},
+	}
+
+	for i, tt := range tests {
+		_, err := jwt.ConvertValue(tt.token)
+		assert.EqualErrorf(t, err, tt.err, "%d", i)
+	}
+}
+
+func TestJWTRSA(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwt := mp.JWT(mp.JWTOptions{
+		KeyFunc: func(alg, kid string) (any, error) {
+			return &privateKey.PublicKey, nil
+		},
+	})
+
+	claims, err := jwt.ConvertValue(signJWT(t, "RS256", privateKey, map[string]any{"sub": "user-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sub": "user-1"}, claims)
+
+	// A token signed with HMAC must not be accepted when the key is an RSA public key.
+	_, err = jwt.ConvertValue(signJWT(t, "HS256", []byte("secret"), map[string]any{"sub": "user-1"}))
+	require.EqualError(t, err, "not a valid token")
+}
+
+func TestJWTClock(t *testing.T) {
+	secret := []byte("secret")
+	frozen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	keyFunc := func(alg, kid string) (any, error) { return secret, nil }
+	token := signJWT(t, "HS256", secret, map[string]any{"sub": "user-1", "exp": frozen.Unix()})
+
+	_, err := mp.JWT(mp.JWTOptions{KeyFunc: keyFunc, Clock: mp.FixedClock(frozen)}).ConvertValue(token)
+	require.NoError(t, err)
+
+	_, err = mp.JWT(mp.JWTOptions{KeyFunc: keyFunc, Clock: mp.FixedClock(frozen.Add(time.Second))}).ConvertValue(token)
+	require.EqualError(t, err, "token has expired")
+
+	tp := mp.NewTypeWithOptions(mp.TypeOptions{Clock: mp.FixedClock(frozen)},
+		mp.NewField("token", mp.JWT(mp.JWTOptions{KeyFunc: keyFunc})),
+	)
+	record := tp.Parse(map[string]any{"token": token})
+	require.NoError(t, record.Errors())
+}
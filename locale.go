@@ -0,0 +1,157 @@
+package mp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DateOrder is the order of the day, month, and year in a date entered by a user.
+type DateOrder int
+
+const (
+	DateOrderYMD DateOrder = iota // e.g. 2024-03-31
+	DateOrderDMY                  // e.g. 31/03/2024
+	DateOrderMDY                  // e.g. 03/31/2024
+)
+
+func (o DateOrder) String() string {
+	switch o {
+	case DateOrderYMD:
+		return "YMD"
+	case DateOrderDMY:
+		return "DMY"
+	case DateOrderMDY:
+		return "MDY"
+	}
+	return fmt.Sprintf("DateOrder(%d)", int(o))
+}
+
+// dateOrderConverters are the date converters of each DateOrder. ISO 8601 dates are accepted by every order.
+var dateOrderConverters = map[DateOrder]ValueConverter{
+	DateOrderYMD: Time("2006-1-2", "2006/1/2", "2006.1.2"),
+	DateOrderDMY: Time("2006-1-2", "2/1/2006", "2.1.2006", "2-1-2006"),
+	DateOrderMDY: Time("2006-1-2", "1/2/2006", "1-2-2006"),
+}
+
+// Locale describes the conventions of the user or tenant that input is parsed for. It is stored in a context with
+// ContextWithLocale and used by the Locale converters such as LocaleDate when parsing with ParseCtx. This allows the
+// same Type to be used for every locale. A Locale must not be modified after it is stored in a context.
+type Locale struct {
+	// DateOrder is the order of dates parsed by LocaleDate.
+	DateOrder DateOrder
+
+	// NumberFormat is the format of numbers parsed by LocaleInt64, LocaleFloat64, and LocaleDecimal.
+	NumberFormat NumberFormat
+
+	// Countries are the country codes allowed by LocaleCountry. Codes are matched case-insensitively. If Countries is
+	// empty then any country is allowed.
+	Countries []string
+}
+
+// defaultLocale is used when a context has no Locale.
+var defaultLocale = &Locale{}
+
+type localeContextKey struct{}
+
+// ContextWithLocale returns a copy of ctx with locale.
+func ContextWithLocale(ctx context.Context, locale *Locale) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the Locale of ctx. If ctx has no Locale then the zero Locale is returned. It uses ISO 8601
+// dates and plain numbers and allows any country.
+func LocaleFromContext(ctx context.Context) *Locale {
+	if locale, ok := FromContext[*Locale](ctx, localeContextKey{}); ok && locale != nil {
+		return locale
+	}
+	return defaultLocale
+}
+
+// Contextual returns a ValueConverter that converts value with the converter returned by selectConverter for the
+// context passed to ParseCtx. It allows the behavior of a field to vary by request, such as by tenant or locale, rather
+// than being fixed when the Type is constructed. ConvertValue selects with context.Background.
+func Contextual(selectConverter func(ctx context.Context) ValueConverter) ValueConverter {
+	return &contextualValueConverter{selectConverter: selectConverter, convertedType: anyType}
+}
+
+type contextualValueConverter struct {
+	selectConverter func(ctx context.Context) ValueConverter
+	convertedType   reflect.Type
+}
+
+func (c *contextualValueConverter) ConvertValue(value any) (any, error) {
+	return c.ConvertValueContext(context.Background(), value)
+}
+
+func (c *contextualValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	return convertValueContext(ctx, c.selectConverter(ctx), value)
+}
+
+func (c *contextualValueConverter) ConvertedType() reflect.Type {
+	return c.convertedType
+}
+
+// LocaleDate returns a ValueConverter that converts value to a time.Time using the DateOrder of the Locale of the
+// context. ISO 8601 dates such as "2024-03-31" are accepted for every DateOrder. If value is nil or a blank string nil
+// is returned.
+func LocaleDate() ValueConverter {
+	return &contextualValueConverter{
+		selectConverter: func(ctx context.Context) ValueConverter {
+			if vc, ok := dateOrderConverters[LocaleFromContext(ctx).DateOrder]; ok {
+				return vc
+			}
+			return dateOrderConverters[DateOrderYMD]
+		},
+		convertedType: reflect.TypeOf(time.Time{}),
+	}
+}
+
+// LocaleInt64 is like Int64WithFormat but uses the NumberFormat of the Locale of the context.
+func LocaleInt64() ValueConverter {
+	return &contextualValueConverter{
+		selectConverter: func(ctx context.Context) ValueConverter {
+			return Int64WithFormat(LocaleFromContext(ctx).NumberFormat)
+		},
+		convertedType: reflect.TypeOf(int64(0)),
+	}
+}
+
+// LocaleFloat64 is like Float64WithFormat but uses the NumberFormat of the Locale of the context.
+func LocaleFloat64() ValueConverter {
+	return &contextualValueConverter{
+		selectConverter: func(ctx context.Context) ValueConverter {
+			return Float64WithFormat(LocaleFromContext(ctx).NumberFormat)
+		},
+		convertedType: reflect.TypeOf(float64(0)),
+	}
+}
+
+// LocaleDecimal is like DecimalWithFormat but uses the NumberFormat of the Locale of the context.
+func LocaleDecimal() ValueConverter {
+	return &contextualValueConverter{
+		selectConverter: func(ctx context.Context) ValueConverter {
+			return DecimalWithFormat(LocaleFromContext(ctx).NumberFormat)
+		},
+		convertedType: reflect.TypeOf(decimal.Decimal{}),
+	}
+}
+
+// LocaleCountry returns a ValueConverter that returns an error unless value is one of the Countries of the Locale of
+// the context. Matching is case-insensitive and the value is canonicalized to the spelling in Countries. It should be
+// used after a string converter. If value is nil then nil is returned.
+func LocaleCountry() ValueConverter {
+	return &contextualValueConverter{
+		selectConverter: func(ctx context.Context) ValueConverter {
+			countries := LocaleFromContext(ctx).Countries
+			if len(countries) == 0 {
+				return ValueConverterFunc(func(value any) (any, error) { return value, nil })
+			}
+			return AllowStringsWithOptions(StringSetOptions{Matching: MatchCaseInsensitive, Canonicalize: true}, countries...)
+		},
+		convertedType: reflect.TypeOf(""),
+	}
+}
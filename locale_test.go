@@ -0,0 +1,124 @@
+package mp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocaleConverters(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("date", mp.LocaleDate()),
+		mp.NewField("quantity", mp.LocaleInt64()),
+		mp.NewField("weight", mp.LocaleFloat64()),
+		mp.NewField("price", mp.LocaleDecimal()),
+		mp.NewField("country", mp.SingleLineString(), mp.LocaleCountry()),
+	)
+
+	us := &mp.Locale{DateOrder: mp.DateOrderMDY, NumberFormat: mp.NumberFormatEnglish, Countries: []string{"US", "CA"}}
+	de := &mp.Locale{DateOrder: mp.DateOrderDMY, NumberFormat: mp.NumberFormatEuropean, Countries: []string{"DE", "AT"}}
+
+	tests := []struct {
+		locale   *mp.Locale
+		attrs    map[string]any
+		expected map[string]any
+		errMsg   string
+	}{
+		{
+			locale: us,
+			attrs:  map[string]any{"date": "03/31/2024", "quantity": "1,000", "weight": "1.5", "price": "$1,234.56", "country": "us"},
+			expected: map[string]any{
+				"date":     time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+				"quantity": int64(1000),
+				"weight":   1.5,
+				"price":    decimal.RequireFromString("1234.56"),
+				"country":  "US",
+			},
+		},
+		{
+			locale: de,
+			attrs:  map[string]any{"date": "31.03.2024", "quantity": "1.000", "weight": "1,5", "price": "1.234,56 €", "country": "at"},
+			expected: map[string]any{
+				"date":     time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+				"quantity": int64(1000),
+				"weight":   1.5,
+				"price":    decimal.RequireFromString("1234.56"),
+				"country":  "AT",
+			},
+		},
+		{
+			locale: nil,
+			attrs:  map[string]any{"date": "2024-03-31", "quantity": "1000", "weight": "1.5", "price": "1234.56", "country": "FR"},
+			expected: map[string]any{
+				"date":     time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+				"quantity": int64(1000),
+				"weight":   1.5,
+				"price":    decimal.RequireFromString("1234.56"),
+				"country":  "FR",
+			},
+		},
+		{
+			locale: us,
+			attrs:  map[string]any{"date": "31/03/2024"},
+			errMsg: "date not a valid time",
+		},
+		{
+			locale: de,
+			attrs:  map[string]any{"country": "US"},
+			errMsg: "country not allowed value",
+		},
+	}
+
+	for i, tt := range tests {
+		ctx := context.Background()
+		if tt.locale != nil {
+			ctx = mp.ContextWithLocale(ctx, tt.locale)
+		}
+		record := ft.ParseCtx(ctx, tt.attrs)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, record.Errors(), tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, record.Errors(), "%d", i)
+		for k, v := range tt.expected {
+			if d, ok := v.(decimal.Decimal); ok {
+				assert.Truef(t, d.Equal(record.Get(k).(decimal.Decimal)), "%d: %s", i, k)
+				continue
+			}
+			assert.Equalf(t, v, record.Get(k), "%d: %s", i, k)
+		}
+	}
+}
+
+func TestLocaleFromContext(t *testing.T) {
+	assert.Equal(t, mp.DateOrderYMD, mp.LocaleFromContext(context.Background()).DateOrder)
+
+	locale := &mp.Locale{DateOrder: mp.DateOrderDMY}
+	assert.Same(t, locale, mp.LocaleFromContext(mp.ContextWithLocale(context.Background(), locale)))
+	assert.Equal(t, "DMY", mp.DateOrderDMY.String())
+}
+
+type planKey struct{}
+
+func TestContextual(t *testing.T) {
+	vc := mp.Contextual(func(ctx context.Context) mp.ValueConverter {
+		if plan, _ := mp.FromContext[string](ctx, planKey{}); plan == "pro" {
+			return mp.MaxLen(10)
+		}
+		return mp.MaxLen(3)
+	})
+
+	ft := mp.NewType(mp.NewField("name", mp.SingleLineString(), vc))
+	attrs := map[string]any{"name": "abcdef"}
+
+	record := ft.ParseCtx(context.WithValue(context.Background(), planKey{}, "pro"), attrs)
+	require.NoError(t, record.Errors())
+
+	record = ft.Parse(attrs)
+	assert.EqualError(t, record.Errors(), "name too long")
+}
@@ -0,0 +1,55 @@
+package mp
+
+import "time"
+
+// Metrics receives measurements of the fields converted by Parse, ParsePartial, and ParseCtx. It allows monitoring
+// which fields users struggle with and which converters are slow. Set it with TypeOptions.Metrics. Use
+// NewPrometheusMetrics to record the measurements with Prometheus. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveField is called after the converters of a field have been applied. typeName is TypeOptions.Name. code is
+	// "" if the field was converted successfully. Otherwise, it is the code of the error as returned by
+	// FieldError.Code such as "required". Fields that are skipped, such as missing optional fields, are not observed.
+	ObserveField(typeName, field, code string, duration time.Duration)
+}
+
+// MetricsFunc is a function that implements the Metrics interface.
+type MetricsFunc func(typeName, field, code string, duration time.Duration)
+
+// ObserveField implements the Metrics interface.
+func (f MetricsFunc) ObserveField(typeName, field, code string, duration time.Duration) {
+	f(typeName, field, code, duration)
+}
+
+// CounterVec is the subset of *prometheus.CounterVec used by NewPrometheusMetrics. C is prometheus.Counter.
+type CounterVec[C interface{ Inc() }] interface {
+	WithLabelValues(lvs ...string) C
+}
+
+// ObserverVec is the subset of *prometheus.HistogramVec and *prometheus.SummaryVec used by NewPrometheusMetrics. O is
+// prometheus.Observer.
+type ObserverVec[O interface{ Observe(float64) }] interface {
+	WithLabelValues(lvs ...string) O
+}
+
+// NewPrometheusMetrics returns Metrics that record measurements with Prometheus collectors without depending on the
+// Prometheus client library. attempts must have the labels "type" and "field". failures must have the labels "type",
+// "field", and "code". durations must have the labels "type" and "field" and observes seconds. Any of them may be nil.
+// For example:
+//
+//	attempts := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "mp_field_attempts_total"}, []string{"type", "field"})
+//	failures := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "mp_field_failures_total"}, []string{"type", "field", "code"})
+//	durations := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "mp_field_duration_seconds"}, []string{"type", "field"})
+//	metrics := mp.NewPrometheusMetrics[prometheus.Counter, prometheus.Observer](attempts, failures, durations)
+func NewPrometheusMetrics[C interface{ Inc() }, O interface{ Observe(float64) }](attempts, failures CounterVec[C], durations ObserverVec[O]) Metrics {
+	return MetricsFunc(func(typeName, field, code string, duration time.Duration) {
+		if attempts != nil {
+			attempts.WithLabelValues(typeName, field).Inc()
+		}
+		if failures != nil && code != "" {
+			failures.WithLabelValues(typeName, field, code).Inc()
+		}
+		if durations != nil {
+			durations.WithLabelValues(typeName, field).Observe(duration.Seconds())
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package mp_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldObservation struct {
+	typeName string
+	field    string
+	code     string
+}
+
+func TestTypeOptionsMetrics(t *testing.T) {
+	var observations []fieldObservation
+	metrics := mp.MetricsFunc(func(typeName, field, code string, duration time.Duration) {
+		assert.GreaterOrEqual(t, duration, time.Duration(0))
+		observations = append(observations, fieldObservation{typeName, field, code})
+	})
+
+	ft := mp.NewTypeWithOptions(
+		mp.TypeOptions{Name: "user", Metrics: metrics},
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64()),
+		mp.NewField("nickname", mp.Optional(mp.SingleLineString())),
+	)
+
+	record := ft.Parse(map[string]any{"name": "Jack", "age": "abc"})
+	require.Error(t, record.Errors())
+
+	record = ft.Parse(map[string]any{"age": 30, "nickname": "J"})
+	require.Error(t, record.Errors())
+
+	assert.Equal(t, []fieldObservation{
+		{"user", "name", ""},
+		{"user", "age", "not_a_number"},
+		{"user", "name", "required"},
+		{"user", "age", ""},
+		{"user", "nickname", ""},
+	}, observations)
+}
+
+type fakeCounter struct {
+	mux    *sync.Mutex
+	counts map[string]int
+	key    string
+}
+
+func (c fakeCounter) Inc() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.counts[c.key]++
+}
+
+func (c fakeCounter) Observe(float64) {
+	c.Inc()
+}
+
+type fakeVec struct {
+	mux    sync.Mutex
+	counts map[string]int
+}
+
+func (v *fakeVec) WithLabelValues(lvs ...string) fakeCounter {
+	return fakeCounter{mux: &v.mux, counts: v.counts, key: strings.Join(lvs, "/")}
+}
+
+func TestNewPrometheusMetrics(t *testing.T) {
+	attempts := &fakeVec{counts: map[string]int{}}
+	failures := &fakeVec{counts: map[string]int{}}
+	durations := &fakeVec{counts: map[string]int{}}
+
+	ft := mp.NewTypeWithOptions(
+		mp.TypeOptions{Name: "user", Metrics: mp.NewPrometheusMetrics[fakeCounter, fakeCounter](attempts, failures, durations)},
+		mp.NewField("age", mp.Int64()),
+	)
+
+	ft.Parse(map[string]any{"age": 30})
+	ft.Parse(map[string]any{"age": "abc"})
+
+	assert.Equal(t, map[string]int{"user/age": 2}, attempts.counts)
+	assert.Equal(t, map[string]int{"user/age/not_a_number": 1}, failures.counts)
+	assert.Equal(t, map[string]int{"user/age": 2}, durations.counts)
+
+	metrics := mp.NewPrometheusMetrics[fakeCounter, fakeCounter](attempts, nil, nil)
+	metrics.ObserveField("user", "name", "required", time.Millisecond)
+	assert.Equal(t, 1, attempts.counts["user/name"])
+}
@@ -11,15 +11,20 @@
 package mp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/shopspring/decimal"
@@ -28,9 +33,139 @@ import (
 // Type is a type that can be used to convert a map[string]any to a Record.
 //
 // It implements the ValueConverter interface so it can be used to build nested structs.
+//
+// A Type is immutable after construction except for registering AfterParse hooks. It is safe for concurrent use by
+// multiple goroutines.
 type Type struct {
 	fieldsByName map[string]Field
 	fields       []Field
+
+	// fieldIndexes maps field names to their index in fields. It is also the index of the field's value in a Record.
+	fieldIndexes map[string]int
+
+	// optionalFields is the set of field names that are skipped when missing from the input.
+	optionalFields map[string]struct{}
+
+	// droppedFields is the set of field names that are always skipped.
+	droppedFields map[string]struct{}
+
+	options TypeOptions
+
+	// afterParseHooksMux serializes AfterParse. afterParseHooks is replaced rather than modified so Parse can read it
+	// without locking.
+	afterParseHooksMux sync.Mutex
+	afterParseHooks    atomic.Pointer[[]func(r *Record) error]
+
+	// dependencies maps field names to the names of the fields they depend on.
+	dependencies map[string][]string
+
+	// parseOrder is the order in which Parse converts fields as indexes into fields. Fields are converted after the
+	// fields they depend on.
+	parseOrder []int
+
+	// stringPolicies is the effective StringPolicy of each field by index.
+	stringPolicies []StringPolicy
+
+	// deprecatedFields maps the names of deprecated fields to their deprecation.
+	deprecatedFields map[string]*deprecatedValueConverter
+
+	// replacedFields maps field names to the deprecated fields they replace.
+	replacedFields map[string][]string
+
+	// aliases maps field aliases to the names of their fields.
+	aliases map[string]string
+
+	// rest is the catch-all field of the type or nil.
+	rest *RestField
+
+	// patterns are the pattern fields of the type in the order they were given.
+	patterns []*PatternField
+
+	// patternIndexes maps the names of pattern fields to their index in patterns.
+	patternIndexes map[string]int
+
+	// warners maps field names to their converters that may add a Warning.
+	warners map[string][]valueWarner
+}
+
+// TypeOptions configures the behavior of a Type.
+type TypeOptions struct {
+	// OmitMissing controls how fields that are missing from the input are represented in the Record's Attrs. By
+	// default, they are present with a nil value. If OmitMissing is true and the field's converters return nil then
+	// the field is omitted. Converters are still applied to missing fields so Require and Default continue to work.
+	OmitMissing bool
+
+	// StringPolicy is applied to string input of every field before it is passed to the field's converters. A field
+	// can override it with StandardField.WithStringPolicy. It does not change the normalization done by the converters
+	// themselves. See StringPolicy.
+	StringPolicy StringPolicy
+
+	// FieldGroups are requirements that apply to groups of fields such as AtLeastOneOf. They are validated by Parse after
+	// all fields have been converted. The error of a group is a *GroupError recorded under FieldGroup.Key.
+	FieldGroups []FieldGroup
+
+	// MaxInputSize is an approximate limit in bytes on the size of the input to a single Parse call including nested
+	// maps and slices. Input that exceeds it fails with an error under BaseErrorKey before any converters are applied.
+	// It protects against pathologically large input that passed decoding. If MaxInputSize is 0 there is no limit.
+	MaxInputSize int
+
+	// TrackUnknownKeys records the input keys that are not fields of the Type. They are ignored by Parse as usual but
+	// are available from Record.UnknownKeys. This allows monitoring clients that send unexpected fields without
+	// rejecting their requests.
+	TrackUnknownKeys bool
+
+	// OnUnknownKeys is called by Parse and ParsePartial with the record and its sorted unknown keys when the input
+	// contains keys that are not fields of the Type. It is intended for logging and metrics. Setting it implies
+	// TrackUnknownKeys. It is called before field groups are validated and AfterParse hooks are called. It must be safe
+	// for concurrent use.
+	OnUnknownKeys func(r *Record, keys []string)
+
+	// Name identifies the Type in Metrics.
+	Name string
+
+	// Metrics receives the outcome and duration of the conversion of each field. If Metrics is nil then nothing is
+	// measured.
+	Metrics Metrics
+
+	// Clock is used by the time-based converters of the Type's fields and of nested Types that do not have their own
+	// Clock. If Clock is nil then the Clock of the context passed to ParseCtx or DefaultClock is used.
+	Clock Clock
+}
+
+// StringPolicy controls how Parse normalizes string input before it is passed to the field's converters. The zero
+// value passes strings through unchanged.
+//
+// A StringPolicy can only add normalization. It cannot turn off normalization done by the converters themselves.
+// Converters that parse strings into other types such as Int64, Float64, Decimal, Bool, Time, and UUID always trim
+// space and treat a blank string as nil because a blank string is never a valid value for them. SingleLineString
+// also trims space. A StringPolicy is therefore only useful with whitespace significant converters such as String and
+// MultiLineString, e.g. to keep the space in a password or to keep a blank string instead of nil.
+type StringPolicy struct {
+	// TrimSpace removes leading and trailing white space.
+	TrimSpace bool
+
+	// BlankToNil converts strings that are empty or only white space to nil.
+	BlankToNil bool
+}
+
+func (p StringPolicy) apply(value any) any {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	if p.BlankToNil && strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	if p.TrimSpace {
+		trimmed := strings.TrimSpace(s)
+		if len(trimmed) != len(s) {
+			return trimmed
+		}
+	}
+
+	return value
 }
 
 type Field interface {
@@ -50,6 +185,96 @@ type StandardField struct {
 
 	// valueConverters is the list of valueConverters that will be applied to the field.
 	valueConverters []ValueConverter
+
+	// stringPolicy overrides TypeOptions.StringPolicy if it is not nil.
+	stringPolicy *StringPolicy
+
+	// examples are example input values for documentation and tests.
+	examples []any
+
+	// aliases are alternative input keys for the field.
+	aliases []string
+
+	// sensitive is true if the value of the field must not be logged.
+	sensitive bool
+
+	// meta is arbitrary application data attached to the field.
+	meta map[string]any
+}
+
+// WithExample returns a copy of f with example added to its examples. Examples are input values used for
+// documentation and tests. See FieldExamples and Type.Example.
+func (f *StandardField) WithExample(example any) *StandardField {
+	nf := *f
+	nf.examples = append(f.examples[:len(f.examples):len(f.examples)], example)
+	return &nf
+}
+
+// FieldExamples returns the examples of f. Only StandardField supports examples. The returned slice must not be
+// modified.
+func FieldExamples(f Field) []any {
+	if sf, ok := f.(*StandardField); ok {
+		return sf.examples
+	}
+	return nil
+}
+
+// WithAlias returns a copy of f with aliases added to its aliases. When the field is missing from the input Parse uses
+// the value of the first alias that is present. Aliases must not be the name or alias of another field of the Type.
+func (f *StandardField) WithAlias(aliases ...string) *StandardField {
+	nf := *f
+	nf.aliases = append(f.aliases[:len(f.aliases):len(f.aliases)], aliases...)
+	return &nf
+}
+
+// FieldAliases returns the aliases of f. Only StandardField supports aliases. The returned slice must not be modified.
+func FieldAliases(f Field) []string {
+	if sf, ok := f.(*StandardField); ok {
+		return sf.aliases
+	}
+	return nil
+}
+
+// WithSensitive returns a copy of f that is marked as sensitive. The values of sensitive fields are redacted by
+// Record.String. See FieldIsSensitive.
+func (f *StandardField) WithSensitive() *StandardField {
+	nf := *f
+	nf.sensitive = true
+	return &nf
+}
+
+// FieldIsSensitive returns true if f is marked as sensitive. Only StandardField can be sensitive.
+func FieldIsSensitive(f Field) bool {
+	sf, ok := f.(*StandardField)
+	return ok && sf.sensitive
+}
+
+// WithMeta returns a copy of f with value stored under key in its metadata. Metadata is not used by mp. It allows
+// applications to attach data such as documentation or UI hints to a field. See FieldMeta.
+func (f *StandardField) WithMeta(key string, value any) *StandardField {
+	nf := *f
+	nf.meta = make(map[string]any, len(f.meta)+1)
+	for k, v := range f.meta {
+		nf.meta[k] = v
+	}
+	nf.meta[key] = value
+	return &nf
+}
+
+// FieldMeta returns the metadata of f. Only StandardField supports metadata. The returned map must not be modified.
+func FieldMeta(f Field) map[string]any {
+	if sf, ok := f.(*StandardField); ok {
+		return sf.meta
+	}
+	return nil
+}
+
+// WithStringPolicy returns a copy of f that uses policy instead of the StringPolicy of the Type it belongs to. policy
+// only affects converters that do not normalize strings themselves such as String.
+func (f *StandardField) WithStringPolicy(policy StringPolicy) *StandardField {
+	nf := *f
+	nf.stringPolicy = &policy
+	return &nf
 }
 
 // Name returns the name of the field.
@@ -67,45 +292,429 @@ func (f *StandardField) ValueConverters() []ValueConverter {
 	return f.valueConverters
 }
 
+// HasField returns true if the type has a field named name.
+func (t *Type) HasField(name string) bool {
+	_, ok := t.fieldsByName[name]
+	return ok
+}
+
 // Fields returns the fields of the type. The returned slice must not be modified.
 func (t *Type) Fields() []Field {
 	return t.fields
 }
 
+// Options returns the options of the type.
+func (t *Type) Options() TypeOptions {
+	return t.options
+}
+
+// WithFieldDefaults returns a copy of t where every StandardField without converters uses converters. It allows the
+// common normalization of a wide Type, such as SingleLineString and NilifyEmpty, to be given once. Fields with
+// converters and fields that are not a StandardField, including Rest and pattern fields, are unchanged. The AfterParse
+// hooks registered on t so far are also registered on the copy. t is not modified.
+func (t *Type) WithFieldDefaults(converters ...ValueConverter) *Type {
+	fields := make([]Field, len(t.fields))
+	for i, f := range t.fields {
+		if sf, ok := f.(*StandardField); ok && len(sf.valueConverters) == 0 {
+			nf := *sf
+			nf.valueConverters = append([]ValueConverter(nil), converters...)
+			f = &nf
+		}
+		fields[i] = f
+	}
+	for _, f := range t.patterns {
+		fields = append(fields, f)
+	}
+	if t.rest != nil {
+		fields = append(fields, t.rest)
+	}
+
+	nt := NewTypeWithOptions(t.options, fields...)
+	if p := t.afterParseHooks.Load(); p != nil {
+		nt.afterParseHooks.Store(p)
+	}
+	return nt
+}
+
+// NewType creates a new Type with the given fields and the default TypeOptions.
 func NewType(fields ...Field) *Type {
+	return NewTypeWithOptions(TypeOptions{}, fields...)
+}
+
+// NewTypeWithOptions creates a new Type with the given options and fields. It panics if more than one field has the same
+// name.
+func NewTypeWithOptions(options TypeOptions, fields ...Field) *Type {
+	// Copy fields so later modification of the caller's slice does not affect the Type. The Rest field and pattern
+	// fields are kept apart.
+	var rest *RestField
+	var patterns []*PatternField
+	namedFields := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		switch f := f.(type) {
+		case *RestField:
+			if rest != nil {
+				panic(errors.New("type cannot have more than one Rest field"))
+			}
+			rest = f
+		case *PatternField:
+			patterns = append(patterns, f)
+		default:
+			namedFields = append(namedFields, f)
+		}
+	}
+	fields = namedFields
+
 	t := &Type{
-		fields:       fields,
-		fieldsByName: make(map[string]Field, len(fields)),
+		fields:         fields,
+		fieldsByName:   make(map[string]Field, len(fields)),
+		fieldIndexes:   make(map[string]int, len(fields)),
+		options:        options,
+		stringPolicies: make([]StringPolicy, len(fields)),
+		rest:           rest,
+		patterns:       patterns,
 	}
 
-	for _, f := range fields {
+	for i, f := range patterns {
+		if t.patternIndexes == nil {
+			t.patternIndexes = make(map[string]int, len(patterns))
+		}
+		if _, ok := t.patternIndexes[f.Name()]; ok {
+			panic(fmt.Errorf("field %q is defined more than once", f.Name()))
+		}
+		t.patternIndexes[f.Name()] = i
+	}
+
+	for i, f := range fields {
+		if _, ok := t.fieldsByName[f.Name()]; ok {
+			panic(fmt.Errorf("field %q is defined more than once", f.Name()))
+		}
 		t.fieldsByName[f.Name()] = f
+		t.fieldIndexes[f.Name()] = i
+		t.stringPolicies[i] = options.StringPolicy
+		if sf, ok := f.(*StandardField); ok && sf.stringPolicy != nil {
+			t.stringPolicies[i] = *sf.stringPolicy
+		}
+		if FieldIsOptional(f) {
+			if t.optionalFields == nil {
+				t.optionalFields = make(map[string]struct{})
+			}
+			t.optionalFields[f.Name()] = struct{}{}
+		}
+		for _, vc := range fieldConverters(f) {
+			if w, ok := vc.(valueWarner); ok {
+				if t.warners == nil {
+					t.warners = make(map[string][]valueWarner)
+				}
+				t.warners[f.Name()] = append(t.warners[f.Name()], w)
+			}
+		}
+		if FieldIsDropped(f) {
+			if t.droppedFields == nil {
+				t.droppedFields = make(map[string]struct{})
+			}
+			t.droppedFields[f.Name()] = struct{}{}
+		}
+		if d := fieldDeprecation(f); d != nil {
+			if t.deprecatedFields == nil {
+				t.deprecatedFields = make(map[string]*deprecatedValueConverter)
+			}
+			t.deprecatedFields[f.Name()] = d
+			if d.replacement != "" {
+				if t.replacedFields == nil {
+					t.replacedFields = make(map[string][]string)
+				}
+				t.replacedFields[d.replacement] = append(t.replacedFields[d.replacement], f.Name())
+			}
+		}
+		for _, alias := range FieldAliases(f) {
+			if t.aliases == nil {
+				t.aliases = make(map[string]string)
+			}
+			if name, ok := t.aliases[alias]; ok {
+				panic(fmt.Errorf("alias %q of %q is already an alias of %q", alias, f.Name(), name))
+			}
+			t.aliases[alias] = f.Name()
+		}
+		if deps := FieldDependencies(f); deps != nil {
+			if t.dependencies == nil {
+				t.dependencies = make(map[string][]string)
+			}
+			t.dependencies[f.Name()] = deps
+		}
+	}
+
+	for _, f := range fields {
+		if d, ok := t.deprecatedFields[f.Name()]; ok && d.replacement != "" {
+			if _, ok := t.fieldsByName[d.replacement]; !ok {
+				panic(fmt.Errorf("%q is replaced by %q which is not a field of type", f.Name(), d.replacement))
+			}
+		}
+	}
+
+	for _, f := range fields {
+		for _, alias := range FieldAliases(f) {
+			if _, ok := t.fieldsByName[alias]; ok {
+				panic(fmt.Errorf("alias %q of %q is already a field of type", alias, f.Name()))
+			}
+		}
+	}
+
+	for _, g := range options.FieldGroups {
+		for _, name := range g.Fields {
+			if _, ok := t.fieldsByName[name]; !ok {
+				panic(fmt.Errorf("%q is not a field of type", name))
+			}
+		}
+	}
+
+	sortedFields := sortFieldsByDependencies(fields, t.fieldsByName, t.dependencies)
+	t.parseOrder = make([]int, len(sortedFields))
+	for i, f := range sortedFields {
+		t.parseOrder[i] = t.fieldIndexes[f.Name()]
 	}
 
 	return t
 }
 
 // Parse creates a Record from attrs.
+//
+// Fields are converted in the order they were given to NewType except that a field is always converted after the
+// fields it depends on (see DependsOn). Within a field the converters are applied in order. The order is fixed when the
+// Type is constructed so converters with side effects, Metrics, and warnings observe the same order on every call.
+// Field groups are validated and AfterParse hooks are called after all fields have been converted.
 func (t *Type) Parse(attrs map[string]any) *Record {
+	return t.parse(context.Background(), attrs, false)
+}
+
+// ParsePartial creates a partial Record from attrs for PATCH style updates. Only the fields present in attrs are
+// converted. Absent fields are skipped as if they were optional so Require and similar converters do not fail for them
+// and they are not included in Attrs or Pick. Converters that depend on an absent field (see DependsOn) are skipped.
+// AfterParse hooks are not called because they may expect all fields to be present.
+func (t *Type) ParsePartial(attrs map[string]any) *Record {
+	return t.parse(context.Background(), attrs, true)
+}
+
+func (t *Type) parse(ctx context.Context, attrs map[string]any, partial bool) *Record {
 	r := &Record{
-		t:         t,
-		original:  attrs,
-		converted: make(map[string]any, len(attrs)),
-		errors:    make(map[string]error, len(attrs)),
+		t:        t,
+		original: attrs,
+		partial:  partial,
+	}
+	r.initValues(len(t.fields))
+
+	if t.options.Clock != nil {
+		ctx = ContextWithClock(ctx, t.options.Clock)
 	}
 
-	for _, f := range t.fieldsByName {
-		value, err := f.ConvertValue(attrs[f.Name()])
+	if t.options.MaxInputSize > 0 && inputSizeExceeds(attrs, t.options.MaxInputSize) {
+		r.errors = Errors{BaseErrorKey: errInputTooLarge}
+		return r
+	}
+
+	for _, idx := range t.parseOrder {
+		f := t.fields[idx]
+		if _, ok := t.droppedFields[f.Name()]; ok {
+			continue
+		}
+		attr, present := attrs[f.Name()]
+		if present {
+			if d, ok := t.deprecatedFields[f.Name()]; ok {
+				r.warnings = append(r.warnings, Warning{Field: f.Name(), Message: d.message})
+			}
+		} else {
+			for _, name := range t.replacedFields[f.Name()] {
+				if attr, present = attrs[name]; present {
+					break
+				}
+			}
+			if !present {
+				for _, alias := range FieldAliases(f) {
+					if attr, present = attrs[alias]; present {
+						break
+					}
+				}
+			}
+		}
+		if !present {
+			if _, ok := t.optionalFields[f.Name()]; ok || partial {
+				continue
+			}
+		}
+		attr = t.stringPolicies[idx].apply(attr)
+
+		var start time.Time
+		if t.options.Metrics != nil {
+			start = time.Now()
+		}
+
+		var value any
+		var err error
+		if deps, ok := t.dependencies[f.Name()]; ok {
+			depValues, ok := r.dependencyValues(deps)
+			if !ok {
+				continue
+			}
+			if partial && !r.allSet(deps) {
+				value, err = convertSliceWithoutDependencies(ctx, attr, fieldValueConverters(f))
+			} else {
+				value, err = convertSliceWithDependencies(ctx, attr, fieldValueConverters(f), depValues)
+			}
+		} else {
+			value, err = convertSliceWithDependencies(ctx, attr, fieldValueConverters(f), nil)
+		}
+
+		if t.options.Metrics != nil {
+			var code string
+			if err != nil {
+				code = errorCode(err)
+			}
+			t.options.Metrics.ObserveField(t.options.Name, f.Name(), code, time.Since(start))
+		}
 		if err == nil {
-			r.converted[f.Name()] = value
+			if !present && value == nil && t.options.OmitMissing {
+				continue
+			}
+			r.values[idx] = fieldValue{value: value, set: true}
+			for _, w := range t.warners[f.Name()] {
+				if message, ok := w.warning(value); ok {
+					r.warnings = append(r.warnings, Warning{Field: f.Name(), Message: message})
+				}
+			}
 		} else {
+			if r.errors == nil {
+				r.errors = make(Errors)
+			}
 			r.errors[f.Name()] = err
 		}
 	}
 
+	if t.rest != nil || len(t.patterns) > 0 {
+		t.parseExtraKeys(ctx, r, attrs)
+	}
+
+	if t.options.TrackUnknownKeys || t.options.OnUnknownKeys != nil {
+		r.unknownKeys = t.unknownKeys(attrs)
+		if r.unknownKeys != nil && t.options.OnUnknownKeys != nil {
+			t.options.OnUnknownKeys(r, r.unknownKeys)
+		}
+	}
+
+	if !partial {
+		r.errors = t.validateFieldGroups(r.errors, func(name string) bool {
+			fv := r.values[t.fieldIndexes[name]]
+			return fv.set && isPresentValue(fv.value)
+		})
+	}
+
+	if len(r.errors) == 0 && !partial {
+		var hooks []func(r *Record) error
+		if p := t.afterParseHooks.Load(); p != nil {
+			hooks = *p
+		}
+		for _, hook := range hooks {
+			err := hook(r)
+			if err != nil {
+				r.addError(err)
+				break
+			}
+		}
+	}
+
 	return r
 }
 
+// BaseErrorKey is the key in Errors used for errors that do not belong to a specific field.
+const BaseErrorKey = "base"
+
+// AfterParse registers hook to be called by Parse when all fields have been converted successfully. Hooks are called
+// in the order they were registered. A hook can compute derived values and store them with Record.Set. If a hook
+// returns an error then no further hooks are called. If the error is an Errors then its entries are added to the
+// record's errors. Otherwise, it is added under BaseErrorKey.
+//
+// AfterParse is safe to call concurrently with Parse. Parse calls that have already started do not see the new hook.
+func (t *Type) AfterParse(hook func(r *Record) error) {
+	t.afterParseHooksMux.Lock()
+	defer t.afterParseHooksMux.Unlock()
+
+	var hooks []func(r *Record) error
+	if p := t.afterParseHooks.Load(); p != nil {
+		hooks = make([]func(r *Record) error, len(*p), len(*p)+1)
+		copy(hooks, *p)
+	}
+	hooks = append(hooks, hook)
+	t.afterParseHooks.Store(&hooks)
+}
+
+// ConvertedType implements the ConvertedTyper interface. ConvertValue returns a *Record. Use StructType to describe
+// the fields of the record.
+func (t *Type) ConvertedType() reflect.Type {
+	return reflect.TypeOf((*Record)(nil))
+}
+
+// StructType returns a struct type that describes the fields of t. Each field name is converted to an exported Go
+// identifier and is tagged with its original name as its json tag. The type of each struct field is the ConvertedType
+// of the last converter of the field that implements ConvertedTyper. Nested Types are described by a pointer to their
+// StructType. If the type of a field is not known then it is any.
+func (t *Type) StructType() reflect.Type {
+	fields := make([]reflect.StructField, 0, len(t.fields))
+	usedNames := make(map[string]struct{}, len(t.fields))
+	for i, f := range t.fields {
+		goName := goFieldName(f.Name())
+		if _, ok := usedNames[goName]; ok || goName == "" {
+			goName = fmt.Sprintf("Field%d", i)
+		}
+		usedNames[goName] = struct{}{}
+
+		fields = append(fields, reflect.StructField{
+			Name: goName,
+			Type: structFieldType(fieldConverters(f)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:%q`, f.Name())),
+		})
+	}
+
+	return reflect.StructOf(fields)
+}
+
+func structFieldType(converters []ValueConverter) reflect.Type {
+	for i := len(converters) - 1; i >= 0; i-- {
+		switch vc := converters[i].(type) {
+		case *Type:
+			return reflect.PointerTo(vc.StructType())
+		case sliceConverter:
+			if _, ok := vc.element().(*Type); ok {
+				return reflect.SliceOf(structFieldType([]ValueConverter{vc.element()}))
+			}
+			return vc.ConvertedType()
+		case ConvertedTyper:
+			return vc.ConvertedType()
+		}
+	}
+
+	return anyType
+}
+
+// goFieldName converts name to an exported Go identifier. Characters that are not letters or digits are treated as
+// word separators.
+func goFieldName(name string) string {
+	sb := &strings.Builder{}
+	upperNext := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+		if sb.Len() == 0 && unicode.IsDigit(r) {
+			sb.WriteRune('F')
+		}
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
 // ConvertValue converts a map[string]any to a Record. If v is nil then nil is returned.
 func (t *Type) ConvertValue(v any) (any, error) {
 	if v == nil {
@@ -121,9 +730,11 @@ func (t *Type) ConvertValue(v any) (any, error) {
 		return record, nil
 	}
 
-	return nil, errors.New("cannot convert to record")
+	return nil, errCannotConvertToRecord
 }
 
+var errCannotConvertToRecord = errors.New("cannot convert to record")
+
 // ValueConverter is an interface that converts a value to a different type or validates the value.
 type ValueConverter interface {
 	ConvertValue(any) (any, error)
@@ -141,19 +752,114 @@ type ConvertedTyper interface {
 	ConvertedType() reflect.Type
 }
 
+// anyType is the reflect.Type of any. It is used as the ConvertedType when the actual type is not known.
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// ConverterParamser is implemented by ValueConverters that can report their configuration. It allows documentation
+// and schema generators to reflect the actual limits of a converter. The returned map must not be modified.
+type ConverterParamser interface {
+	ConverterParams() map[string]any
+}
+
+// FieldConverterParams returns the merged ConverterParams of all converters of f that implement ConverterParamser. If
+// multiple converters report the same parameter the last one wins.
+func FieldConverterParams(f Field) map[string]any {
+	params := make(map[string]any)
+	for _, vc := range fieldConverters(f) {
+		if cp, ok := vc.(ConverterParamser); ok {
+			for k, v := range cp.ConverterParams() {
+				params[k] = v
+			}
+		}
+	}
+
+	return params
+}
+
+// RequiredMarker is implemented by ValueConverters that fail when the value is nil. A field that includes a
+// RequiredMarker in its converters must be present in the input.
+type RequiredMarker interface {
+	IsNotNil()
+}
+
+// NullableMarker is implemented by ValueConverters that replace nil with a non-nil value such as Default. A field with a
+// NullableMarker before any RequiredMarker in its converters does not need to be present in the input.
+type NullableMarker interface {
+	IsNullable()
+}
+
+// OptionalMarker is implemented by ValueConverters that mark a field as optional. An optional field that is missing
+// from the input is skipped entirely. It is not converted and it does not appear in the Record's Attrs.
+type OptionalMarker interface {
+	IsOptional()
+}
+
+// FieldIsOptional returns true if f is skipped when it is missing from the input.
+func FieldIsOptional(f Field) bool {
+	for _, vc := range fieldConverters(f) {
+		if _, ok := vc.(OptionalMarker); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DroppedMarker is implemented by ValueConverters that mark a field as dropped. A dropped field is skipped entirely
+// even when it is present in the input. It is not converted and it does not appear in the Record's Attrs.
+type DroppedMarker interface {
+	IsDropped()
+}
+
+// FieldIsDropped returns true if f is always skipped.
+func FieldIsDropped(f Field) bool {
+	for _, vc := range fieldConverters(f) {
+		if _, ok := vc.(DroppedMarker); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldIsRequired returns true if f will fail when its value is nil or missing.
+func FieldIsRequired(f Field) bool {
+	for _, vc := range fieldConverters(f) {
+		if _, ok := vc.(NullableMarker); ok {
+			return false
+		}
+		if _, ok := vc.(RequiredMarker); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldConverters returns the converters of f for introspection. Fields other than StandardField are treated as a
+// single converter. Converter groups such as All are flattened.
+func fieldConverters(f Field) []ValueConverter {
+	return appendFlattenedConverters(nil, fieldValueConverters(f))
+}
+
+// fieldValueConverters returns the converters of f as they are applied. Fields other than StandardField are treated as
+// a single converter.
+func fieldValueConverters(f Field) []ValueConverter {
+	if sf, ok := f.(*StandardField); ok {
+		return sf.valueConverters
+	}
+	return []ValueConverter{f}
+}
+
 // Errors is a map of field name to error. It implements the error interface.
 type Errors map[string]error
 
+// Error returns the field errors sorted by field name so the message is deterministic.
 func (e Errors) Error() string {
 	sb := &strings.Builder{}
 
-	join := false
-	for attr, err := range e {
-		if join {
+	for i, attr := range e.fieldNames() {
+		if i > 0 {
 			sb.WriteString(", ")
 		}
-		fmt.Fprintf(sb, "%s %v", attr, err)
-		join = true
+		fmt.Fprintf(sb, "%s %v", attr, e[attr])
 	}
 
 	return sb.String()
@@ -198,20 +904,121 @@ func (e sliceElementErrors) Error() string {
 }
 
 // Record is an "instance" of a type. It is created by calling Type.Parse.
+//
+// A Record may be read concurrently by multiple goroutines but it must not be modified with Set concurrently with any
+// other use. Use Freeze to guarantee that a shared Record is not modified.
 type Record struct {
-	t         *Type
-	original  map[string]any
-	converted map[string]any
-	errors    Errors
+	t        *Type
+	original map[string]any
+
+	// values are the converted values of the fields of t in the same order as t.fields.
+	values []fieldValue
+
+	// smallValues is used as the backing array of values for Types with few fields to save an allocation.
+	smallValues [smallRecordFields]fieldValue
+
+	// errors is nil when there are no errors.
+	errors Errors
+
+	// warnings is nil when there are no warnings.
+	warnings []Warning
+
+	frozen bool
+
+	// partial is true when the record was created by ParsePartial.
+	partial bool
+
+	// unknownKeys are the input keys that are not fields of t. It is only set when tracking is enabled.
+	unknownKeys []string
+
+	// rest are the converted values collected by the Rest field of t.
+	rest map[string]any
+
+	// patternValues are the converted values of the keys matched by each pattern field of t.
+	patternValues []map[string]any
+
+	// attrs caches the map returned by AttrsUnsafe.
+	attrs atomic.Pointer[map[string]any]
+}
+
+// smallRecordFields is the number of field values a Record stores without a separate allocation.
+const smallRecordFields = 4
+
+// initValues sets r.values to n unset field values.
+func (r *Record) initValues(n int) {
+	if n <= len(r.smallValues) {
+		r.values = r.smallValues[:n:n]
+		return
+	}
+	r.values = make([]fieldValue, n)
+}
+
+type fieldValue struct {
+	value any
+
+	// set is false when the field was skipped by Parse. Such a field is not included in Attrs.
+	set bool
 }
 
 // Get returns the value of the field named s. If s is not a field of the type then Get panics.
 func (r *Record) Get(s string) any {
-	if _, ok := r.t.fieldsByName[s]; !ok {
+	value, ok := r.TryGet(s)
+	if !ok {
 		panic(fmt.Errorf("%q is not a field of type", s))
 	}
 
-	return r.converted[s]
+	return value
+}
+
+// TryGet returns the value of the field named s. If s is not a field of the type then ok is false.
+func (r *Record) TryGet(s string) (value any, ok bool) {
+	idx, ok := r.t.fieldIndexes[s]
+	if !ok {
+		return nil, false
+	}
+
+	return r.values[idx].value, true
+}
+
+// dependencyValues returns the converted values of the fields named in deps. ok is false if any of them failed to
+// convert.
+func (r *Record) dependencyValues(deps []string) (values map[string]any, ok bool) {
+	values = make(map[string]any, len(deps))
+	for _, name := range deps {
+		if _, failed := r.errors[name]; failed {
+			return nil, false
+		}
+		values[name] = r.values[r.t.fieldIndexes[name]].value
+	}
+	return values, true
+}
+
+// allSet returns true if all of the fields named in names were set by Parse.
+func (r *Record) allSet(names []string) bool {
+	for _, name := range names {
+		if !r.values[r.t.fieldIndexes[name]].set {
+			return false
+		}
+	}
+	return true
+}
+
+// addError adds err to the errors of r. If err is an Errors then its entries are added. Otherwise, err is added under
+// BaseErrorKey.
+func (r *Record) addError(err error) {
+	if r.errors == nil {
+		r.errors = make(Errors)
+	}
+
+	var errs Errors
+	if errors.As(err, &errs) {
+		for k, v := range errs {
+			r.errors[k] = v
+		}
+		return
+	}
+
+	r.errors[BaseErrorKey] = err
 }
 
 // Errors returns the errors for the record. If the record is valid then nil is returned.
@@ -226,22 +1033,132 @@ func (r *Record) Errors() error {
 // Pick returns a map with the keys and values of the fields named in keys. If any of the keys are not fields of the
 // type then Pick panics.
 func (r *Record) Pick(keys ...string) map[string]any {
+	m, err := r.TryPick(keys...)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryPick returns a map with the keys and values of the fields named in keys. If any of the keys are not fields of the
+// type then an error is returned.
+func (r *Record) TryPick(keys ...string) (map[string]any, error) {
 	m := make(map[string]any, len(keys))
 	for _, k := range keys {
-		if _, ok := r.t.fieldsByName[k]; !ok {
-			panic(fmt.Errorf("%q is not a field of type", k))
+		idx, ok := r.t.fieldIndexes[k]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a field of type", k)
 		}
 
-		if value, ok := r.converted[k]; ok {
-			m[k] = value
+		if fv := r.values[idx]; fv.set {
+			m[k] = fv.value
 		}
 	}
-	return m
+	return m, nil
+}
+
+// With returns a new Record of the same type parsed from the original input of r overlaid with attrs. Keys in attrs
+// take precedence. r is not modified.
+func (r *Record) With(attrs map[string]any) *Record {
+	merged := make(map[string]any, len(r.original)+len(attrs))
+	for k, v := range r.original {
+		merged[k] = v
+	}
+	for k, v := range attrs {
+		merged[k] = v
+	}
+
+	return r.t.Parse(merged)
 }
 
-// Attrs returns the converted attributes of the record.
+// MergeRecords returns a new Record where the fields present in the original input of overlay take precedence over
+// the fields of base. The merged input is parsed again so all converters are applied to the result. base and overlay
+// must be of the same type or MergeRecords panics.
+func MergeRecords(base, overlay *Record) *Record {
+	if base.t != overlay.t {
+		panic(errors.New("cannot merge records of different types"))
+	}
+
+	return base.With(overlay.original)
+}
+
+// Set sets the converted value of the field named s. Set does not apply the field's converters. If s is not a field of
+// the type or the record is frozen then Set panics.
+func (r *Record) Set(s string, value any) {
+	if r.frozen {
+		panic(errors.New("cannot modify frozen record"))
+	}
+
+	idx, ok := r.t.fieldIndexes[s]
+	if !ok {
+		panic(fmt.Errorf("%q is not a field of type", s))
+	}
+
+	r.values[idx] = fieldValue{value: value, set: true}
+	r.attrs.Store(nil)
+}
+
+// Freeze prevents any further modification of r. Calling Set on a frozen record panics.
+func (r *Record) Freeze() {
+	r.frozen = true
+}
+
+// Frozen returns true if r is frozen.
+func (r *Record) Frozen() bool {
+	return r.frozen
+}
+
+// Partial returns true if r was created by ParsePartial. Fields absent from the input of a partial record are not set.
+func (r *Record) Partial() bool {
+	return r.partial
+}
+
+// Clone returns a copy of r. The converted values and errors are copied but the values themselves are not. The clone
+// is never frozen.
+func (r *Record) Clone() *Record {
+	clone := &Record{
+		t:             r.t,
+		original:      r.original,
+		warnings:      r.warnings,
+		partial:       r.partial,
+		unknownKeys:   r.unknownKeys,
+		rest:          r.rest,
+		patternValues: r.patternValues,
+	}
+	clone.initValues(len(r.values))
+	copy(clone.values, r.values)
+
+	if r.errors != nil {
+		clone.errors = make(Errors, len(r.errors))
+		for k, v := range r.errors {
+			clone.errors[k] = v
+		}
+	}
+
+	return clone
+}
+
+// Attrs returns a copy of the converted attributes of the record.
 func (r *Record) Attrs() map[string]any {
-	return r.converted
+	attrs := make(map[string]any, len(r.values))
+	for i, fv := range r.values {
+		if fv.set {
+			attrs[r.t.fields[i].Name()] = fv.value
+		}
+	}
+	return attrs
+}
+
+// AttrsUnsafe returns the converted attributes of the record. The map is built on first use and shared by subsequent
+// calls until the record is modified. The returned map must not be modified.
+func (r *Record) AttrsUnsafe() map[string]any {
+	if p := r.attrs.Load(); p != nil {
+		return *p
+	}
+
+	attrs := r.Attrs()
+	r.attrs.CompareAndSwap(nil, &attrs)
+	return attrs
 }
 
 // Int64 returns a ValueConverter that converts value to an int64. If value is nil or a blank string nil is returned.
@@ -249,7 +1166,15 @@ func Int64() ValueConverter {
 	return int64ValueConverter{}
 }
 
-type int64ValueConverter struct{}
+// Int64WithFormat returns a ValueConverter that converts value to an int64. String values are normalized according to
+// format before parsing. If value is nil or a blank string nil is returned.
+func Int64WithFormat(format NumberFormat) ValueConverter {
+	return int64ValueConverter{format: &format}
+}
+
+type int64ValueConverter struct {
+	format *NumberFormat
+}
 
 func (c int64ValueConverter) ConvertValue(value any) (any, error) {
 	value = normalizeForParsing(value)
@@ -258,6 +1183,11 @@ func (c int64ValueConverter) ConvertValue(value any) (any, error) {
 		return nil, nil
 	}
 
+	value, err := normalizeFormattedNumber(value, c.format)
+	if err != nil {
+		return nil, err
+	}
+
 	n, err := convertInt64(value)
 	if err != nil {
 		return nil, err
@@ -288,50 +1218,96 @@ func convertInt64(value any) (int64, error) {
 		return int64(value), nil
 	case uint64:
 		if value > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, errGreaterThanMaxInt
 		}
 		return int64(value), nil
 	case int:
 		return int64(value), nil
 	case uint:
 		if uint64(value) > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, errGreaterThanMaxInt
 		}
 		return int64(value), nil
 	case float32:
 		if value < math.MinInt64 {
-			return 0, errors.New("less than minimum allowed number")
+			return 0, errLessThanMinInt
 		}
 		if value > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, errGreaterThanMaxInt
 		}
 		if float32(int64(value)) != value {
-			return 0, errors.New("not a valid number")
+			return 0, errNotAValidNumber
 		}
 		return int64(value), nil
 	case float64:
 		if value < math.MinInt64 {
-			return 0, errors.New("less than minimum allowed number")
+			return 0, errLessThanMinInt
 		}
 		if value > math.MaxInt64 {
-			return 0, errors.New("greater than maximum allowed number")
+			return 0, errGreaterThanMaxInt
 		}
 		if float64(int64(value)) != value {
-			return 0, errors.New("not a valid number")
+			return 0, errNotAValidNumber
 		}
 		return int64(value), nil
+	case json.Number:
+		return convertJSONNumberToInt64(value)
 	}
 
-	s := fmt.Sprintf("%v", value)
+	s, err := numericString(value)
+	if err != nil {
+		return 0, err
+	}
 	s = strings.TrimSpace(s)
 
 	num, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
-		return 0, errors.New("not a valid number")
+		return 0, errNotAValidNumber
 	}
 	return num, nil
 }
 
+// convertJSONNumberToInt64 converts n to an int64 without losing precision. Numbers in exponent or decimal form such
+// as 1e3 or 30.0 are accepted if they are integers.
+func convertJSONNumberToInt64(n json.Number) (int64, error) {
+	num, err := strconv.ParseInt(string(n), 10, 64)
+	if err == nil {
+		return num, nil
+	}
+
+	d, err := decimal.NewFromString(string(n))
+	if err != nil || !d.IsInteger() {
+		return 0, errNotAValidNumber
+	}
+	if d.LessThan(minInt64Decimal) {
+		return 0, errLessThanMinInt
+	}
+	if d.GreaterThan(maxInt64Decimal) {
+		return 0, errGreaterThanMaxInt
+	}
+	return d.IntPart(), nil
+}
+
+var (
+	minInt64Decimal = decimal.NewFromInt(math.MinInt64)
+	maxInt64Decimal = decimal.NewFromInt(math.MaxInt64)
+)
+
+// numericString returns the string form of a value that is not a number type so it can be parsed as a number. Only
+// strings, []byte, and fmt.Stringer are accepted. Any other type is an error.
+func numericString(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	case fmt.Stringer:
+		return value.String(), nil
+	}
+
+	return "", newKindError(ErrNotANumber, fmt.Sprintf("cannot convert %T to a number", value))
+}
+
 func convertInt32(value any) (int32, error) {
 	n, err := convertInt64(value)
 	if err != nil {
@@ -339,10 +1315,10 @@ func convertInt32(value any) (int32, error) {
 	}
 
 	if n < math.MinInt32 {
-		return 0, errors.New("less than minimum allowed number")
+		return 0, errLessThanMinInt
 	}
 	if n > math.MaxInt32 {
-		return 0, errors.New("greater than maximum allowed number")
+		return 0, errGreaterThanMaxInt
 	}
 
 	return int32(n), nil
@@ -379,7 +1355,15 @@ func Float64() ValueConverter {
 	return float64ValueConverter{}
 }
 
-type float64ValueConverter struct{}
+// Float64WithFormat returns a ValueConverter that converts value to a float64. String values are normalized according
+// to format before parsing. If value is nil or a blank string nil is returned.
+func Float64WithFormat(format NumberFormat) ValueConverter {
+	return float64ValueConverter{format: &format}
+}
+
+type float64ValueConverter struct {
+	format *NumberFormat
+}
 
 func (c float64ValueConverter) ConvertValue(value any) (any, error) {
 	value = normalizeForParsing(value)
@@ -388,6 +1372,11 @@ func (c float64ValueConverter) ConvertValue(value any) (any, error) {
 		return value, nil
 	}
 
+	value, err := normalizeFormattedNumber(value, c.format)
+	if err != nil {
+		return nil, err
+	}
+
 	n, err := convertFloat64(value)
 	if err != nil {
 		return nil, err
@@ -426,14 +1415,23 @@ func convertFloat64(value any) (float64, error) {
 		return float64(value), nil
 	case float64:
 		return value, nil
+	case json.Number:
+		num, err := value.Float64()
+		if err != nil {
+			return 0, errNotAValidNumber
+		}
+		return num, nil
 	}
 
-	s := fmt.Sprintf("%v", value)
+	s, err := numericString(value)
+	if err != nil {
+		return 0, err
+	}
 	s = strings.TrimSpace(s)
 
 	num, err := strconv.ParseFloat(s, 64)
 	if err != nil {
-		return 0, errors.New("not a valid number")
+		return 0, errNotAValidNumber
 	}
 	return num, nil
 }
@@ -472,10 +1470,10 @@ func convertFloat32(value any) (float32, error) {
 	}
 
 	if n < -math.MaxFloat32 {
-		return 0, errors.New("less than minimum allowed number")
+		return 0, errLessThanMinFloat
 	}
 	if n > math.MaxFloat32 {
-		return 0, errors.New("greater than maximum allowed number")
+		return 0, errGreaterThanMaxFloat
 	}
 
 	return float32(n), nil
@@ -514,6 +1512,36 @@ func (c boolValueConverter) ConvertedType() reflect.Type {
 	return reflect.TypeOf(false)
 }
 
+// BoolPtr returns a ValueConverter that converts value to a *bool. It accepts the same values as Bool. If value is
+// nil, a nil *bool, or a blank string then nil is returned. It is intended for tri-state parameters such as filters
+// where not specified must be distinguishable from false.
+func BoolPtr() ValueConverter {
+	return boolPtrValueConverter{}
+}
+
+type boolPtrValueConverter struct{}
+
+func (c boolPtrValueConverter) ConvertValue(value any) (any, error) {
+	if p, ok := value.(*bool); ok {
+		if p == nil {
+			return nil, nil
+		}
+		value = *p
+	}
+
+	b, err := boolValueConverter{}.ConvertValue(value)
+	if b == nil || err != nil {
+		return nil, err
+	}
+
+	bp := b.(bool)
+	return &bp, nil
+}
+
+func (c boolPtrValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf((*bool)(nil))
+}
+
 // Time returns a ValueConverter that converts value to a time.Time using formats. If value is nil or a blank string nil is returned.
 func Time(formats ...string) ValueConverter {
 	return &timeValueConverter{formats: formats}
@@ -549,6 +1577,12 @@ func (c *timeValueConverter) ConvertedType() reflect.Type {
 	return reflect.TypeOf(time.Time{})
 }
 
+func (c *timeValueConverter) ConverterParams() map[string]any {
+	formats := make([]string, len(c.formats))
+	copy(formats, c.formats)
+	return map[string]any{"formats": formats}
+}
+
 // UUID returns a ValueConverter that converts value to a uuid.UUID. If value is nil or a blank string nil is returned.
 func UUID() ValueConverter {
 	return uuidValueConverter{}
@@ -566,14 +1600,17 @@ func (c uuidValueConverter) ConvertValue(value any) (any, error) {
 	var uuidValue uuid.UUID
 	var err error
 
-	if value, ok := value.([]byte); ok {
-		uuidValue, err = uuid.FromBytes(value)
-		return uuidValue, err
+	if b, ok := value.([]byte); ok {
+		uuidValue, err = uuid.FromBytes(b)
+	} else {
+		s := fmt.Sprintf("%v", value)
+		uuidValue, err = uuid.FromString(s)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	s := fmt.Sprintf("%v", value)
-	uuidValue, err = uuid.FromString(s)
-	return uuidValue, err
+	return uuidValue, nil
 }
 
 func (c uuidValueConverter) ConvertedType() reflect.Type {
@@ -586,7 +1623,15 @@ func Decimal() ValueConverter {
 	return decimalValueConverter{}
 }
 
-type decimalValueConverter struct{}
+// DecimalWithFormat returns a ValueConverter that converts value to a decimal.Decimal. String values are normalized
+// according to format before parsing. If value is nil or a blank string nil is returned.
+func DecimalWithFormat(format NumberFormat) ValueConverter {
+	return decimalValueConverter{format: &format}
+}
+
+type decimalValueConverter struct {
+	format *NumberFormat
+}
 
 func (c decimalValueConverter) ConvertValue(value any) (any, error) {
 	value = normalizeForParsing(value)
@@ -595,6 +1640,11 @@ func (c decimalValueConverter) ConvertValue(value any) (any, error) {
 		return nil, nil
 	}
 
+	value, err := normalizeFormattedNumber(value, c.format)
+	if err != nil {
+		return nil, err
+	}
+
 	n, err := convertDecimal(value)
 	if err != nil {
 		return nil, err
@@ -618,12 +1668,20 @@ func convertDecimal(value any) (decimal.Decimal, error) {
 	case int32:
 		return decimal.NewFromInt32(value), nil
 	case float32:
+		if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+			return decimal.Decimal{}, errNotAValidNumber
+		}
 		return decimal.NewFromFloat32(value), nil
 	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return decimal.Decimal{}, errNotAValidNumber
+		}
 		return decimal.NewFromFloat(value), nil
 	case string:
 		value = strings.TrimSpace(value)
 		return decimal.NewFromString(value)
+	case json.Number:
+		return decimal.NewFromString(string(value))
 	default:
 		s := fmt.Sprintf("%v", value)
 		s = strings.TrimSpace(s)
@@ -655,6 +1713,11 @@ func (c stringValueConverter) ConvertValue(value any) (any, error) {
 		return value, nil
 	}
 
+	// Return a string value as is to avoid allocating a new interface value.
+	if _, ok := value.(string); ok {
+		return value, nil
+	}
+
 	return convertString(value), nil
 }
 
@@ -662,48 +1725,77 @@ func (c stringValueConverter) ConvertedType() reflect.Type {
 	return reflect.TypeOf("")
 }
 
+// sliceConverter is implemented by the ValueConverters returned by Slice.
+type sliceConverter interface {
+	ConvertedTyper
+	element() ValueConverter
+}
+
 // Slice returns a ValueConverter that converts value to a []T. value must be a []T or []any. If value is nil then nil
 // is returned.
 func Slice[T any](elementConverter ValueConverter) ValueConverter {
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return nil, nil
-		}
+	return &sliceValueConverter[T]{elementConverter: elementConverter}
+}
 
-		switch value := value.(type) {
-		case []T:
-			return value, nil
-		case []any:
-			ts := make([]T, len(value))
-			var elErrs sliceElementErrors
-			for i := range value {
-				element, err := elementConverter.ConvertValue(value[i])
-				if err != nil {
-					elErrs = append(elErrs, sliceElementError{Index: i, Err: err})
-				}
-				if element, ok := element.(T); ok {
-					ts[i] = element
-				} else {
-					elErrs = append(elErrs, sliceElementError{Index: i, Err: err})
-				}
-			}
+type sliceValueConverter[T any] struct {
+	elementConverter ValueConverter
+}
 
-			if elErrs != nil {
-				return nil, elErrs
+func (c *sliceValueConverter[T]) ConvertValue(value any) (any, error) {
+	return c.ConvertValueContext(context.Background(), value)
+}
+
+func (c *sliceValueConverter[T]) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch value := value.(type) {
+	case []T:
+		return value, nil
+	case []any:
+		ts := make([]T, len(value))
+		var elErrs sliceElementErrors
+		for i := range value {
+			element, err := convertValueContext(ctx, c.elementConverter, value[i])
+			if err != nil {
+				elErrs = append(elErrs, sliceElementError{Index: i, Err: err})
+				continue
 			}
+			if element, ok := element.(T); ok {
+				ts[i] = element
+			} else {
+				elErrs = append(elErrs, sliceElementError{Index: i, Err: fmt.Errorf("cannot convert %T to %v", element, reflect.TypeOf(ts).Elem())})
+			}
+		}
 
-			return ts, nil
+		if elErrs != nil {
+			return nil, elErrs
 		}
 
-		return nil, fmt.Errorf("cannot convert to slice")
-	})
+		return ts, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert to slice")
+}
+
+func (c *sliceValueConverter[T]) ConvertedType() reflect.Type {
+	return reflect.TypeOf([]T{})
+}
+
+func (c *sliceValueConverter[T]) element() ValueConverter {
+	return c.elementConverter
+}
+
+func (c *sliceValueConverter[T]) ConverterParams() map[string]any {
+	return map[string]any{"element": c.elementConverter}
 }
 
 type notNilValueConverter struct{}
 
 func (c notNilValueConverter) ConvertValue(value any) (any, error) {
 	if value == nil {
-		return nil, errors.New("cannot be nil")
+		return nil, errCannotBeNil
 	}
 	return value, nil
 }
@@ -719,7 +1811,7 @@ type requireValueConverter struct{}
 
 func (c requireValueConverter) ConvertValue(value any) (any, error) {
 	if value == nil || value == "" {
-		return nil, fmt.Errorf("cannot be nil or empty")
+		return nil, errCannotBeNilOrEmpty
 	}
 
 	return value, nil
@@ -746,224 +1838,780 @@ func convertSlice(value any, converters []ValueConverter) (any, error) {
 	return v, err
 }
 
+// IfNotNil returns a ValueConverter that applies converters only when value is not nil.
 func IfNotNil(converters ...ValueConverter) ValueConverter {
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return value, nil
-		}
-
-		return convertSlice(value, converters)
-	})
+	return ifNotNilValueConverter{converters: converters}
 }
 
-// SingleLineString returns a ValueConverter that converts a string value to a normalized string. If value is nil then nil is
-// returned. If value is not a string then an error is returned.
-//
-// It performs the following operations:
-//   - Remove any invalid UTF-8
-//   - Replace non-printable characters with standard space
-//   - Remove spaces from left and right
-func SingleLineString() ValueConverter {
-	return singleLineStringValueConverter{}
+type ifNotNilValueConverter struct {
+	converters []ValueConverter
 }
 
-type singleLineStringValueConverter struct{}
-
-func (c singleLineStringValueConverter) ConvertValue(value any) (any, error) {
+func (c ifNotNilValueConverter) ConvertValue(value any) (any, error) {
 	if value == nil {
-		return nil, nil
+		return value, nil
 	}
 
-	if s, ok := value.(string); ok {
-		s = strings.ToValidUTF8(s, "")
-		s = strings.Map(func(r rune) rune {
-			if unicode.IsPrint(r) {
-				return r
-			} else {
-				return ' '
-			}
-		}, s)
-		s = strings.TrimSpace(s)
+	return convertSlice(value, c.converters)
+}
 
-		return s, nil
+func (c ifNotNilValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	if value == nil {
+		return value, nil
 	}
 
-	return nil, errors.New("not a string")
+	return convertSliceWithoutDependencies(ctx, value, c.converters)
 }
 
-func (c singleLineStringValueConverter) ConvertedType() reflect.Type {
-	return reflect.TypeOf("")
+func (c ifNotNilValueConverter) convertWithDependencies(ctx context.Context, value any, deps map[string]any) (any, error) {
+	if value == nil {
+		return value, nil
+	}
+
+	return convertSliceWithDependencies(ctx, value, c.converters, deps)
 }
 
-// MultiLineString returns a ValueConverter that converts a string value to a normalized string. If value is nil then nil is
-// returned. If value is not a string then an error is returned.
-//
-// It performs the following operations:
-//   - Remove any invalid UTF-8
-//   - Replace characters that are not graphic or space with standard space
-func MultiLineString() ValueConverter {
-	return multiLineStringValueConverter{}
+func (c ifNotNilValueConverter) wrappedConverters() []ValueConverter {
+	return c.converters
 }
 
-type multiLineStringValueConverter struct{}
+// ConvertedType returns the converted type of the last wrapped converter that implements ConvertedTyper. If there is
+// none then the type of any is returned.
+func (c ifNotNilValueConverter) ConvertedType() reflect.Type {
+	return lastConvertedType(c.converters)
+}
 
-func (c multiLineStringValueConverter) ConvertValue(value any) (any, error) {
-	if value == nil {
-		return nil, nil
+func lastConvertedType(converters []ValueConverter) reflect.Type {
+	for i := len(converters) - 1; i >= 0; i-- {
+		if ct, ok := converters[i].(ConvertedTyper); ok {
+			return ct.ConvertedType()
+		}
 	}
+	return anyType
+}
 
-	if s, ok := value.(string); ok {
-		s = strings.ToValidUTF8(s, "")
-		s = strings.Map(func(r rune) rune {
-			if unicode.IsGraphic(r) || unicode.IsSpace(r) {
-				return r
-			} else {
-				return ' '
-			}
-		}, s)
+func (c ifNotNilValueConverter) ConverterParams() map[string]any {
+	converters := make([]ValueConverter, len(c.converters))
+	copy(converters, c.converters)
+	return map[string]any{"converters": converters}
+}
 
-		return s, nil
-	}
+// Optional returns a ValueConverter that marks a field as optional. When the field is missing from the input the field
+// is skipped entirely. When the field is present, including when it is present with a nil value, converters are
+// applied. Converters wrapped by Optional do not make the field required for introspection purposes.
+func Optional(converters ...ValueConverter) ValueConverter {
+	return optionalValueConverter{converters: converters}
+}
 
-	return nil, errors.New("not a string")
+type optionalValueConverter struct {
+	converters []ValueConverter
 }
 
-func (c multiLineStringValueConverter) ConvertedType() reflect.Type {
-	return reflect.TypeOf("")
+func (c optionalValueConverter) ConvertValue(value any) (any, error) {
+	return convertSlice(value, c.converters)
 }
 
-// normalizeForParsing prepares value for parsing. If the value is not a string it is returned. Otherwise, space is
-// trimmed from both sides of the string. If the string is now empty then nil is returned. Otherwise, the string is
-// returned.
-func normalizeForParsing(value any) any {
-	if s, ok := value.(string); ok {
-		s = strings.TrimSpace(s)
-		if s == "" {
-			return nil
-		}
-		return s
-	}
-	return value
+func (c optionalValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	return convertSliceWithoutDependencies(ctx, value, c.converters)
 }
 
-// NilifyEmpty converts strings, slices, and maps where len(value) == 0 to nil. Any other value not modified.
-func NilifyEmpty() ValueConverter {
-	return ValueConverterFunc(func(value any) (any, error) {
-		n, ok := tryLen(value)
-		if ok && n == 0 {
-			return nil, nil
-		}
-		return value, nil
-	})
+func (c optionalValueConverter) convertWithDependencies(ctx context.Context, value any, deps map[string]any) (any, error) {
+	return convertSliceWithDependencies(ctx, value, c.converters, deps)
 }
 
-func tryLen(value any) (n int, ok bool) {
-	s, ok := value.(string)
-	if ok {
-		return len(s), true
-	}
+func (c optionalValueConverter) wrappedConverters() []ValueConverter {
+	return c.converters
+}
 
-	refval := reflect.ValueOf(value)
-	switch refval.Kind() {
-	case reflect.String, reflect.Slice, reflect.Map:
-		return refval.Len(), true
-	}
+func (c optionalValueConverter) IsOptional() {}
 
-	return 0, false
+// ConvertedType returns the converted type of the last wrapped converter that implements ConvertedTyper. If there is
+// none then the type of any is returned.
+func (c optionalValueConverter) ConvertedType() reflect.Type {
+	return lastConvertedType(c.converters)
 }
 
-// MinLen returns a ValueConverter that fails if len(value) < min. value must be a string, slice, or map. nil is
-// returned unmodified.
-func MinLen(min int) ValueConverter {
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return nil, nil
+func (c optionalValueConverter) ConverterParams() map[string]any {
+	converters := make([]ValueConverter, len(c.converters))
+	copy(converters, c.converters)
+	return map[string]any{"converters": converters}
+}
+
+// Drop returns a ValueConverter that marks a field as dropped. Any value supplied for the field is silently discarded
+// and the field never appears in the Record's Attrs. It is intended for fields such as "role" or "is_admin" that must
+// never be assigned from input but that clients may send anyway. Use Forbidden to reject such input instead.
+func Drop() ValueConverter {
+	return dropValueConverter{}
+}
+
+type dropValueConverter struct{}
+
+func (dropValueConverter) ConvertValue(value any) (any, error) {
+	return nil, nil
+}
+
+func (dropValueConverter) IsDropped() {}
+
+// Forbidden returns a ValueConverter that returns an error if value is not nil. It is intended for fields such as
+// "role" or "is_admin" that must never be assigned from input. Use Drop to silently discard such input instead.
+func Forbidden() ValueConverter {
+	return forbiddenValueConverter{}
+}
+
+type forbiddenValueConverter struct{}
+
+func (forbiddenValueConverter) ConvertValue(value any) (any, error) {
+	if value != nil {
+		return nil, errForbidden
+	}
+	return nil, nil
+}
+
+func (forbiddenValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"forbidden": true}
+}
+
+// Default returns a ValueConverter that replaces nil with value. Any other value is returned unmodified.
+func Default(value any) ValueConverter {
+	return defaultValueConverter{value: value}
+}
+
+type defaultValueConverter struct {
+	value any
+}
+
+func (c defaultValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return c.value, nil
+	}
+
+	return value, nil
+}
+
+func (c defaultValueConverter) IsNullable() {}
+
+func (c defaultValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"default": c.value}
+}
+
+// Equals returns a ValueConverter that returns an error unless value equals expected. Values are compared by type as
+// with Record.Equal so it should be used after a converter that produces the type of expected. e.g. Equals(2) after
+// Int64() to pin an API version, or Equals("") after String() for a honeypot field that must be left empty. Numbers of
+// different types such as int and int64 are equal if they have the same value. If value is nil then nil is returned.
+// Use Require to reject nil.
+func Equals(expected any) ValueConverter {
+	if expected == nil {
+		return equalsValueConverter{err: newKindError(ErrNotAllowed, "must be nil")}
+	}
+	return equalsValueConverter{expected: expected, err: newKindError(ErrNotAllowed, fmt.Sprintf("must be %v", expected))}
+}
+
+type equalsValueConverter struct {
+	expected any
+	err      error
+}
+
+func (c equalsValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if c.expected == nil {
+		return nil, c.err
+	}
+
+	if !valuesEqual(value, c.expected) && !numbersEqual(value, c.expected) {
+		return nil, c.err
+	}
+
+	return value, nil
+}
+
+func (c equalsValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"equals": c.expected}
+}
+
+// numbersEqual returns true if a and b are both numbers with the same value.
+func numbersEqual(a, b any) bool {
+	if !isNumber(a) || !isNumber(b) {
+		return false
+	}
+	an, ok1 := tryDecimal(a)
+	bn, ok2 := tryDecimal(b)
+	return ok1 && ok2 && an.Equal(bn)
+}
+
+func isNumber(value any) bool {
+	switch value.(type) {
+	case nil:
+		return false
+	case decimal.Decimal, json.Number:
+		return true
+	}
+	return isNumericKind(reflect.TypeOf(value).Kind())
+}
+
+// Const returns a ValueConverter that converts any value, including nil, to value. It is intended for server
+// controlled fields in a Type shared with client input. The client cannot influence the field.
+func Const(value any) ValueConverter {
+	return constValueConverter{value: value}
+}
+
+type constValueConverter struct {
+	value any
+}
+
+func (c constValueConverter) ConvertValue(any) (any, error) {
+	return c.value, nil
+}
+
+func (c constValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf(c.value)
+}
+
+func (c constValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"const": c.value}
+}
+
+// SingleLineString returns a ValueConverter that converts a string value to a normalized string. If value is nil then nil is
+// returned. If value is not a string then an error is returned.
+//
+// It performs the following operations:
+//   - Remove any invalid UTF-8
+//   - Replace non-printable characters with standard space
+//   - Remove spaces from left and right
+func SingleLineString() ValueConverter {
+	return singleLineStringValueConverter{}
+}
+
+type singleLineStringValueConverter struct{}
+
+func (c singleLineStringValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if s, ok := value.(string); ok {
+		// Fast path for strings that are already normalized. Returning value avoids allocating a new string and a new
+		// interface value.
+		if allRunesValid(s, isSingleLineRune) {
+			trimmed := strings.TrimSpace(s)
+			if len(trimmed) == len(s) {
+				return value, nil
+			}
+			return trimmed, nil
 		}
 
-		n, ok := tryLen(value)
-		if !ok {
-			return nil, errors.New("not a string, slice or map")
+		s = strings.ToValidUTF8(s, "")
+		s = strings.Map(func(r rune) rune {
+			if isSingleLineRune(r) {
+				return r
+			} else {
+				return ' '
+			}
+		}, s)
+		s = strings.TrimSpace(s)
+
+		return s, nil
+	}
+
+	return nil, errors.New("not a string")
+}
+
+func (c singleLineStringValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf("")
+}
+
+// MultiLineString returns a ValueConverter that converts a string value to a normalized string. If value is nil then nil is
+// returned. If value is not a string then an error is returned.
+//
+// It performs the following operations:
+//   - Remove any invalid UTF-8
+//   - Replace characters that are not graphic or space with standard space
+func MultiLineString() ValueConverter {
+	return multiLineStringValueConverter{}
+}
+
+// MultiLineStringOptions configures the converter returned by MultiLineStringWithOptions.
+type MultiLineStringOptions struct {
+	// NormalizeNewlines replaces "\r\n" and "\r" with "\n".
+	NormalizeNewlines bool
+
+	// TabWidth expands tabs to spaces up to the next multiple of TabWidth columns. If TabWidth is 0 then tabs are
+	// kept.
+	TabWidth int
+
+	// MaxBlankLines limits the number of consecutive blank lines. Additional blank lines are removed. A line is blank if
+	// it contains only white space. If MaxBlankLines is 0 then there is no limit.
+	MaxBlankLines int
+}
+
+// MultiLineStringWithOptions is like MultiLineString but with options. The options are applied after the operations of
+// MultiLineString in the order they are listed in MultiLineStringOptions.
+func MultiLineStringWithOptions(options MultiLineStringOptions) ValueConverter {
+	return multiLineStringValueConverter{options: options}
+}
+
+type multiLineStringValueConverter struct {
+	options MultiLineStringOptions
+}
+
+func (c multiLineStringValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if s, ok := value.(string); ok {
+		// Fast path for strings that are already normalized.
+		if !allRunesValid(s, isMultiLineRune) {
+			s = strings.ToValidUTF8(s, "")
+			s = strings.Map(func(r rune) rune {
+				if isMultiLineRune(r) {
+					return r
+				} else {
+					return ' '
+				}
+			}, s)
 		}
 
-		if n < min {
-			return nil, fmt.Errorf("too short")
+		if c.options.NormalizeNewlines {
+			s = normalizeNewlines(s)
+		}
+		if c.options.TabWidth > 0 {
+			s = expandTabs(s, c.options.TabWidth)
+		}
+		if c.options.MaxBlankLines > 0 {
+			s = limitBlankLines(s, c.options.MaxBlankLines)
 		}
 
-		return value, nil
-	})
+		return s, nil
+	}
+
+	return nil, errors.New("not a string")
 }
 
-// MaxLen returns a ValueConverter that fails if len(value) > max. value must be a string, slice, or map. nil is
-// returned unmodified.
-func MaxLen(max int) ValueConverter {
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return nil, nil
+func (c multiLineStringValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf("")
+}
+
+func isSingleLineRune(r rune) bool {
+	return unicode.IsPrint(r)
+}
+
+func isMultiLineRune(r rune) bool {
+	return unicode.IsGraphic(r) || unicode.IsSpace(r)
+}
+
+// allRunesValid returns true if s is valid UTF-8 and valid returns true for every rune in s.
+func allRunesValid(s string, valid func(r rune) bool) bool {
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b < utf8.RuneSelf {
+			if !valid(rune(b)) {
+				return false
+			}
+			i++
+			continue
 		}
 
-		n, ok := tryLen(value)
-		if !ok {
-			return nil, errors.New("not a string, slice or map")
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			return false
 		}
+		if !valid(r) {
+			return false
+		}
+		i += size
+	}
+
+	return true
+}
 
-		if n > max {
-			return nil, fmt.Errorf("too long")
+// normalizeForParsing prepares value for parsing. If the value is not a string it is returned. Otherwise, space is
+// trimmed from both sides of the string. If the string is now empty then nil is returned. Otherwise, the string is
+// returned.
+func normalizeForParsing(value any) any {
+	if s, ok := value.(string); ok {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return nil
 		}
+		return s
+	}
+	return value
+}
 
-		return value, nil
-	})
+// NilifyEmpty converts strings, slices, and maps where len(value) == 0 to nil. Any other value not modified.
+func NilifyEmpty() ValueConverter {
+	return nilifyEmptyValueConverter{}
+}
+
+type nilifyEmptyValueConverter struct{}
+
+func (c nilifyEmptyValueConverter) ConvertValue(value any) (any, error) {
+	n, ok := tryLen(value)
+	if ok && n == 0 {
+		return nil, nil
+	}
+	return value, nil
+}
+
+func tryLen(value any) (n int, ok bool) {
+	// Fast paths for common types. reflect is only used for other types.
+	switch value := value.(type) {
+	case string:
+		return len(value), true
+	case []any:
+		return len(value), true
+	case []string:
+		return len(value), true
+	case []int:
+		return len(value), true
+	case []int32:
+		return len(value), true
+	case []int64:
+		return len(value), true
+	case []float64:
+		return len(value), true
+	case []byte:
+		return len(value), true
+	case []*Record:
+		return len(value), true
+	case map[string]any:
+		return len(value), true
+	case map[string]string:
+		return len(value), true
+	case nil:
+		return 0, false
+	}
+
+	refval := reflect.ValueOf(value)
+	switch refval.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return refval.Len(), true
+	}
+
+	return 0, false
+}
+
+// MinLen returns a ValueConverter that fails if len(value) < min. value must be a string, slice, or map. nil is
+// returned unmodified.
+func MinLen(min int) ValueConverter {
+	return minLenValueConverter{min: min}
+}
+
+type minLenValueConverter struct {
+	min int
+}
+
+func (c minLenValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	n, ok := tryLen(value)
+	if !ok {
+		return nil, errors.New("not a string, slice or map")
+	}
+
+	if n < c.min {
+		return nil, errTooShort
+	}
+
+	return value, nil
+}
+
+func (c minLenValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"minLen": c.min}
+}
+
+// MaxLen returns a ValueConverter that fails if len(value) > max. value must be a string, slice, or map. nil is
+// returned unmodified.
+func MaxLen(max int) ValueConverter {
+	return maxLenValueConverter{max: max}
+}
+
+type maxLenValueConverter struct {
+	max int
+}
+
+func (c maxLenValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	n, ok := tryLen(value)
+	if !ok {
+		return nil, errors.New("not a string, slice or map")
+	}
+
+	if n > c.max {
+		return nil, errTooLong
+	}
+
+	return value, nil
+}
+
+func (c maxLenValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"maxLen": c.max}
 }
 
 // AllowStrings returns a ValueConverter that returns an error unless value is one of the allowedItems. If value is nil
 // then nil is returned. If value is not a string then an error is returned.
 func AllowStrings(allowedItems ...string) ValueConverter {
-	set := make(map[string]struct{}, len(allowedItems))
-	for _, item := range allowedItems {
-		set[item] = struct{}{}
+	return AllowStringsWithOptions(StringSetOptions{}, allowedItems...)
+}
+
+// AllowStringsWithOptions is like AllowStrings but with options.
+func AllowStringsWithOptions(options StringSetOptions, allowedItems ...string) ValueConverter {
+	items := newStringSetWithMatching(allowedItems, options.Matching)
+	return &stringSetValueConverter{items: items, allow: true, options: options}
+}
+
+// ExcludeStrings returns a ValueConverter that returns an error if value is one of the excludedItems. If value is nil
+// then nil is returned. If value is not a string then an error is returned.
+func ExcludeStrings(excludedItems ...string) ValueConverter {
+	return ExcludeStringsWithOptions(StringSetOptions{}, excludedItems...)
+}
+
+// ExcludeStringsWithOptions is like ExcludeStrings but with options. StringSetOptions.Canonicalize has no effect.
+func ExcludeStringsWithOptions(options StringSetOptions, excludedItems ...string) ValueConverter {
+	items := newStringSetWithMatching(excludedItems, options.Matching)
+	return &stringSetValueConverter{items: items, allow: false, options: options}
+}
+
+// StringMatching controls how strings are compared by AllowStringsWithOptions and ExcludeStringsWithOptions.
+type StringMatching int
+
+const (
+	// MatchExact compares strings byte for byte.
+	MatchExact StringMatching = iota
+
+	// MatchCaseInsensitive compares strings after converting them to lower case.
+	MatchCaseInsensitive
+
+	// MatchFoldCase compares strings under Unicode case folding like strings.EqualFold. It is more thorough than
+	// MatchCaseInsensitive. For example, "ſ" (long s) matches "s".
+	MatchFoldCase
+)
+
+// String returns the name of m as used in converter params.
+func (m StringMatching) String() string {
+	switch m {
+	case MatchExact:
+		return "exact"
+	case MatchCaseInsensitive:
+		return "caseInsensitive"
+	case MatchFoldCase:
+		return "foldCase"
 	}
+	return fmt.Sprintf("StringMatching(%d)", int(m))
+}
 
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return value, nil
-		}
+// StringSetOptions configures AllowStringsWithOptions and ExcludeStringsWithOptions.
+type StringSetOptions struct {
+	Matching StringMatching
 
-		s, ok := value.(string)
-		if !ok {
-			return nil, fmt.Errorf("not allowed value")
+	// Canonicalize converts an allowed value to the item it matched. For example, with MatchCaseInsensitive and the
+	// allowed item "active", the input "ACTIVE" is converted to "active".
+	Canonicalize bool
+}
+
+type stringSet struct {
+	list     []string
+	set      map[string]struct{}
+	matching StringMatching
+
+	// canonical maps the keys of set to the item they were created from.
+	canonical map[string]string
+}
+
+func newStringSet(items []string) stringSet {
+	return newStringSetWithMatching(items, MatchExact)
+}
+
+func newStringSetWithMatching(items []string, matching StringMatching) stringSet {
+	ss := stringSet{
+		list:      make([]string, len(items)),
+		set:       make(map[string]struct{}, len(items)),
+		matching:  matching,
+		canonical: make(map[string]string, len(items)),
+	}
+	copy(ss.list, items)
+	for _, item := range items {
+		key := ss.key(item)
+		ss.set[key] = struct{}{}
+		if _, ok := ss.canonical[key]; !ok {
+			ss.canonical[key] = item
 		}
+	}
+	return ss
+}
+
+// key returns the form of s used for comparison.
+func (ss stringSet) key(s string) string {
+	switch ss.matching {
+	case MatchCaseInsensitive:
+		return strings.ToLower(s)
+	case MatchFoldCase:
+		return foldString(s)
+	}
+	return s
+}
 
-		if _, ok := set[s]; !ok {
-			return nil, fmt.Errorf("not allowed value")
+// foldString returns s with each rune replaced by the smallest rune that is equivalent to it under Unicode simple case
+// folding. Two strings are equal under strings.EqualFold if and only if their folded forms are equal.
+func foldString(s string) string {
+	return strings.Map(func(r rune) rune {
+		smallest := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < smallest {
+				smallest = f
+			}
 		}
+		return smallest
+	}, s)
+}
+
+// stringSetValueConverter implements AllowStrings and ExcludeStrings.
+type stringSetValueConverter struct {
+	items   stringSet
+	allow   bool
+	options StringSetOptions
+}
 
+func (c *stringSetValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
 		return value, nil
-	})
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errNotAllowedValue
+	}
+
+	key := c.items.key(s)
+	if _, ok := c.items.set[key]; ok != c.allow {
+		return nil, errNotAllowedValue
+	}
+
+	if c.allow && c.options.Canonicalize {
+		return c.items.canonical[key], nil
+	}
+
+	return value, nil
 }
 
-// ExcludeStrings returns a ValueConverter that returns an error if value is one of the excludedItems. If value is nil
-// then nil is returned. If value is not a string then an error is returned.
-func ExcludeStrings(excludedItems ...string) ValueConverter {
-	set := make(map[string]struct{}, len(excludedItems))
-	for _, item := range excludedItems {
-		set[item] = struct{}{}
+func (c *stringSetValueConverter) ConverterParams() map[string]any {
+	items := make([]string, len(c.items.list))
+	copy(items, c.items.list)
+
+	var params map[string]any
+	if c.allow {
+		params = map[string]any{"allowStrings": items}
+	} else {
+		params = map[string]any{"excludeStrings": items}
+	}
+	if c.options.Matching != MatchExact {
+		params["stringMatching"] = c.options.Matching.String()
 	}
+	return params
+}
 
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return value, nil
-		}
+// In returns a ValueConverter that returns an error unless value is one of items. Values are compared after
+// conversion so In is intended to be used after a converter that produces T. e.g. In[int32](1, 2, 3) after Int32() or
+// In[uuid.UUID](ids...) after UUID(). If value is nil then nil is returned. If value is not a T then an error is
+// returned.
+func In[T comparable](items ...T) ValueConverter {
+	return newValueSetValueConverter(items, true)
+}
 
-		s, ok := value.(string)
-		if !ok {
-			return nil, fmt.Errorf("not allowed value")
-		}
+// NotIn returns a ValueConverter that returns an error if value is one of items. Values are compared after conversion
+// as with In. If value is nil then nil is returned. If value is not a T then an error is returned.
+func NotIn[T comparable](items ...T) ValueConverter {
+	return newValueSetValueConverter(items, false)
+}
 
-		if _, ok := set[s]; ok {
-			return nil, fmt.Errorf("not allowed value")
-		}
+// AllowValues is equivalent to In. It is named for symmetry with AllowStrings.
+func AllowValues[T comparable](allowedItems ...T) ValueConverter {
+	return In(allowedItems...)
+}
+
+// valueSetValueConverter implements In and NotIn.
+type valueSetValueConverter[T comparable] struct {
+	list  []T
+	set   map[T]struct{}
+	allow bool
+}
+
+func newValueSetValueConverter[T comparable](items []T, allow bool) *valueSetValueConverter[T] {
+	c := &valueSetValueConverter[T]{
+		list:  make([]T, len(items)),
+		set:   make(map[T]struct{}, len(items)),
+		allow: allow,
+	}
+	copy(c.list, items)
+	for _, item := range items {
+		c.set[item] = struct{}{}
+	}
+	return c
+}
+
+func (c *valueSetValueConverter[T]) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	v, ok := value.(T)
+	if !ok {
+		return nil, errNotAllowedValue
+	}
+
+	if _, ok := c.set[v]; ok != c.allow {
+		return nil, errNotAllowedValue
+	}
+
+	return value, nil
+}
+
+func (c *valueSetValueConverter[T]) ConverterParams() map[string]any {
+	items := make([]T, len(c.list))
+	copy(items, c.list)
+	if c.allow {
+		return map[string]any{"allowValues": items}
+	}
+	return map[string]any{"excludeValues": items}
+}
+
+// Matches returns a ValueConverter that returns an error unless value matches re. If value is nil then nil is returned.
+// If value is not a string then an error is returned.
+func Matches(re *regexp.Regexp) ValueConverter {
+	return &matchesValueConverter{re: re}
+}
+
+type matchesValueConverter struct {
+	re *regexp.Regexp
+}
 
+func (c *matchesValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
 		return value, nil
-	})
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	if !c.re.MatchString(s) {
+		return nil, errInvalidFormat
+	}
+
+	return value, nil
+}
+
+func (c *matchesValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"pattern": c.re.String()}
 }
 
 func tryDecimal(value any) (n decimal.Decimal, ok bool) {
@@ -978,11 +2626,19 @@ func tryDecimal(value any) (n decimal.Decimal, ok bool) {
 	case int:
 		return decimal.NewFromInt(int64(value)), true
 	case float32:
+		if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+			return decimal.Zero, false
+		}
 		return decimal.NewFromFloat32(value), true
 	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return decimal.Zero, false
+		}
 		return decimal.NewFromFloat(value), true
 	case string:
 		strValue = value
+	case json.Number:
+		strValue = string(value)
 	default:
 		strValue = fmt.Sprint(value)
 	}
@@ -995,106 +2651,98 @@ func tryDecimal(value any) (n decimal.Decimal, ok bool) {
 	return n, true
 }
 
-// LessThan returns a ValueConverter that fails unless value < x. x must be convertable to a decimal number or LessThan
-// panics. value must be convertable to a decimal number. nil is returned unmodified.
-func LessThan(x any) ValueConverter {
+type comparisonOp int
+
+const (
+	lessThanOp comparisonOp = iota
+	lessThanOrEqualOp
+	greaterThanOp
+	greaterThanOrEqualOp
+)
+
+// comparisonValueConverter implements LessThan, LessThanOrEqual, GreaterThan, and GreaterThanOrEqual.
+type comparisonValueConverter struct {
+	op    comparisonOp
+	limit decimal.Decimal
+}
+
+func newComparisonValueConverter(op comparisonOp, x any) comparisonValueConverter {
 	dx, ok := tryDecimal(x)
 	if !ok {
 		panic(fmt.Errorf("%v is not convertable to a decimal number", x))
 	}
 
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return nil, nil
-		}
-
-		n, ok := tryDecimal(value)
-		if !ok {
-			return nil, fmt.Errorf("not a number")
-		}
-
-		if !n.LessThan(dx) {
-			return nil, fmt.Errorf("too large")
-		}
-
-		return value, nil
-	})
+	return comparisonValueConverter{op: op, limit: dx}
 }
 
-// LessThanOrEqual returns a ValueConverter that fails unless value <= x. x must be convertable to a decimal number or
-// LessThanOrEqual panics. value must be convertable to a decimal number. nil is returned unmodified.
-func LessThanOrEqual(x any) ValueConverter {
-	dx, ok := tryDecimal(x)
+func (c comparisonValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	n, ok := tryDecimal(value)
 	if !ok {
-		panic(fmt.Errorf("%v is not convertable to a decimal number", x))
+		return nil, errNotANumber
 	}
 
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return nil, nil
+	switch c.op {
+	case lessThanOp:
+		if !n.LessThan(c.limit) {
+			return nil, errTooLarge
 		}
-
-		n, ok := tryDecimal(value)
-		if !ok {
-			return nil, fmt.Errorf("not a number")
+	case lessThanOrEqualOp:
+		if !n.LessThanOrEqual(c.limit) {
+			return nil, errTooLarge
 		}
-
-		if !n.LessThanOrEqual(dx) {
-			return nil, fmt.Errorf("too large")
+	case greaterThanOp:
+		if !n.GreaterThan(c.limit) {
+			return nil, errTooSmall
+		}
+	case greaterThanOrEqualOp:
+		if !n.GreaterThanOrEqual(c.limit) {
+			return nil, errTooSmall
 		}
+	}
 
-		return value, nil
-	})
+	return value, nil
 }
 
-// GreaterThan returns a ValueConverter that fails unless value > x. x must be convertable to a decimal number or
-// GreaterThan panics. value must be convertable to a decimal number. nil is returned unmodified.
-func GreaterThan(x any) ValueConverter {
-	dx, ok := tryDecimal(x)
-	if !ok {
-		panic(fmt.Errorf("%v is not convertable to a decimal number", x))
+func (c comparisonValueConverter) ConverterParams() map[string]any {
+	var name string
+	switch c.op {
+	case lessThanOp:
+		name = "lessThan"
+	case lessThanOrEqualOp:
+		name = "lessThanOrEqual"
+	case greaterThanOp:
+		name = "greaterThan"
+	case greaterThanOrEqualOp:
+		name = "greaterThanOrEqual"
 	}
 
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return nil, nil
-		}
+	return map[string]any{name: c.limit}
+}
 
-		n, ok := tryDecimal(value)
-		if !ok {
-			return nil, fmt.Errorf("not a number")
-		}
+// LessThan returns a ValueConverter that fails unless value < x. x must be convertable to a decimal number or LessThan
+// panics. value must be convertable to a decimal number. nil is returned unmodified.
+func LessThan(x any) ValueConverter {
+	return newComparisonValueConverter(lessThanOp, x)
+}
 
-		if !n.GreaterThan(dx) {
-			return nil, fmt.Errorf("too small")
-		}
+// LessThanOrEqual returns a ValueConverter that fails unless value <= x. x must be convertable to a decimal number or
+// LessThanOrEqual panics. value must be convertable to a decimal number. nil is returned unmodified.
+func LessThanOrEqual(x any) ValueConverter {
+	return newComparisonValueConverter(lessThanOrEqualOp, x)
+}
 
-		return value, nil
-	})
+// GreaterThan returns a ValueConverter that fails unless value > x. x must be convertable to a decimal number or
+// GreaterThan panics. value must be convertable to a decimal number. nil is returned unmodified.
+func GreaterThan(x any) ValueConverter {
+	return newComparisonValueConverter(greaterThanOp, x)
 }
 
 // GreaterThanOrEqual returns a ValueConverter that fails unless value >= x. x must be convertable to a decimal number
 // or GreaterThanOrEqual panics. value must be convertable to a decimal number. nil is returned unmodified.
 func GreaterThanOrEqual(x any) ValueConverter {
-	dx, ok := tryDecimal(x)
-	if !ok {
-		panic(fmt.Errorf("%v is not convertable to a decimal number", x))
-	}
-
-	return ValueConverterFunc(func(value any) (any, error) {
-		if value == nil {
-			return nil, nil
-		}
-
-		n, ok := tryDecimal(value)
-		if !ok {
-			return nil, fmt.Errorf("not a number")
-		}
-
-		if !n.GreaterThanOrEqual(dx) {
-			return nil, fmt.Errorf("too small")
-		}
-
-		return value, nil
-	})
+	return newComparisonValueConverter(greaterThanOrEqualOp, x)
 }
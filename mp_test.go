@@ -1,10 +1,17 @@
 package mp_test
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
 	"regexp"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gofrs/uuid/v5"
 	"github.com/jackc/mp"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +29,100 @@ func TestType(t *testing.T) {
 	assert.Equal(t, "Adam", record.Get("name"))
 }
 
+func TestTypeAfterParse(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("first_name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("last_name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("full_name"),
+	)
+	ft.AfterParse(func(r *mp.Record) error {
+		r.Set("full_name", r.Get("first_name").(string)+" "+r.Get("last_name").(string))
+		return nil
+	})
+
+	record := ft.Parse(map[string]any{"first_name": "Adam", "last_name": "Smith"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Adam Smith", record.Get("full_name"))
+
+	record = ft.Parse(map[string]any{"first_name": "Adam"})
+	require.Error(t, record.Errors())
+	assert.Nil(t, record.Get("full_name"))
+}
+
+func TestTypeAfterParseError(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("start", mp.Int64()),
+		mp.NewField("end", mp.Int64()),
+	)
+	ft.AfterParse(func(r *mp.Record) error {
+		if r.Get("start").(int64) > r.Get("end").(int64) {
+			return mp.Errors{"end": errors.New("must be after start")}
+		}
+		return nil
+	})
+	ft.AfterParse(func(r *mp.Record) error {
+		return errors.New("always fails")
+	})
+
+	record := ft.Parse(map[string]any{"start": 2, "end": 1})
+	assert.EqualError(t, record.Errors(), "end must be after start")
+
+	record = ft.Parse(map[string]any{"start": 1, "end": 2})
+	assert.EqualError(t, record.Errors(), "base always fails")
+}
+
+func TestNewTypeCopiesFields(t *testing.T) {
+	fields := []mp.Field{mp.NewField("a"), mp.NewField("b")}
+	ft := mp.NewType(fields...)
+	fields[0] = mp.NewField("z")
+
+	assert.Equal(t, "a", ft.Fields()[0].Name())
+}
+
+func TestTypeConcurrentUse(t *testing.T) {
+	itemType := mp.NewType(
+		mp.NewField("sku", mp.SingleLineString(), mp.Require()),
+	)
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int32(), mp.GreaterThanOrEqual(0)),
+		mp.NewField("items", mp.Slice[*mp.Record](itemType)),
+		mp.NewField("status", mp.AllowStrings("active", "inactive")),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				record := ft.Parse(map[string]any{
+					"name":   fmt.Sprintf("name %d", i),
+					"age":    j,
+					"items":  []any{map[string]any{"sku": "abc"}},
+					"status": "active",
+				})
+				if !assert.NoError(t, record.Errors()) {
+					return
+				}
+				record.Freeze()
+				_ = record.Attrs()
+				_ = record.Clone()
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 10; j++ {
+			ft.AfterParse(func(r *mp.Record) error { return nil })
+		}
+	}()
+
+	wg.Wait()
+}
+
 func TestTypeNewError(t *testing.T) {
 	ft := mp.NewType(
 		mp.NewField("age", mp.Int64()),
@@ -40,6 +141,51 @@ func TestTypeNewRequiredError(t *testing.T) {
 	require.Error(t, record.Errors())
 }
 
+func TestTypeParsePartial(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64(), mp.Require()),
+		mp.NewField("nickname", mp.SingleLineString(), mp.Default("none")),
+	)
+	ft.AfterParse(func(r *mp.Record) error {
+		return errors.New("hook called")
+	})
+
+	record := ft.ParsePartial(map[string]any{"age": "30"})
+	require.NoError(t, record.Errors())
+	assert.True(t, record.Partial())
+	assert.Equal(t, map[string]any{"age": int64(30)}, record.Attrs())
+	assert.Equal(t, map[string]any{"age": int64(30)}, record.Pick("name", "age"))
+	assert.True(t, record.Clone().Partial())
+
+	record = ft.ParsePartial(map[string]any{"name": nil, "age": "abc"})
+	errs := record.Errors().(mp.Errors)
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs, "name")
+	assert.Contains(t, errs, "age")
+
+	assert.False(t, ft.Parse(map[string]any{"name": "Adam", "age": 30}).Partial())
+}
+
+func TestTypeParsePartialDependencies(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("min", mp.Int64()),
+		mp.NewField("max", mp.Int64(), mp.DependsOn([]string{"min"}, func(value any, deps map[string]any) (any, error) {
+			if value != nil && deps["min"] != nil && value.(int64) < deps["min"].(int64) {
+				return nil, errors.New("must not be less than min")
+			}
+			return value, nil
+		})),
+	)
+
+	record := ft.ParsePartial(map[string]any{"max": "5"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"max": int64(5)}, record.Attrs())
+
+	record = ft.ParsePartial(map[string]any{"min": "10", "max": "5"})
+	assert.EqualError(t, record.Errors(), "max must not be less than min")
+}
+
 func TestRecordAttrs(t *testing.T) {
 	ft := mp.NewType(
 		mp.NewField("a"),
@@ -51,6 +197,131 @@ func TestRecordAttrs(t *testing.T) {
 	assert.Equal(t, map[string]any{"a": "1", "b": "2", "c": "3", "d": nil}, record.Attrs())
 }
 
+func TestRecordAttrsOmitMissing(t *testing.T) {
+	ft := mp.NewTypeWithOptions(mp.TypeOptions{OmitMissing: true},
+		mp.NewField("a"),
+		mp.NewField("b"),
+		mp.NewField("c", mp.Default("x")),
+		mp.NewField("d"),
+	)
+
+	record := ft.Parse(map[string]any{"a": "1", "b": nil})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"a": "1", "b": nil, "c": "x"}, record.Attrs())
+
+	ft = mp.NewTypeWithOptions(mp.TypeOptions{OmitMissing: true},
+		mp.NewField("a", mp.Require()),
+	)
+	record = ft.Parse(map[string]any{})
+	require.Error(t, record.Errors())
+}
+
+func TestTypeStringPolicy(t *testing.T) {
+	ft := mp.NewTypeWithOptions(mp.TypeOptions{StringPolicy: mp.StringPolicy{TrimSpace: true, BlankToNil: true}},
+		mp.NewField("name", mp.String()),
+		mp.NewField("nickname", mp.String()),
+		mp.NewField("password", mp.String()).WithStringPolicy(mp.StringPolicy{}),
+		mp.NewField("age", mp.Int64()),
+	)
+
+	record := ft.Parse(map[string]any{"name": " Adam ", "nickname": "  ", "password": " secret ", "age": 30})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Adam", record.Get("name"))
+	assert.Nil(t, record.Get("nickname"))
+	assert.Equal(t, " secret ", record.Get("password"))
+	assert.Equal(t, int64(30), record.Get("age"))
+
+	ft = mp.NewType(mp.NewField("name", mp.String()))
+	record = ft.Parse(map[string]any{"name": "  "})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "  ", record.Get("name"))
+
+	// Converters that parse strings into other types normalize their input regardless of the policy.
+	ft = mp.NewType(
+		mp.NewField("age", mp.Int64()).WithStringPolicy(mp.StringPolicy{}),
+		mp.NewField("admin", mp.Bool()).WithStringPolicy(mp.StringPolicy{}),
+	)
+	record = ft.Parse(map[string]any{"age": " 30 ", "admin": "  "})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, int64(30), record.Get("age"))
+	assert.Nil(t, record.Get("admin"))
+}
+
+func TestRecordAttrsOptionalField(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("a", mp.Optional(mp.Int64())),
+		mp.NewField("b", mp.Optional(mp.Int64(), mp.Require())),
+		mp.NewField("c", mp.Int64()),
+	)
+
+	record := ft.Parse(map[string]any{})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"c": nil}, record.Attrs())
+
+	record = ft.Parse(map[string]any{"a": "1", "b": "2"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"a": int64(1), "b": int64(2), "c": nil}, record.Attrs())
+
+	record = ft.Parse(map[string]any{"a": nil})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"a": nil, "c": nil}, record.Attrs())
+
+	record = ft.Parse(map[string]any{"b": nil})
+	require.Error(t, record.Errors())
+}
+
+func TestFieldIsOptional(t *testing.T) {
+	assert.True(t, mp.FieldIsOptional(mp.NewField("a", mp.Optional(mp.Require()))))
+	assert.False(t, mp.FieldIsRequired(mp.NewField("a", mp.Optional(mp.Require()))))
+	assert.False(t, mp.FieldIsOptional(mp.NewField("a", mp.Int64())))
+}
+
+func TestRecordAttrsReturnsCopy(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("a"),
+	)
+
+	record := ft.Parse(map[string]any{"a": "1"})
+	attrs := record.Attrs()
+	attrs["a"] = "2"
+	assert.Equal(t, "1", record.Get("a"))
+	assert.Equal(t, map[string]any{"a": "1"}, record.AttrsUnsafe())
+}
+
+func TestRecordSet(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("a"),
+	)
+
+	record := ft.Parse(map[string]any{"a": "1"})
+	record.Set("a", "2")
+	assert.Equal(t, "2", record.Get("a"))
+	assert.PanicsWithError(t, `"b" is not a field of type`, func() { record.Set("b", "2") })
+
+	record.Freeze()
+	assert.True(t, record.Frozen())
+	assert.PanicsWithError(t, "cannot modify frozen record", func() { record.Set("a", "3") })
+}
+
+func TestRecordClone(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("a"),
+		mp.NewField("b", mp.Int64()),
+	)
+
+	record := ft.Parse(map[string]any{"a": "1", "b": "abc"})
+	record.Freeze()
+
+	clone := record.Clone()
+	assert.False(t, clone.Frozen())
+	assert.Equal(t, record.Attrs(), clone.Attrs())
+	assert.Equal(t, record.Errors(), clone.Errors())
+
+	clone.Set("a", "2")
+	assert.Equal(t, "1", record.Get("a"))
+	assert.Equal(t, "2", clone.Get("a"))
+}
+
 func TestRecordGetPanicsWhenFieldNameNotInType(t *testing.T) {
 	ft := mp.NewType(
 		mp.NewField("a"),
@@ -59,6 +330,21 @@ func TestRecordGetPanicsWhenFieldNameNotInType(t *testing.T) {
 	assert.PanicsWithError(t, `"b" is not a field of type`, func() { record.Get("b") })
 }
 
+func TestRecordTryGet(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("a"),
+	)
+	record := ft.Parse(map[string]any{"a": "1", "b": "2"})
+
+	value, ok := record.TryGet("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+
+	value, ok = record.TryGet("b")
+	assert.False(t, ok)
+	assert.Nil(t, value)
+}
+
 func TestRecordPick(t *testing.T) {
 	ft := mp.NewType(
 		mp.NewField("a"),
@@ -89,6 +375,59 @@ func TestRecordPickPanicsWhenFieldNameNotInType(t *testing.T) {
 	assert.PanicsWithError(t, `"z" is not a field of type`, func() { record.Pick("a", "b", "z") })
 }
 
+func TestRecordTryPick(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("a"),
+		mp.NewField("b"),
+	)
+
+	record := ft.Parse(map[string]any{"a": "1", "b": "2"})
+
+	attrs, err := record.TryPick("a")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1"}, attrs)
+
+	attrs, err = record.TryPick("a", "z")
+	require.EqualError(t, err, `"z" is not a field of type`)
+	assert.Nil(t, attrs)
+}
+
+func TestRecordWith(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.Require()),
+		mp.NewField("age", mp.Int64()),
+	)
+
+	base := ft.Parse(map[string]any{"name": "Adam", "age": "30"})
+	require.NoError(t, base.Errors())
+
+	record := base.With(map[string]any{"age": "31"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"name": "Adam", "age": int64(31)}, record.Attrs())
+	assert.Equal(t, int64(30), base.Get("age"))
+
+	record = base.With(map[string]any{"name": ""})
+	require.Error(t, record.Errors())
+}
+
+func TestMergeRecords(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.Require()),
+		mp.NewField("age", mp.Int64()),
+	)
+
+	base := ft.Parse(map[string]any{"name": "Adam", "age": "30"})
+	overlay := ft.Parse(map[string]any{"age": 31})
+
+	record := mp.MergeRecords(base, overlay)
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"name": "Adam", "age": int64(31)}, record.Attrs())
+
+	otherType := mp.NewType(mp.NewField("name"))
+	other := otherType.Parse(map[string]any{"name": "Bob"})
+	assert.PanicsWithError(t, "cannot merge records of different types", func() { mp.MergeRecords(base, other) })
+}
+
 func TestNotNil(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -137,6 +476,10 @@ func TestInt64(t *testing.T) {
 		{float64(1234567890), int64(1234567890), true},
 		{"10.5", nil, false},
 		{"abc", nil, false},
+		{[]byte("42"), int64(42), true},
+		{json.Number("43"), int64(43), true},
+		{true, nil, false},
+		{struct{}{}, nil, false},
 		{nil, nil, true},
 		{"", nil, true},
 		{"  ", nil, true},
@@ -149,6 +492,11 @@ func TestInt64(t *testing.T) {
 	}
 }
 
+func TestInt64UnsupportedTypeError(t *testing.T) {
+	_, err := mp.Int64().ConvertValue(true)
+	require.EqualError(t, err, "cannot convert bool to a number")
+}
+
 func TestFloat64(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -160,6 +508,10 @@ func TestFloat64(t *testing.T) {
 		{" 2 ", float64(2), true},
 		{"10.5", float64(10.5), true},
 		{"abc", nil, false},
+		{[]byte("1.5"), float64(1.5), true},
+		{json.Number("2.5"), float64(2.5), true},
+		{decimal.NewFromFloat(3.5), float64(3.5), true},
+		{false, nil, false},
 		{nil, nil, true},
 		{"", nil, true},
 		{"  ", nil, true},
@@ -195,6 +547,16 @@ func TestFloat32(t *testing.T) {
 	}
 }
 
+func TestFloat32OutOfRange(t *testing.T) {
+	_, err := mp.Float32().ConvertValue("1e39")
+	assert.EqualError(t, err, "greater than maximum allowed float")
+	assert.ErrorIs(t, err, mp.ErrOutOfRange)
+
+	_, err = mp.Float32().ConvertValue("-1e39")
+	assert.EqualError(t, err, "less than minimum allowed float")
+	assert.ErrorIs(t, err, mp.ErrOutOfRange)
+}
+
 func TestBool(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -221,6 +583,33 @@ func TestBool(t *testing.T) {
 	}
 }
 
+func TestBoolPtr(t *testing.T) {
+	trueValue := true
+	falseValue := false
+
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{true, &trueValue, true},
+		{false, &falseValue, true},
+		{"true", &trueValue, true},
+		{" f ", &falseValue, true},
+		{&trueValue, &trueValue, true},
+		{(*bool)(nil), nil, true},
+		{"abc", nil, false},
+		{nil, nil, true},
+		{"", nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.BoolPtr().ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
 func TestTime(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -263,6 +652,8 @@ func TestDecimal(t *testing.T) {
 		{"", nil, true},
 		{"  ", nil, true},
 		{"abc", nil, false},
+		{math.NaN(), nil, false},
+		{math.Inf(1), nil, false},
 	}
 
 	for i, tt := range tests {
@@ -272,6 +663,30 @@ func TestDecimal(t *testing.T) {
 	}
 }
 
+func TestUUID(t *testing.T) {
+	u := uuid.Must(uuid.FromString("e3c2a5f2-3b5c-4f0b-9f5b-1c1f8c0a4b6d"))
+
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"e3c2a5f2-3b5c-4f0b-9f5b-1c1f8c0a4b6d", u, true},
+		{" e3c2a5f2-3b5c-4f0b-9f5b-1c1f8c0a4b6d ", u, true},
+		{u.Bytes(), u, true},
+		{nil, nil, true},
+		{"", nil, true},
+		{"abc", nil, false},
+		{[]byte{1, 2, 3}, nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.UUID().ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
 func TestSliceRecord(t *testing.T) {
 	mpType := mp.NewType(
 		mp.NewField("n", mp.Int32(), mp.Require()),
@@ -347,6 +762,55 @@ func TestSliceString(t *testing.T) {
 	}
 }
 
+func TestSliceConvertedType(t *testing.T) {
+	ct := mp.Slice[int32](mp.Int32()).(mp.ConvertedTyper)
+	assert.Equal(t, reflect.TypeOf([]int32{}), ct.ConvertedType())
+}
+
+func TestTypeConvertedType(t *testing.T) {
+	ft := mp.NewType(mp.NewField("a"))
+	assert.Equal(t, reflect.TypeOf(&mp.Record{}), ft.ConvertedType())
+}
+
+func TestTypeStructType(t *testing.T) {
+	itemType := mp.NewType(
+		mp.NewField("sku", mp.SingleLineString()),
+	)
+
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int32()),
+		mp.NewField("first_name"),
+		mp.NewField("items", mp.Slice[*mp.Record](itemType)),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString())),
+		mp.NewField("parent", itemType),
+	)
+
+	st := ft.StructType()
+	require.Equal(t, reflect.Struct, st.Kind())
+	require.Equal(t, 6, st.NumField())
+
+	tests := []struct {
+		name    string
+		jsonTag string
+		typ     string
+	}{
+		{"Name", "name", "string"},
+		{"Age", "age", "int32"},
+		{"FirstName", "first_name", "interface {}"},
+		{"Items", "items", `[]*struct { Sku string "json:\"sku\"" }`},
+		{"Tags", "tags", "[]string"},
+		{"Parent", "parent", `*struct { Sku string "json:\"sku\"" }`},
+	}
+
+	for i, tt := range tests {
+		sf := st.Field(i)
+		assert.Equalf(t, tt.name, sf.Name, "%d", i)
+		assert.Equalf(t, tt.jsonTag, sf.Tag.Get("json"), "%d", i)
+		assert.Equalf(t, tt.typ, sf.Type.String(), "%d", i)
+	}
+}
+
 func TestSingleLineString(t *testing.T) {
 	tests := []struct {
 		value    any
@@ -362,6 +826,8 @@ func TestSingleLineString(t *testing.T) {
 		{value: "a\u200Ba", expected: "a a", success: true, msg: "replace non-normal spaces"},
 		{value: "a\ta", expected: "a a", success: true, msg: "replace control character"},
 		{value: "a\r\n", expected: "a", success: true, msg: "trim happens after replaced control character"},
+		{value: "日本語 テキスト", expected: "日本語 テキスト", success: true, msg: "multibyte no changes"},
+		{value: "\u00a0a\u00a0", expected: "a", success: true, msg: "trim unicode space"},
 		{value: nil, expected: nil, success: true},
 	}
 
@@ -372,6 +838,28 @@ func TestSingleLineString(t *testing.T) {
 	}
 }
 
+func TestMultiLineString(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+		msg      string
+	}{
+		{value: "a\nb", expected: "a\nb", success: true, msg: "no changes"},
+		{value: " a\r\n", expected: " a\r\n", success: true, msg: "no trim"},
+		{value: "a\xfe\xffa", expected: "aa", success: true, msg: "invalid UTF-8"},
+		{value: "a\x00a", expected: "a a", success: true, msg: "replace control character"},
+		{value: 1, expected: nil, success: false, msg: "not a string"},
+		{value: nil, expected: nil, success: true},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.MultiLineString().ConvertValue(tt.value)
+		assert.Equalf(t, tt.success, err == nil, "%d: %s", i, tt.msg)
+		assert.Equalf(t, tt.expected, value, "%d: %s", i, tt.msg)
+	}
+}
+
 func TestNilifyEmpty(t *testing.T) {
 	type otherString string
 
@@ -386,6 +874,12 @@ func TestNilifyEmpty(t *testing.T) {
 		{[]int{1}, []int{1}},
 		{map[string]any{}, nil},
 		{map[string]any{"foo": "bar"}, map[string]any{"foo": "bar"}},
+		{[]any{}, nil},
+		{[]string{}, nil},
+		{[]byte{}, nil},
+		{map[string]string{}, nil},
+		{[]uint16{}, nil},
+		{0, 0},
 		{nil, nil},
 	}
 
@@ -513,6 +1007,27 @@ func TestExcludeStrings(t *testing.T) {
 	}
 }
 
+func TestMatches(t *testing.T) {
+	vc := mp.Matches(regexp.MustCompile(`^\d+$`))
+
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"123", "123", true},
+		{nil, nil, true},
+		{"12a", nil, false},
+		{123, nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := vc.ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
 func TestLessThan(t *testing.T) {
 	tests := []struct {
 		value      any
@@ -525,6 +1040,7 @@ func TestLessThan(t *testing.T) {
 		{10, nil, 10, regexp.MustCompile(`too large`)},
 		{32.5, nil, 10, regexp.MustCompile(`too large`)},
 		{"11", nil, 10, regexp.MustCompile(`too large`)},
+		{math.NaN(), nil, 10, regexp.MustCompile(`not a number`)},
 		{nil, nil, decimal.NewFromInt(10), nil},
 	}
 
@@ -620,6 +1136,198 @@ func TestGreaterThanOrEqual(t *testing.T) {
 	}
 }
 
+func TestIfNotNil(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"1", int64(1), true},
+		{"abc", nil, false},
+		{nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.IfNotNil(mp.Int64(), mp.Require()).ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestIfNotNilConvertedType(t *testing.T) {
+	ct := mp.IfNotNil(mp.Int64(), mp.LessThan(10)).(mp.ConvertedTyper)
+	assert.Equal(t, reflect.TypeOf(int64(0)), ct.ConvertedType())
+
+	ct = mp.IfNotNil(mp.LessThan(10)).(mp.ConvertedTyper)
+	assert.Equal(t, reflect.Interface, ct.ConvertedType().Kind())
+}
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+	}{
+		{"foo", "foo"},
+		{"", ""},
+		{nil, "bar"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.Default("bar").ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.NoErrorf(t, err, "%d", i)
+	}
+}
+
+func TestEquals(t *testing.T) {
+	tests := []struct {
+		expected any
+		value    any
+		errMsg   string
+	}{
+		{"", "", ""},
+		{"", "bot", "must be "},
+		{2, int64(2), ""},
+		{int64(2), int32(2), ""},
+		{2, int64(3), "must be 2"},
+		{2, "2", "must be 2"},
+		{decimal.RequireFromString("1.50"), decimal.RequireFromString("1.5"), ""},
+		{true, false, "must be true"},
+		{"v2", nil, ""},
+		{nil, nil, ""},
+		{nil, "5", "must be nil"},
+		{nil, int64(5), "must be nil"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.Equals(tt.expected).ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			assert.ErrorIsf(t, err, mp.ErrNotAllowed, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.value, value, "%d", i)
+	}
+}
+
+func TestEqualsNilInType(t *testing.T) {
+	ft := mp.NewType(mp.NewField("v", mp.Int64(), mp.Equals(nil)))
+
+	record := ft.Parse(map[string]any{"v": "5"})
+	assert.EqualError(t, record.Errors(), "v must be nil")
+
+	record = ft.Parse(map[string]any{"v": ""})
+	require.NoError(t, record.Errors())
+	assert.Nil(t, record.Get("v"))
+}
+
+func TestConst(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("role", mp.Const("user")),
+	)
+
+	for i, attrs := range []map[string]any{{}, {"role": "admin"}, {"role": nil}} {
+		record := ft.Parse(attrs)
+		require.NoErrorf(t, record.Errors(), "%d", i)
+		assert.Equalf(t, "user", record.Get("role"), "%d", i)
+	}
+
+	assert.Equal(t, reflect.TypeOf(""), mp.Const("user").(mp.ConvertedTyper).ConvertedType())
+	assert.Equal(t, map[string]any{"const": "user"}, mp.FieldConverterParams(ft.Fields()[1]))
+}
+
+func TestDrop(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("role", mp.Drop()),
+	)
+
+	for i, attrs := range []map[string]any{{"name": "Jack"}, {"name": "Jack", "role": "admin"}, {"name": "Jack", "role": nil}} {
+		record := ft.Parse(attrs)
+		require.NoErrorf(t, record.Errors(), "%d", i)
+		assert.Equalf(t, map[string]any{"name": "Jack"}, record.Attrs(), "%d", i)
+	}
+
+	assert.True(t, mp.FieldIsDropped(ft.Fields()[1]))
+	assert.False(t, mp.FieldIsDropped(ft.Fields()[0]))
+	assert.NoError(t, ft.Check(map[string]any{"name": "Jack", "role": 42}))
+}
+
+func TestForbidden(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("is_admin", mp.Forbidden(), mp.Bool()),
+	)
+
+	record := ft.Parse(map[string]any{"name": "Jack"})
+	require.NoError(t, record.Errors())
+	assert.Nil(t, record.Get("is_admin"))
+
+	record = ft.Parse(map[string]any{"name": "Jack", "is_admin": nil})
+	require.NoError(t, record.Errors())
+
+	record = ft.Parse(map[string]any{"name": "Jack", "is_admin": false})
+	assert.EqualError(t, record.Errors(), "is_admin is not allowed")
+	assert.ErrorIs(t, record.Errors(), mp.ErrNotAllowed)
+}
+
+func TestFieldIsRequired(t *testing.T) {
+	tests := []struct {
+		field    mp.Field
+		expected bool
+	}{
+		{mp.NewField("a"), false},
+		{mp.NewField("a", mp.Int64()), false},
+		{mp.NewField("a", mp.Int64(), mp.Require()), true},
+		{mp.NewField("a", mp.NotNil()), true},
+		{mp.NewField("a", mp.IfNotNil(mp.Require())), false},
+		{mp.NewField("a", mp.Default("foo")), false},
+		{mp.NewField("a", mp.Default("foo"), mp.Require()), false},
+		{mp.NewField("a", mp.All(mp.Default("foo"), mp.Require())), false},
+		{mp.NewField("a", mp.Require(), mp.Default("foo")), true},
+		{mp.NewField("a", mp.IfNotNil(mp.Int64()), mp.Require()), true},
+	}
+
+	for i, tt := range tests {
+		assert.Equalf(t, tt.expected, mp.FieldIsRequired(tt.field), "%d", i)
+	}
+}
+
+func TestConverterParams(t *testing.T) {
+	int64Converter := mp.Int64()
+
+	tests := []struct {
+		converter mp.ValueConverter
+		expected  map[string]any
+	}{
+		{mp.MinLen(3), map[string]any{"minLen": 3}},
+		{mp.MaxLen(5), map[string]any{"maxLen": 5}},
+		{mp.AllowStrings("a", "b"), map[string]any{"allowStrings": []string{"a", "b"}}},
+		{mp.ExcludeStrings("c"), map[string]any{"excludeStrings": []string{"c"}}},
+		{mp.LessThan(10), map[string]any{"lessThan": decimal.NewFromInt(10)}},
+		{mp.LessThanOrEqual(10), map[string]any{"lessThanOrEqual": decimal.NewFromInt(10)}},
+		{mp.GreaterThan(10), map[string]any{"greaterThan": decimal.NewFromInt(10)}},
+		{mp.GreaterThanOrEqual(10), map[string]any{"greaterThanOrEqual": decimal.NewFromInt(10)}},
+		{mp.Time("2006-01-02"), map[string]any{"formats": []string{"2006-01-02"}}},
+		{mp.Default("foo"), map[string]any{"default": "foo"}},
+		{mp.Slice[int64](int64Converter), map[string]any{"element": int64Converter}},
+		{mp.IfNotNil(int64Converter), map[string]any{"converters": []mp.ValueConverter{int64Converter}}},
+	}
+
+	for i, tt := range tests {
+		cp, ok := tt.converter.(mp.ConverterParamser)
+		require.Truef(t, ok, "%d", i)
+		assert.Equalf(t, tt.expected, cp.ConverterParams(), "%d", i)
+	}
+}
+
+func TestFieldConverterParams(t *testing.T) {
+	f := mp.NewField("name", mp.SingleLineString(), mp.MinLen(1), mp.MaxLen(10), mp.MaxLen(20))
+	assert.Equal(t, map[string]any{"minLen": 1, "maxLen": 20}, mp.FieldConverterParams(f))
+}
+
 func BenchmarkTypeParse(b *testing.B) {
 	ft := mp.NewType(
 		mp.NewField("name", mp.String()),
@@ -631,3 +1339,315 @@ func BenchmarkTypeParse(b *testing.B) {
 		require.NoError(b, record.Errors())
 	}
 }
+
+func BenchmarkTypeParsePrebuiltAttrs(b *testing.B) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.String()),
+		mp.NewField("age", mp.Int32()),
+	)
+
+	attrs := map[string]any{"name": "Adam", "age": 30}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		record := ft.Parse(attrs)
+		require.NoError(b, record.Errors())
+	}
+}
+
+func BenchmarkTypeParseStrings(b *testing.B) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int32()),
+		mp.NewField("score", mp.Float64()),
+		mp.NewField("nickname", mp.SingleLineString()),
+	)
+
+	attrs := map[string]any{"name": "Adam", "age": "30", "score": "12.5"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		record := ft.Parse(attrs)
+		require.NoError(b, record.Errors())
+	}
+}
+
+func BenchmarkMinLen(b *testing.B) {
+	value := make([]any, 1000)
+	vc := mp.MinLen(1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := vc.ConvertValue(value)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSingleLineString(b *testing.B) {
+	vc := mp.SingleLineString()
+	var value any = "The quick brown fox jumps over the lazy dog"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := vc.ConvertValue(value)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestAllowStringsWithOptions(t *testing.T) {
+	tests := []struct {
+		options  mp.StringSetOptions
+		items    []string
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{mp.StringSetOptions{}, []string{"active"}, "ACTIVE", nil, "not allowed value"},
+		{mp.StringSetOptions{Matching: mp.MatchCaseInsensitive}, []string{"active"}, "ACTIVE", "ACTIVE", ""},
+		{mp.StringSetOptions{Matching: mp.MatchCaseInsensitive, Canonicalize: true}, []string{"Active"}, "aCTIVE", "Active", ""},
+		{mp.StringSetOptions{Matching: mp.MatchCaseInsensitive}, []string{"sun"}, "ſun", nil, "not allowed value"},
+		{mp.StringSetOptions{Matching: mp.MatchFoldCase}, []string{"sun"}, "ſUN", "ſUN", ""},
+		{mp.StringSetOptions{Matching: mp.MatchFoldCase, Canonicalize: true}, []string{"sun"}, "ſUN", "sun", ""},
+		{mp.StringSetOptions{Matching: mp.MatchFoldCase}, []string{"sun"}, "moon", nil, "not allowed value"},
+		{mp.StringSetOptions{Matching: mp.MatchFoldCase}, []string{"sun"}, nil, nil, ""},
+		{mp.StringSetOptions{Matching: mp.MatchFoldCase}, []string{"sun"}, 1, nil, "not allowed value"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.AllowStringsWithOptions(tt.options, tt.items...).ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+}
+
+func TestExcludeStringsWithOptions(t *testing.T) {
+	vc := mp.ExcludeStringsWithOptions(mp.StringSetOptions{Matching: mp.MatchCaseInsensitive}, "admin", "root")
+
+	_, err := vc.ConvertValue("ADMIN")
+	assert.ErrorIs(t, err, mp.ErrNotAllowed)
+
+	value, err := vc.ConvertValue("Adam")
+	require.NoError(t, err)
+	assert.Equal(t, "Adam", value)
+
+	assert.Equal(t,
+		map[string]any{"excludeStrings": []string{"admin", "root"}, "stringMatching": "caseInsensitive"},
+		vc.(mp.ConverterParamser).ConverterParams(),
+	)
+}
+
+func TestAllowValues(t *testing.T) {
+	vc := mp.AllowValues[int32](1, 2, 3)
+
+	tests := []struct {
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{int32(2), int32(2), ""},
+		{int32(4), nil, "not allowed value"},
+		{int64(2), nil, "not allowed value"},
+		{nil, nil, ""},
+	}
+
+	for i, tt := range tests {
+		value, err := vc.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+
+	ft := mp.NewType(mp.NewField("status", mp.Int32(), mp.AllowValues[int32](1, 2)))
+	assert.NoError(t, ft.Parse(map[string]any{"status": "2"}).Errors())
+	assert.Error(t, ft.Parse(map[string]any{"status": "3"}).Errors())
+	assert.Equal(t, map[string]any{"allowValues": []int32{1, 2}}, mp.FieldConverterParams(ft.Fields()[0]))
+}
+
+func TestInAndNotIn(t *testing.T) {
+	id1 := uuid.Must(uuid.FromString("9a2f0fbc-1f3c-4d5e-8a3b-1b2c3d4e5f60"))
+	id2 := uuid.Must(uuid.FromString("1b2c3d4e-1f3c-4d5e-8a3b-9a2f0fbc5f60"))
+
+	tests := []struct {
+		converter mp.ValueConverter
+		value     any
+		errMsg    string
+	}{
+		{mp.In[int32](1, 2), int32(1), ""},
+		{mp.In[int32](1, 2), int32(3), "not allowed value"},
+		{mp.In[int32](1, 2), int64(1), "not allowed value"},
+		{mp.In[int32](1, 2), nil, ""},
+		{mp.In(id1), id1, ""},
+		{mp.In(id1), id2, "not allowed value"},
+		{mp.NotIn[int64](0), int64(1), ""},
+		{mp.NotIn[int64](0), int64(0), "not allowed value"},
+		{mp.NotIn[int64](0), "1", "not allowed value"},
+		{mp.NotIn[int64](0), nil, ""},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.converter.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			assert.ErrorIsf(t, err, mp.ErrNotAllowed, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.value, value, "%d", i)
+	}
+
+	ft := mp.NewType(
+		mp.NewField("id", mp.UUID(), mp.In(id1, id2)),
+		mp.NewField("count", mp.Int64(), mp.NotIn[int64](0)),
+	)
+	record := ft.Parse(map[string]any{"id": id2.String(), "count": "5"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, id2, record.Get("id"))
+
+	assert.Equal(t, map[string]any{"excludeValues": []int64{0}}, mp.FieldConverterParams(ft.Fields()[1]))
+}
+
+func TestParseOrder(t *testing.T) {
+	var order []string
+	record := func(name string) mp.ValueConverter {
+		return mp.ValueConverterFunc(func(value any) (any, error) {
+			order = append(order, name)
+			return value, nil
+		})
+	}
+
+	tp := mp.NewType(
+		mp.NewField("e", record("e")),
+		mp.NewField("d", record("d1"), record("d2")),
+		mp.NewField("c", mp.DependsOn([]string{"a"}, func(value any, deps map[string]any) (any, error) {
+			order = append(order, "c")
+			return value, nil
+		})),
+		mp.NewField("b", record("b")),
+		mp.NewField("a", record("a")),
+	)
+
+	for i := 0; i < 10; i++ {
+		order = nil
+		tp.Parse(map[string]any{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5})
+		assert.Equalf(t, []string{"e", "d1", "d2", "a", "c", "b"}, order, "%d", i)
+	}
+}
+
+func TestNewTypeDuplicateField(t *testing.T) {
+	assert.PanicsWithError(t, `field "name" is defined more than once`, func() {
+		mp.NewType(
+			mp.NewField("name", mp.String()),
+			mp.NewField("age", mp.Int64()),
+			mp.NewField("name", mp.SingleLineString()),
+		)
+	})
+}
+
+func TestTypeHasField(t *testing.T) {
+	tp := mp.NewType(mp.NewField("name", mp.String()))
+	assert.True(t, tp.HasField("name"))
+	assert.False(t, tp.HasField("age"))
+}
+
+func TestStandardFieldWithAlias(t *testing.T) {
+	tp := mp.NewTypeWithOptions(mp.TypeOptions{TrackUnknownKeys: true},
+		mp.NewField("first_name", mp.SingleLineString()).WithAlias("firstName", "fname"),
+	)
+
+	tests := []struct {
+		attrs    map[string]any
+		expected any
+	}{
+		{map[string]any{"first_name": "Jack"}, "Jack"},
+		{map[string]any{"firstName": "Jack"}, "Jack"},
+		{map[string]any{"fname": "Jack"}, "Jack"},
+		{map[string]any{"first_name": "Jack", "firstName": "John"}, "Jack"},
+		{map[string]any{"firstName": "Jack", "fname": "John"}, "Jack"},
+	}
+
+	for i, tt := range tests {
+		record := tp.Parse(tt.attrs)
+		require.NoErrorf(t, record.Errors(), "%d", i)
+		assert.Equalf(t, tt.expected, record.Get("first_name"), "%d", i)
+		assert.Nilf(t, record.UnknownKeys(), "%d", i)
+	}
+
+	assert.PanicsWithError(t, `alias "name" of "first_name" is already a field of type`, func() {
+		mp.NewType(mp.NewField("first_name").WithAlias("name"), mp.NewField("name"))
+	})
+	assert.PanicsWithError(t, `alias "n" of "last_name" is already an alias of "first_name"`, func() {
+		mp.NewType(mp.NewField("first_name").WithAlias("n"), mp.NewField("last_name").WithAlias("n"))
+	})
+}
+
+func TestStandardFieldWithSensitive(t *testing.T) {
+	inner := mp.NewType(mp.NewField("token", mp.String()).WithSensitive())
+	tp := mp.NewType(
+		mp.NewField("name", mp.String()),
+		mp.NewField("password", mp.String()).WithSensitive(),
+		mp.NewField("auth", inner),
+	)
+	assert.False(t, mp.FieldIsSensitive(tp.Fields()[0]))
+	assert.True(t, mp.FieldIsSensitive(tp.Fields()[1]))
+
+	record := tp.Parse(map[string]any{"name": "Jack", "password": "hunter2", "auth": map[string]any{"token": "abc"}})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, `{"auth": {"token": [REDACTED]}, "name": "Jack", "password": [REDACTED]}`, record.String())
+	assert.Equal(t, `{"auth": {"token": "abc"}, "name": "Jack", "password": "hunter2"}`, record.Canonical())
+}
+
+func TestStandardFieldWithMeta(t *testing.T) {
+	f := mp.NewField("name").WithMeta("label", "Name")
+	g := f.WithMeta("placeholder", "Jane Doe")
+	assert.Equal(t, map[string]any{"label": "Name"}, mp.FieldMeta(f))
+	assert.Equal(t, map[string]any{"label": "Name", "placeholder": "Jane Doe"}, mp.FieldMeta(g))
+	assert.Nil(t, mp.FieldMeta(mp.NewField("other")))
+}
+
+func TestTypeWithFieldDefaults(t *testing.T) {
+	base := mp.NewType(
+		mp.NewField("first_name"),
+		mp.NewField("last_name"),
+		mp.NewField("age", mp.Int64()),
+	)
+	var hookCalls int
+	base.AfterParse(func(r *mp.Record) error {
+		hookCalls++
+		return nil
+	})
+
+	tp := base.WithFieldDefaults(mp.SingleLineString(), mp.NilifyEmpty())
+
+	record := tp.Parse(map[string]any{"first_name": "  Jack ", "last_name": "", "age": "30"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"first_name": "Jack", "last_name": nil, "age": int64(30)}, record.Attrs())
+	assert.Equal(t, 1, hookCalls)
+
+	record = tp.Parse(map[string]any{"first_name": 42})
+	assert.Error(t, record.Errors())
+
+	// The original Type is not modified.
+	record = base.Parse(map[string]any{"first_name": "  Jack ", "last_name": ""})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "  Jack ", record.Get("first_name"))
+	assert.Equal(t, "", record.Get("last_name"))
+}
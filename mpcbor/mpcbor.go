@@ -0,0 +1,393 @@
+// Package mpcbor decodes CBOR (RFC 8949) into map[string]any for use with mp.Type.Parse.
+//
+// Integers are decoded as int64, or uint64 if they do not fit in an int64, so they keep full precision. Byte strings
+// are decoded as []byte. Floats are decoded as float64. Date/time tags 0 and 1 are decoded as time.Time and bignum
+// tags 2 and 3 are decoded as decimal.Decimal. Other tags are ignored and their content is decoded as is. Undefined is
+// decoded as nil.
+package mpcbor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxDepth is the maximum nesting depth of maps, arrays, and tags. Deeper input is rejected to prevent stack
+// exhaustion.
+const MaxDepth = 1000
+
+// Decode reads all of r and decodes it with Unmarshal.
+func Decode(r io.Reader) (map[string]any, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(data)
+}
+
+// Unmarshal decodes data, which must contain a single CBOR map with text string keys. Duplicate keys are rejected.
+func Unmarshal(data []byte) (map[string]any, error) {
+	d := &decoder{data: data}
+	value, err := d.decodeValue(0)
+	if err != nil {
+		return nil, err
+	}
+	if value == breakMarker {
+		return nil, errUnexpectedBreak
+	}
+
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("cbor value is not a map")
+	}
+
+	if d.pos != len(d.data) {
+		return nil, errors.New("unexpected data after cbor map")
+	}
+
+	return m, nil
+}
+
+var (
+	errUnexpectedEnd   = errors.New("unexpected end of cbor data")
+	errUnexpectedBreak = errors.New("unexpected cbor break")
+)
+
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorText     = 3
+	majorArray    = 4
+	majorMap      = 5
+	majorTag      = 6
+	majorSimple   = 7
+
+	additionalIndefinite = 31
+)
+
+// breakMarker is returned by decodeValue for the break stop code that ends an indefinite length item.
+type breakMarkerType struct{}
+
+var breakMarker = breakMarkerType{}
+
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *decoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errUnexpectedEnd
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// readArgument reads the argument of an item with additional information ai.
+func (d *decoder) readArgument(ai byte) (uint64, error) {
+	if ai < 24 {
+		return uint64(ai), nil
+	}
+
+	var size uint64
+	switch ai {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	default:
+		return 0, fmt.Errorf("invalid cbor additional information %d", ai)
+	}
+
+	b, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+// checkCount returns an error if n elements of at least minElementSize bytes cannot fit in the remaining data. It
+// prevents a hostile length from causing a large allocation.
+func (d *decoder) checkCount(n uint64, minElementSize uint64) error {
+	if n > uint64(len(d.data)-d.pos)/minElementSize {
+		return errUnexpectedEnd
+	}
+	return nil
+}
+
+func (d *decoder) decodeValue(depth int) (any, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("cbor exceeds maximum depth of %d", MaxDepth)
+	}
+
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	major := b[0] >> 5
+	ai := b[0] & 0x1f
+
+	if ai == additionalIndefinite {
+		switch major {
+		case majorBytes, majorText:
+			return d.decodeIndefiniteString(major)
+		case majorArray:
+			return d.decodeArray(0, true, depth)
+		case majorMap:
+			return d.decodeMap(0, true, depth)
+		case majorSimple:
+			return breakMarker, nil
+		}
+		return nil, fmt.Errorf("invalid indefinite length cbor major type %d", major)
+	}
+
+	if major == majorSimple {
+		return d.decodeSimple(ai)
+	}
+
+	n, err := d.readArgument(ai)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUnsigned:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case majorNegative:
+		if n > math.MaxInt64 {
+			return nil, errors.New("cbor negative integer out of range")
+		}
+		return -1 - int64(n), nil
+	case majorBytes:
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case majorText:
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(b) {
+			return nil, errors.New("cbor text string is not valid UTF-8")
+		}
+		return string(b), nil
+	case majorArray:
+		if err := d.checkCount(n, 1); err != nil {
+			return nil, err
+		}
+		return d.decodeArray(int(n), false, depth)
+	case majorMap:
+		if err := d.checkCount(n, 2); err != nil {
+			return nil, err
+		}
+		return d.decodeMap(int(n), false, depth)
+	default:
+		return d.decodeTag(n, depth)
+	}
+}
+
+func (d *decoder) decodeSimple(ai byte) (any, error) {
+	switch ai {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		n, err := d.readArgument(ai)
+		if err != nil {
+			return nil, err
+		}
+		return halfToFloat64(uint16(n)), nil
+	case 26:
+		n, err := d.readArgument(ai)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(n))), nil
+	case 27:
+		n, err := d.readArgument(ai)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(n), nil
+	}
+
+	return nil, fmt.Errorf("unsupported cbor simple value %d", ai)
+}
+
+// halfToFloat64 converts an IEEE 754 half precision float to a float64.
+func halfToFloat64(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1.0
+	}
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(mant+1024, exp-25)
+}
+
+func (d *decoder) decodeIndefiniteString(major byte) (any, error) {
+	var buf []byte
+	for {
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if b[0] == 0xff {
+			break
+		}
+		if b[0]>>5 != major || b[0]&0x1f == additionalIndefinite {
+			return nil, errors.New("invalid cbor indefinite length string chunk")
+		}
+		n, err := d.readArgument(b[0] & 0x1f)
+		if err != nil {
+			return nil, err
+		}
+		chunk, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, chunk...)
+	}
+
+	if major == majorBytes {
+		if buf == nil {
+			buf = []byte{}
+		}
+		return buf, nil
+	}
+	if !utf8.Valid(buf) {
+		return nil, errors.New("cbor text string is not valid UTF-8")
+	}
+	return string(buf), nil
+}
+
+func (d *decoder) decodeArray(n int, indefinite bool, depth int) ([]any, error) {
+	elements := make([]any, 0, n)
+	for i := 0; indefinite || i < n; i++ {
+		element, err := d.decodeValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if element == breakMarker {
+			if !indefinite {
+				return nil, errUnexpectedBreak
+			}
+			break
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+func (d *decoder) decodeMap(n int, indefinite bool, depth int) (map[string]any, error) {
+	m := make(map[string]any, n)
+	for i := 0; indefinite || i < n; i++ {
+		key, err := d.decodeValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if key == breakMarker {
+			if !indefinite {
+				return nil, errUnexpectedBreak
+			}
+			break
+		}
+		s, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("cbor map key %v is not a text string", key)
+		}
+		if _, ok := m[s]; ok {
+			return nil, fmt.Errorf("duplicate cbor map key %q", s)
+		}
+
+		value, err := d.decodeValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if value == breakMarker {
+			return nil, errUnexpectedBreak
+		}
+		m[s] = value
+	}
+	return m, nil
+}
+
+func (d *decoder) decodeTag(tag uint64, depth int) (any, error) {
+	content, err := d.decodeValue(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	if content == breakMarker {
+		return nil, errUnexpectedBreak
+	}
+
+	switch tag {
+	case 0:
+		s, ok := content.(string)
+		if !ok {
+			return nil, errors.New("cbor date/time tag content is not a text string")
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case 1:
+		switch n := content.(type) {
+		case int64:
+			return time.Unix(n, 0).UTC(), nil
+		case float64:
+			sec, frac := math.Modf(n)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+		}
+		return nil, errors.New("cbor epoch time tag content is not a number")
+	case 2, 3:
+		b, ok := content.([]byte)
+		if !ok {
+			return nil, errors.New("cbor bignum tag content is not a byte string")
+		}
+		n := new(big.Int).SetBytes(b)
+		if tag == 3 {
+			n.Neg(n).Sub(n, big.NewInt(1))
+		}
+		return decimal.NewFromBigInt(n, 0), nil
+	}
+
+	return content, nil
+}
@@ -0,0 +1,105 @@
+package mpcbor_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/mpcbor"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalValues(t *testing.T) {
+	tests := []struct {
+		encoded  []byte
+		expected any
+	}{
+		{[]byte{0x0a}, int64(10)},
+		{[]byte{0x18, 0x64}, int64(100)},
+		{[]byte{0x19, 0x03, 0xe8}, int64(1000)},
+		{[]byte{0x1b, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}, int64(9007199254740993)},
+		{[]byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, uint64(math.MaxUint64)},
+		{[]byte{0x20}, int64(-1)},
+		{[]byte{0x38, 0x63}, int64(-100)},
+		{[]byte{0xf9, 0x3e, 0x00}, 1.5},
+		{[]byte{0xf9, 0x00, 0x01}, 5.960464477539063e-8},
+		{[]byte{0xfa, 0x47, 0xc3, 0x50, 0x00}, 100000.0},
+		{[]byte{0xfb, 0x3f, 0xf1, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a}, 1.1},
+		{[]byte{0xf4}, false},
+		{[]byte{0xf5}, true},
+		{[]byte{0xf6}, nil},
+		{[]byte{0xf7}, nil},
+		{[]byte{0x62, 'h', 'i'}, "hi"},
+		{[]byte{0x7f, 0x61, 'h', 0x61, 'i', 0xff}, "hi"},
+		{[]byte{0x42, 0x01, 0x02}, []byte{1, 2}},
+		{[]byte{0x5f, 0x41, 0x01, 0x41, 0x02, 0xff}, []byte{1, 2}},
+		{[]byte{0x82, 0x01, 0x61, 'a'}, []any{int64(1), "a"}},
+		{[]byte{0x9f, 0x01, 0x02, 0xff}, []any{int64(1), int64(2)}},
+		{[]byte{0xbf, 0x61, 'a', 0x01, 0xff}, map[string]any{"a": int64(1)}},
+		{append([]byte{0xc0, 0x74}, "2013-03-21T20:04:00Z"...), time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{[]byte{0xc1, 0x1a, 0x51, 0x4b, 0x67, 0xb0}, time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{[]byte{0xc2, 0x49, 0x01, 0, 0, 0, 0, 0, 0, 0, 0}, decimal.RequireFromString("18446744073709551616")},
+		{[]byte{0xc3, 0x49, 0x01, 0, 0, 0, 0, 0, 0, 0, 0}, decimal.RequireFromString("-18446744073709551617")},
+		{[]byte{0xd8, 0x20, 0x61, 'u'}, "u"},
+	}
+
+	for i, tt := range tests {
+		encoded := append([]byte{0xa1, 0x61, 'v'}, tt.encoded...)
+		m, err := mpcbor.Unmarshal(encoded)
+		require.NoErrorf(t, err, "%d", i)
+		if d, ok := tt.expected.(decimal.Decimal); ok {
+			assert.Truef(t, d.Equal(m["v"].(decimal.Decimal)), "%d", i)
+			continue
+		}
+		assert.Equalf(t, tt.expected, m["v"], "%d", i)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := [][]byte{
+		{},
+		{0x01},
+		{0x82, 0x01, 0x02},
+		{0xff},
+		{0xa1, 0x01, 0x01},
+		{0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02},
+		{0xa1, 0x61, 'a'},
+		{0xa1, 0x61, 'a', 0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0xa1, 0x61, 'a', 0x61, 0xff},
+		{0xa1, 0x61, 'a', 0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0xa1, 0x61, 'a', 0x1c},
+		{0xa1, 0x61, 'a', 0xc0, 0x01},
+		{0xa1, 0x61, 'a', 0x5f, 0x61, 'x', 0xff},
+		{0xa1, 0x61, 'a', 0x82, 0x01, 0xff},
+		{0xa0, 0x01},
+		append([]byte{0xa1, 0x61, 'a'}, bytes.Repeat([]byte{0x81}, mpcbor.MaxDepth+1)...),
+	}
+
+	for i, data := range tests {
+		_, err := mpcbor.Unmarshal(data)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestDecodeAndParse(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("balance", mp.Decimal()),
+	)
+
+	encoded := []byte{0xa2,
+		0x64, 'n', 'a', 'm', 'e', 0x64, 'A', 'd', 'a', 'm',
+		0x67, 'b', 'a', 'l', 'a', 'n', 'c', 'e', 0xc2, 0x49, 0x01, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	m, err := mpcbor.Decode(bytes.NewReader(encoded))
+	require.NoError(t, err)
+
+	record := ft.Parse(m)
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Adam", record.Get("name"))
+	assert.Equal(t, "18446744073709551616", record.Get("balance").(decimal.Decimal).String())
+}
@@ -0,0 +1,85 @@
+package mphttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/mphttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		remoteAddr    string
+		forwardedFor  []string
+		expected      string
+		expectedError bool
+	}{
+		{"203.0.113.5:1234", nil, "203.0.113.5", false},
+		{"203.0.113.5:1234", []string{"198.51.100.1"}, "203.0.113.5", false},
+		{"10.0.0.1:1234", []string{"198.51.100.1"}, "198.51.100.1", false},
+		{"10.0.0.1:1234", []string{"1.1.1.1, 198.51.100.1, 10.0.0.2"}, "198.51.100.1", false},
+		{"10.0.0.1:1234", []string{"198.51.100.1", "10.0.0.2"}, "198.51.100.1", false},
+		{"10.0.0.1:1234", []string{"10.0.0.3, 10.0.0.2"}, "10.0.0.3", false},
+		{"10.0.0.1:1234", nil, "10.0.0.1", false},
+		{"[::ffff:203.0.113.5]:1234", nil, "203.0.113.5", false},
+		{"10.0.0.1:1234", []string{"garbage"}, "", true},
+		{"garbage", nil, "", true},
+	}
+
+	for i, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = tt.remoteAddr
+		for _, v := range tt.forwardedFor {
+			r.Header.Add("X-Forwarded-For", v)
+		}
+
+		addr, err := mphttp.ClientIP(r, trusted)
+		if tt.expectedError {
+			assert.Errorf(t, err, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, addr.String(), "%d", i)
+	}
+}
+
+func TestBindClientIPAndUserAgent(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("ip", mp.SingleLineString(), mp.Require()),
+		mp.NewField("userAgent", mp.SingleLineString()),
+	)
+	options := mphttp.Options{
+		ClientIPField:  "ip",
+		TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+		UserAgentField: "userAgent",
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/?ip=1.2.3.4", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	r.Header.Set("User-Agent", "curl/8.0")
+
+	record, err := mphttp.BindWithOptions(r, ft, options)
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.1", record.Get("ip"))
+	assert.Equal(t, "curl/8.0", record.Get("userAgent"))
+	assert.Equal(t, "client IP", options.Source("ip"))
+	assert.Equal(t, "header User-Agent", options.Source("userAgent"))
+}
+
+func TestUserAgentFieldIgnoresQuery(t *testing.T) {
+	options := mphttp.Options{UserAgentField: "user_agent"}
+
+	r := httptest.NewRequest(http.MethodGet, "/?user_agent=forged", nil)
+	r.Header.Del("User-Agent")
+	attrs, err := mphttp.AttrsWithOptions(r, options)
+	require.NoError(t, err)
+	assert.NotContains(t, attrs, "user_agent")
+}
@@ -0,0 +1,260 @@
+// Package mphttp binds net/http requests to mp Types.
+//
+// It works with any router that uses net/http handlers. There are no framework specific adapters. Path parameters
+// extracted by the router must be passed in Options.PathParams. For example:
+//
+//	// net/http (Go 1.22+)
+//	mphttp.BindWithOptions(r, t, mphttp.Options{PathParams: map[string]string{"id": r.PathValue("id")}})
+//
+//	// Chi
+//	mphttp.BindWithOptions(r, t, mphttp.Options{PathParams: map[string]string{"id": chi.URLParam(r, "id")}})
+//
+//	// Gin
+//	mphttp.BindWithOptions(c.Request, t, mphttp.Options{PathParams: map[string]string{"id": c.Param("id")}})
+//
+//	// Echo
+//	mphttp.BindWithOptions(c.Request(), t, mphttp.Options{PathParams: map[string]string{"id": c.Param("id")}})
+package mphttp
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/mpcbor"
+	"github.com/jackc/mp/mpmsgpack"
+)
+
+// Options configures how a request is converted to attrs.
+type Options struct {
+	// PathParams are values extracted from the URL path by the router. They take precedence over all other sources.
+	PathParams map[string]string
+
+	// Headers maps field names to the request header that populates them (e.g. "requestID" to "X-Request-ID"). A
+	// field mapped to a header is never read from the query or body. It is missing if the header is absent.
+	Headers map[string]string
+
+	// Cookies maps field names to the cookie that populates them. Cookies take precedence over headers. A field mapped
+	// to a cookie is never read from the query or body. It is missing if the cookie is absent.
+	Cookies map[string]string
+
+	// ClientIPField is the name of the field populated with the client IP address as determined by ClientIP. It is not
+	// populated if empty.
+	ClientIPField string
+
+	// TrustedProxies are the proxies whose X-Forwarded-For header is trusted when determining the client IP address.
+	TrustedProxies []netip.Prefix
+
+	// UserAgentField is the name of the field populated with the User-Agent header. It is not populated if empty. The
+	// field is never read from the query or body. It is missing if the request has no User-Agent.
+	UserAgentField string
+
+	// JSONDecoder decodes JSON bodies. If it is nil then mp.StandardJSONDecoder is used.
+	JSONDecoder mp.JSONDecoder
+
+	// MaxBodyBytes is the maximum size of the request body. A larger body fails to decode. If it is 0 then
+	// DefaultMaxBodyBytes is used. If it is negative there is no limit.
+	MaxBodyBytes int64
+}
+
+// DefaultMaxBodyBytes is the MaxBodyBytes used when Options.MaxBodyBytes is 0.
+const DefaultMaxBodyBytes = 10 << 20
+
+// Source returns a description of where field is read from for use in error reporting. It is "path", "cookie <name>",
+// "client IP", or "header <name>" for fields populated by those options and "" for fields read from the query or body.
+func (o Options) Source(field string) string {
+	if _, ok := o.PathParams[field]; ok {
+		return "path"
+	}
+	if field != "" && field == o.ClientIPField {
+		return "client IP"
+	}
+	if field != "" && field == o.UserAgentField {
+		return "header User-Agent"
+	}
+	if name, ok := o.Cookies[field]; ok {
+		return "cookie " + name
+	}
+	if name, ok := o.Headers[field]; ok {
+		return "header " + name
+	}
+	return ""
+}
+
+// Bind converts r to attrs with Attrs and parses them with t. If the request cannot be decoded then the decoding error
+// is returned. Otherwise, the Record is returned with the Record's errors.
+func Bind(r *http.Request, t *mp.Type) (*mp.Record, error) {
+	return BindWithOptions(r, t, Options{})
+}
+
+// BindWithOptions is like Bind but with options.
+func BindWithOptions(r *http.Request, t *mp.Type, options Options) (*mp.Record, error) {
+	attrs, err := AttrsWithOptions(r, options)
+	if err != nil {
+		return nil, err
+	}
+
+	record := t.Parse(attrs)
+	return record, record.Errors()
+}
+
+// Attrs converts r to attrs. Query parameters are read first, then the body, then headers, cookies, and path params
+// when configured by Options. Later sources override earlier ones. A JSON body must be an object without duplicate keys
+// and is decoded with mp.DecodeJSONWithOptions. Numbers in a JSON body are decoded as json.Number to preserve
+// precision. MessagePack and CBOR bodies are decoded with mpmsgpack and mpcbor. A form body
+// (application/x-www-form-urlencoded or multipart/form-data) is read like query parameters. Files in a multipart body
+// are ignored. The body is limited to DefaultMaxBodyBytes.
+// Parameters with a single value are strings and parameters with multiple values are []any.
+func Attrs(r *http.Request) (map[string]any, error) {
+	return AttrsWithOptions(r, Options{})
+}
+
+// AttrsWithOptions is like Attrs but with options.
+func AttrsWithOptions(r *http.Request, options Options) (map[string]any, error) {
+	attrs := make(map[string]any)
+	setValues(attrs, r.URL.Query())
+
+	if r.Body != nil && r.Body != http.NoBody {
+		err := decodeBody(r, attrs, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A field mapped to a header or cookie must only come from there. Otherwise, a client could supply it with a query
+	// or body parameter when the header or cookie is absent.
+	for field := range options.Headers {
+		delete(attrs, field)
+	}
+	for field := range options.Cookies {
+		delete(attrs, field)
+	}
+
+	for field, name := range options.Headers {
+		if values := r.Header.Values(name); len(values) == 1 {
+			attrs[field] = values[0]
+		} else if len(values) > 1 {
+			attrs[field] = stringsToAny(values)
+		}
+	}
+
+	for field, name := range options.Cookies {
+		if cookie, err := r.Cookie(name); err == nil {
+			attrs[field] = cookie.Value
+		}
+	}
+
+	if options.UserAgentField != "" {
+		delete(attrs, options.UserAgentField)
+		if userAgent := r.UserAgent(); userAgent != "" {
+			attrs[options.UserAgentField] = userAgent
+		}
+	}
+
+	if options.ClientIPField != "" {
+		addr, err := ClientIP(r, options.TrustedProxies)
+		if err != nil {
+			return nil, err
+		}
+		attrs[options.ClientIPField] = addr.String()
+	}
+
+	for k, v := range options.PathParams {
+		attrs[k] = v
+	}
+
+	return attrs, nil
+}
+
+// maxMultipartMemory is the maximum memory used by multipart form parsing before file parts are stored on disk.
+const maxMultipartMemory = 32 << 20
+
+func decodeBody(r *http.Request, attrs map[string]any, options Options) error {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("invalid content type: %w", err)
+	}
+
+	maxBodyBytes := options.MaxBodyBytes
+	if maxBodyBytes == 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+	if maxBodyBytes > 0 {
+		r.Body = http.MaxBytesReader(nil, r.Body, maxBodyBytes)
+	}
+
+	switch mediaType {
+	case "application/json":
+		body, err := mp.DecodeJSONWithOptions(r.Body, mp.JSONOptions{UseNumber: true, Decoder: options.JSONDecoder})
+		if err != nil {
+			return fmt.Errorf("invalid JSON body: %w", err)
+		}
+		for k, v := range body {
+			attrs[k] = v
+		}
+	case "application/msgpack", "application/x-msgpack", "application/vnd.msgpack":
+		body, err := mpmsgpack.Decode(r.Body)
+		if err != nil {
+			return fmt.Errorf("invalid MessagePack body: %w", err)
+		}
+		for k, v := range body {
+			attrs[k] = v
+		}
+	case "application/cbor":
+		body, err := mpcbor.Decode(r.Body)
+		if err != nil {
+			return fmt.Errorf("invalid CBOR body: %w", err)
+		}
+		for k, v := range body {
+			attrs[k] = v
+		}
+	case "application/x-www-form-urlencoded":
+		err := r.ParseForm()
+		if err != nil {
+			return fmt.Errorf("invalid form body: %w", err)
+		}
+		setValues(attrs, r.PostForm)
+	case "multipart/form-data":
+		err := r.ParseMultipartForm(maxMultipartMemory)
+		if err != nil {
+			return fmt.Errorf("invalid multipart body: %w", err)
+		}
+		setValues(attrs, r.MultipartForm.Value)
+		err = r.MultipartForm.RemoveAll()
+		if err != nil {
+			return fmt.Errorf("cannot remove multipart files: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported content type %s", mediaType)
+	}
+
+	return nil
+}
+
+func setValues(attrs map[string]any, values url.Values) {
+	for k, vs := range values {
+		switch len(vs) {
+		case 0:
+		case 1:
+			attrs[k] = vs[0]
+		default:
+			attrs[k] = stringsToAny(vs)
+		}
+	}
+}
+
+func stringsToAny(strs []string) []any {
+	elements := make([]any, len(strs))
+	for i, s := range strs {
+		elements[i] = s
+	}
+	return elements
+}
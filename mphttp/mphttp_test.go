@@ -0,0 +1,225 @@
+package mphttp_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/mphttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var personType = mp.NewType(
+	mp.NewField("id", mp.Int64(), mp.Require()),
+	mp.NewField("name", mp.SingleLineString(), mp.Require()),
+	mp.NewField("age", mp.Int64()),
+	mp.NewField("tags", mp.Slice[string](mp.SingleLineString())),
+)
+
+func TestBindJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/people/7?age=40&tags=a&tags=b", strings.NewReader(`{"name": "Adam", "age": 30}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	record, err := mphttp.BindWithOptions(r, personType, mphttp.Options{PathParams: map[string]string{"id": "7"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), record.Get("id"))
+	assert.Equal(t, "Adam", record.Get("name"))
+	assert.Equal(t, int64(30), record.Get("age"))
+	assert.Equal(t, []string{"a", "b"}, record.Get("tags"))
+}
+
+func TestBindForm(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader("id=1&name=Adam&tags=x&tags=y"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	record, err := mphttp.Bind(r, personType)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), record.Get("id"))
+	assert.Equal(t, "Adam", record.Get("name"))
+	assert.Equal(t, []string{"x", "y"}, record.Get("tags"))
+}
+
+func TestBindValidationError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/people?id=abc", nil)
+
+	record, err := mphttp.Bind(r, personType)
+	require.Error(t, err)
+	require.NotNil(t, record)
+	assert.ErrorIs(t, err, mp.ErrNotANumber)
+}
+
+func TestBindDecodeError(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+	}{
+		{"application/json", `{"name": `},
+		{"application/json", `[1, 2]`},
+		{"application/json", `null`},
+		{"text/plain", `hello`},
+	}
+
+	for i, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", tt.contentType)
+
+		record, err := mphttp.Bind(r, personType)
+		assert.Errorf(t, err, "%d", i)
+		assert.Nilf(t, record, "%d", i)
+	}
+}
+
+func TestBindHeadersAndCookies(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("requestID", mp.UUID(), mp.Require()),
+		mp.NewField("language", mp.SingleLineString()),
+		mp.NewField("session", mp.SingleLineString(), mp.Require()),
+	)
+	options := mphttp.Options{
+		Headers: map[string]string{"requestID": "X-Request-ID", "language": "Accept-Language"},
+		Cookies: map[string]string{"session": "session_id"},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/?language=fr&session=ignored", nil)
+	r.Header.Set("X-Request-ID", "9a2f0fbc-1f3c-4d5e-8a3b-1b2c3d4e5f60")
+	r.Header.Set("Accept-Language", "en-US")
+	r.AddCookie(&http.Cookie{Name: "session_id", Value: "abc"})
+
+	record, err := mphttp.BindWithOptions(r, ft, options)
+	require.NoError(t, err)
+	assert.Equal(t, "en-US", record.Get("language"))
+	assert.Equal(t, "abc", record.Get("session"))
+
+	assert.Equal(t, "header X-Request-ID", options.Source("requestID"))
+	assert.Equal(t, "cookie session_id", options.Source("session"))
+	assert.Equal(t, "", options.Source("other"))
+}
+
+func TestBindMissingHeader(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("requestID", mp.UUID(), mp.Require()),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err := mphttp.BindWithOptions(r, ft, mphttp.Options{Headers: map[string]string{"requestID": "X-Request-ID"}})
+	assert.ErrorIs(t, err, mp.ErrRequired)
+}
+
+func TestBindHeaderAndCookieFieldsIgnoreQueryAndBody(t *testing.T) {
+	options := mphttp.Options{
+		Headers: map[string]string{"requestID": "X-Request-ID"},
+		Cookies: map[string]string{"session": "session_id"},
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/?requestID=forged&session=forged", strings.NewReader("session=forged"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	attrs, err := mphttp.AttrsWithOptions(r, options)
+	require.NoError(t, err)
+	assert.NotContains(t, attrs, "requestID")
+	assert.NotContains(t, attrs, "session")
+
+	// A cookie takes precedence over a header but a missing cookie does not remove the header value.
+	options.Headers["session"] = "X-Session"
+	r = httptest.NewRequest(http.MethodGet, "/?session=forged", nil)
+	r.Header.Set("X-Session", "from-header")
+	attrs, err = mphttp.AttrsWithOptions(r, options)
+	require.NoError(t, err)
+	assert.Equal(t, "from-header", attrs["session"])
+}
+
+func TestBindJSONDuplicateKeys(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id": 1, "name": "Adam", "id": 2}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	_, err := mphttp.Bind(r, personType)
+	var dupErr *mp.DuplicateKeyError
+	require.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, []string{"/id"}, dupErr.Paths)
+}
+
+func TestBindCustomJSONDecoder(t *testing.T) {
+	called := false
+	decoder := mp.JSONDecoderFunc(func(r io.Reader, options mp.JSONOptions) (map[string]any, error) {
+		called = true
+		return mp.StandardJSONDecoder.DecodeJSONObject(r, options)
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id": 1, "name": "Adam"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	_, err := mphttp.BindWithOptions(r, personType, mphttp.Options{JSONDecoder: decoder})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestBindMessagePackAndCBOR(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        []byte
+	}{
+		{"application/msgpack", []byte{0x82, 0xa2, 'i', 'd', 0x07, 0xa4, 'n', 'a', 'm', 'e', 0xa4, 'A', 'd', 'a', 'm'}},
+		{"application/cbor", []byte{0xa2, 0x62, 'i', 'd', 0x07, 0x64, 'n', 'a', 'm', 'e', 0x64, 'A', 'd', 'a', 'm'}},
+	}
+
+	for i, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+		r.Header.Set("Content-Type", tt.contentType)
+
+		record, err := mphttp.Bind(r, personType)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, int64(7), record.Get("id"), "%d", i)
+		assert.Equalf(t, "Adam", record.Get("name"), "%d", i)
+	}
+}
+
+func TestBindBodyTooLarge(t *testing.T) {
+	body := `{"id": 1, "name": "` + strings.Repeat("a", 100) + `"}`
+
+	tests := []struct {
+		maxBodyBytes int64
+		ok           bool
+	}{
+		{64, false},
+		{1024, true},
+		{-1, true},
+		{0, true},
+	}
+
+	for i, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, err := mphttp.BindWithOptions(r, personType, mphttp.Options{MaxBodyBytes: tt.maxBodyBytes})
+		if tt.ok {
+			assert.NoErrorf(t, err, "%d", i)
+		} else {
+			var maxBytesErr *http.MaxBytesError
+			assert.ErrorAsf(t, err, &maxBytesErr, "%d", i)
+		}
+	}
+}
+
+func TestBindMultipart(t *testing.T) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	require.NoError(t, w.WriteField("id", "1"))
+	require.NoError(t, w.WriteField("name", "Adam"))
+	fw, err := w.CreateFormFile("avatar", "avatar.png")
+	require.NoError(t, err)
+	_, err = fw.Write([]byte("png"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	record, err := mphttp.Bind(r, personType)
+	require.NoError(t, err)
+	assert.Equal(t, "Adam", record.Get("name"))
+}
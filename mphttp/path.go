@@ -0,0 +1,85 @@
+package mphttp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathPattern matches URL paths against a pattern such as "/users/{id}/posts/{post_id}" and extracts the named
+// segments. It is intended for use with routers that do not extract path parameters themselves.
+type PathPattern struct {
+	pattern  string
+	segments []string
+}
+
+// NewPathPattern parses pattern. Each segment is either a literal or a parameter name wrapped in braces. A parameter
+// matches exactly one non-empty path segment. It returns an error if a parameter is empty, malformed, or repeated.
+func NewPathPattern(pattern string) (*PathPattern, error) {
+	segments := splitPath(pattern)
+	names := make(map[string]struct{})
+	for _, s := range segments {
+		name, isParam := paramName(s)
+		if !isParam {
+			if strings.ContainsAny(s, "{}") {
+				return nil, fmt.Errorf("invalid segment %q in pattern %q", s, pattern)
+			}
+			continue
+		}
+		if name == "" || strings.ContainsAny(name, "{}") {
+			return nil, fmt.Errorf("invalid segment %q in pattern %q", s, pattern)
+		}
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("duplicate parameter %q in pattern %q", name, pattern)
+		}
+		names[name] = struct{}{}
+	}
+
+	return &PathPattern{pattern: pattern, segments: segments}, nil
+}
+
+// MustPathPattern is like NewPathPattern but panics on error.
+func MustPathPattern(pattern string) *PathPattern {
+	p, err := NewPathPattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the pattern p was created with.
+func (p *PathPattern) String() string {
+	return p.pattern
+}
+
+// Match matches path against p. If path matches it returns the parameters and true.
+func (p *PathPattern) Match(path string) (map[string]string, bool) {
+	segments := splitPath(path)
+	if len(segments) != len(p.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, s := range p.segments {
+		if name, isParam := paramName(s); isParam {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[name] = segments[i]
+		} else if s != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func paramName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
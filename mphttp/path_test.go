@@ -0,0 +1,65 @@
+package mphttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/mphttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		params  map[string]string
+		ok      bool
+	}{
+		{"/users/{id}/posts/{post_id}", "/users/7/posts/42", map[string]string{"id": "7", "post_id": "42"}, true},
+		{"/users/{id}", "/users/7/", map[string]string{"id": "7"}, true},
+		{"/users", "/users", map[string]string{}, true},
+		{"/users/{id}", "/users", nil, false},
+		{"/users/{id}", "/users/7/posts", nil, false},
+		{"/users/{id}", "/accounts/7", nil, false},
+		{"/users/{id}/posts", "/users//posts", nil, false},
+	}
+
+	for i, tt := range tests {
+		params, ok := mphttp.MustPathPattern(tt.pattern).Match(tt.path)
+		assert.Equalf(t, tt.ok, ok, "%d", i)
+		assert.Equalf(t, tt.params, params, "%d", i)
+	}
+}
+
+func TestNewPathPatternErrors(t *testing.T) {
+	patterns := []string{
+		"/users/{}",
+		"/users/{id}/posts/{id}",
+		"/users/id}",
+		"/users/{{id}}",
+	}
+
+	for i, pattern := range patterns {
+		_, err := mphttp.NewPathPattern(pattern)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestPathPatternBind(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("id", mp.Int64(), mp.Require()),
+		mp.NewField("post_id", mp.Int64(), mp.Require()),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/users/7/posts/42", nil)
+	params, ok := mphttp.MustPathPattern("/users/{id}/posts/{post_id}").Match(r.URL.Path)
+	require.True(t, ok)
+
+	record, err := mphttp.BindWithOptions(r, ft, mphttp.Options{PathParams: params})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), record.Get("id"))
+	assert.Equal(t, int64(42), record.Get("post_id"))
+}
@@ -0,0 +1,278 @@
+// Package mpmsgpack decodes MessagePack into map[string]any for use with mp.Type.Parse.
+//
+// Integers are decoded as int64, or uint64 if they do not fit in an int64, so they keep full precision. Binary data is
+// decoded as []byte and the timestamp extension is decoded as time.Time. Floats are decoded as float32 or float64.
+package mpmsgpack
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxDepth is the maximum nesting depth of maps and arrays. Deeper input is rejected to prevent stack exhaustion.
+const MaxDepth = 1000
+
+// Decode reads all of r and decodes it with Unmarshal.
+func Decode(r io.Reader) (map[string]any, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(data)
+}
+
+// Unmarshal decodes data, which must contain a single MessagePack map with string keys. Duplicate keys are rejected.
+func Unmarshal(data []byte) (map[string]any, error) {
+	d := &decoder{data: data}
+	value, err := d.decodeValue(0)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("msgpack value is not a map")
+	}
+
+	if d.pos != len(d.data) {
+		return nil, errors.New("unexpected data after msgpack map")
+	}
+
+	return m, nil
+}
+
+var errUnexpectedEnd = errors.New("unexpected end of msgpack data")
+
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || n > len(d.data)-d.pos {
+		return nil, errUnexpectedEnd
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) readUint(size int) (uint64, error) {
+	b, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+// readLength reads a length of size bytes. The length is checked against the remaining data so a hostile length
+// cannot cause a large allocation. minElementSize is the minimum number of bytes each element occupies.
+func (d *decoder) readLength(size int, minElementSize int) (int, error) {
+	n, err := d.readUint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)-d.pos)/uint64(minElementSize) {
+		return 0, errUnexpectedEnd
+	}
+	return int(n), nil
+}
+
+func (d *decoder) decodeValue(depth int) (any, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("msgpack exceeds maximum depth of %d", MaxDepth)
+	}
+
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c >= 0x80 && c <= 0x8f:
+		return d.decodeMap(int(c&0x0f), depth)
+	case c >= 0x90 && c <= 0x9f:
+		return d.decodeArray(int(c&0x0f), depth)
+	case c >= 0xa0 && c <= 0xbf:
+		return d.decodeString(int(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.readLength(1<<(c-0xc4), 1)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.readLength(1<<(c-0xc7), 1)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeExt(n)
+	case 0xca:
+		n, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(uint32(n)), nil
+	case 0xcb:
+		n, err := d.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(n), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := d.readUint(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case 0xd0:
+		n, err := d.readUint(1)
+		return int64(int8(n)), err
+	case 0xd1:
+		n, err := d.readUint(2)
+		return int64(int16(n)), err
+	case 0xd2:
+		n, err := d.readUint(4)
+		return int64(int32(n)), err
+	case 0xd3:
+		n, err := d.readUint(8)
+		return int64(n), err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.decodeExt(1 << (c - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.readLength(1<<(c-0xd9), 1)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(n)
+	case 0xdc, 0xdd:
+		n, err := d.readLength(2<<(c-0xdc), 1)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(n, depth)
+	case 0xde, 0xdf:
+		n, err := d.readLength(2<<(c-0xde), 2)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(n, depth)
+	}
+
+	return nil, fmt.Errorf("invalid msgpack format 0x%02x", c)
+}
+
+func (d *decoder) decodeString(n int) (string, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("msgpack string is not valid UTF-8")
+	}
+	return string(b), nil
+}
+
+func (d *decoder) decodeArray(n int, depth int) ([]any, error) {
+	elements := make([]any, n)
+	for i := range elements {
+		var err error
+		elements[i], err = d.decodeValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return elements, nil
+}
+
+func (d *decoder) decodeMap(n int, depth int) (map[string]any, error) {
+	m := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		key, err := d.decodeValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("msgpack map key %v is not a string", key)
+		}
+		if _, ok := m[s]; ok {
+			return nil, fmt.Errorf("duplicate msgpack map key %q", s)
+		}
+
+		m[s], err = d.decodeValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// timestampExtType is the extension type of the MessagePack timestamp.
+const timestampExtType = -1
+
+func (d *decoder) decodeExt(n int) (any, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	extType := int8(b[0])
+
+	data, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if extType != timestampExtType {
+		return nil, fmt.Errorf("unsupported msgpack extension type %d", extType)
+	}
+
+	switch len(data) {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+	case 8:
+		n := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(n&0x3ffffffff), int64(n>>34)).UTC(), nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(data[:4])
+		sec := int64(binary.BigEndian.Uint64(data[4:]))
+		return time.Unix(sec, int64(nsec)).UTC(), nil
+	}
+
+	return nil, errors.New("invalid msgpack timestamp")
+}
@@ -0,0 +1,95 @@
+package mpmsgpack_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/mpmsgpack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalValues(t *testing.T) {
+	tests := []struct {
+		encoded  []byte
+		expected any
+	}{
+		{[]byte{0x05}, int64(5)},
+		{[]byte{0xff}, int64(-1)},
+		{[]byte{0xcc, 0xc8}, int64(200)},
+		{[]byte{0xcd, 0x01, 0x00}, int64(256)},
+		{[]byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, uint64(math.MaxUint64)},
+		{[]byte{0xd0, 0x80}, int64(-128)},
+		{[]byte{0xd3, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}, int64(9007199254740993)},
+		{[]byte{0xca, 0x3f, 0xc0, 0x00, 0x00}, float32(1.5)},
+		{[]byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, float64(1.5)},
+		{[]byte{0xc0}, nil},
+		{[]byte{0xc2}, false},
+		{[]byte{0xc3}, true},
+		{[]byte{0xa2, 'h', 'i'}, "hi"},
+		{[]byte{0xd9, 0x02, 'h', 'i'}, "hi"},
+		{[]byte{0xc4, 0x02, 0x01, 0x02}, []byte{1, 2}},
+		{[]byte{0x92, 0x01, 0xa1, 'a'}, []any{int64(1), "a"}},
+		{[]byte{0xdc, 0x00, 0x01, 0x01}, []any{int64(1)}},
+		{[]byte{0xd6, 0xff, 0x00, 0x00, 0x00, 0x3c}, time.Unix(60, 0).UTC()},
+		{[]byte{0xc7, 0x0c, 0xff, 0x00, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0, 0, 0x3c}, time.Unix(60, 1).UTC()},
+	}
+
+	for i, tt := range tests {
+		encoded := append([]byte{0x81, 0xa1, 'v'}, tt.encoded...)
+		m, err := mpmsgpack.Unmarshal(encoded)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, m["v"], "%d", i)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := [][]byte{
+		{},
+		{0x01},
+		{0x92, 0x01, 0x02},
+		{0x81, 0x01, 0x01},
+		{0x82, 0xa1, 'a', 0x01, 0xa1, 'a', 0x02},
+		{0x81, 0xa1, 'a'},
+		{0x81, 0xa1, 'a', 0xdb, 0xff, 0xff, 0xff, 0xff},
+		{0x81, 0xa1, 'a', 0xa1, 0xff},
+		{0x81, 0xa1, 'a', 0xc1},
+		{0x81, 0xa1, 'a', 0xd4, 0x01, 0x00},
+		{0x80, 0x01},
+		append([]byte{0x81, 0xa1, 'a'}, bytes.Repeat([]byte{0x91}, mpmsgpack.MaxDepth+1)...),
+	}
+
+	for i, data := range tests {
+		_, err := mpmsgpack.Unmarshal(data)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestDecodeAndParse(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64()),
+		mp.NewField("data", mp.Bytes(16)),
+	)
+
+	encoded := []byte{0x83,
+		0xa4, 'n', 'a', 'm', 'e', 0xa4, 'A', 'd', 'a', 'm',
+		0xa3, 'a', 'g', 'e', 0x1e,
+		0xa4, 'd', 'a', 't', 'a', 0xc4, 0x01, 0xff,
+	}
+	m, err := mpmsgpack.Decode(bytes.NewReader(encoded))
+	require.NoError(t, err)
+
+	record := ft.Parse(m)
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Adam", record.Get("name"))
+	assert.Equal(t, int64(30), record.Get("age"))
+	assert.Equal(t, []byte{0xff}, record.Get("data"))
+
+	_, err = mpmsgpack.Decode(strings.NewReader(""))
+	assert.Error(t, err)
+}
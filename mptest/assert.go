@@ -0,0 +1,124 @@
+package mptest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jackc/mp"
+)
+
+// AssertValid asserts that t parses attrs without errors and returns the parsed Record.
+func AssertValid(tb testing.TB, t *mp.Type, attrs map[string]any) *mp.Record {
+	tb.Helper()
+
+	record := t.Parse(attrs)
+	if err := record.Errors(); err != nil {
+		tb.Errorf("expected %v to be valid: %v", attrs, err)
+	}
+
+	return record
+}
+
+// AssertFieldError asserts that parsing attrs with t fails with an error for field that matches target with errors.Is.
+// target is usually one of the mp error kinds such as mp.ErrRequired or mp.ErrTooSmall. The error for field is
+// returned.
+func AssertFieldError(tb testing.TB, t *mp.Type, attrs map[string]any, field string, target error) error {
+	tb.Helper()
+
+	record := t.Parse(attrs)
+	var errs mp.Errors
+	if !errors.As(record.Errors(), &errs) {
+		tb.Errorf("expected %v to have an error for %s but it was valid", attrs, field)
+		return nil
+	}
+
+	err, ok := errs[field]
+	if !ok {
+		tb.Errorf("expected %v to have an error for %s but got: %v", attrs, field, errs)
+		return nil
+	}
+
+	if !errors.Is(err, target) {
+		tb.Errorf("expected error for %s to be %q but got %q", field, target, err)
+	}
+
+	return err
+}
+
+// ConverterCase is a test case for RunConverterCases.
+type ConverterCase struct {
+	// Input is the value passed to the converter.
+	Input any
+
+	// Expected is the expected converted value. It is ignored if Err is not empty.
+	Expected any
+
+	// Err is a substring of the expected error message. If Err is empty then the conversion must succeed.
+	Err string
+}
+
+// RunConverterCases runs each case against vc as a subtest.
+func RunConverterCases(t *testing.T, vc mp.ValueConverter, cases []ConverterCase) {
+	t.Helper()
+
+	for i, tc := range cases {
+		tc := tc
+		t.Run(fmt.Sprintf("%d %#v", i, tc.Input), func(t *testing.T) {
+			t.Helper()
+			checkConverterCase(t, vc, tc)
+		})
+	}
+}
+
+func checkConverterCase(tb testing.TB, vc mp.ValueConverter, tc ConverterCase) {
+	tb.Helper()
+
+	value, err := convert(vc, tc.Input)
+	if tc.Err != "" {
+		if err == nil {
+			tb.Errorf("expected error containing %q but got %#v", tc.Err, value)
+		} else if !strings.Contains(err.Error(), tc.Err) {
+			tb.Errorf("expected error containing %q but got %q", tc.Err, err.Error())
+		}
+		return
+	}
+
+	if err != nil {
+		tb.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if !equalValues(tc.Expected, value) {
+		tb.Errorf("expected %#v but got %#v", tc.Expected, value)
+	}
+}
+
+// Attrs builds an attrs map from alternating keys and values. It panics if a key is not a string or if the last key
+// has no value.
+func Attrs(keysAndValues ...any) map[string]any {
+	return With(nil, keysAndValues...)
+}
+
+// With returns a copy of base with the alternating keys and values set. It is useful for deriving invalid inputs from
+// a valid one.
+func With(base map[string]any, keysAndValues ...any) map[string]any {
+	if len(keysAndValues)%2 != 0 {
+		panic("keysAndValues must have an even number of elements")
+	}
+
+	attrs := make(map[string]any, len(base)+len(keysAndValues)/2)
+	for k, v := range base {
+		attrs[k] = v
+	}
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			panic(fmt.Sprintf("key %v is not a string", keysAndValues[i]))
+		}
+		attrs[key] = keysAndValues[i+1]
+	}
+
+	return attrs
+}
@@ -0,0 +1,70 @@
+package mptest_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/mptest"
+)
+
+var personType = mp.NewType(
+	mp.NewField("name", mp.SingleLineString(), mp.Require()),
+	mp.NewField("age", mp.Int64(), mp.GreaterThanOrEqual(18)),
+)
+
+func TestAssertValid(t *testing.T) {
+	valid := mptest.Attrs("name", "Adam", "age", 30)
+
+	record := mptest.AssertValid(t, personType, valid)
+	if record.Get("age") != int64(30) {
+		t.Errorf("unexpected age: %v", record.Get("age"))
+	}
+
+	tb := &recordingTB{}
+	mptest.AssertValid(tb, personType, mptest.With(valid, "name", ""))
+	if !tb.failed {
+		t.Error("expected failure")
+	}
+}
+
+func TestAssertFieldError(t *testing.T) {
+	valid := mptest.Attrs("name", "Adam", "age", 30)
+
+	mptest.AssertFieldError(t, personType, mptest.With(valid, "age", 12), "age", mp.ErrTooSmall)
+	mptest.AssertFieldError(t, personType, mptest.With(valid, "name", ""), "name", mp.ErrRequired)
+
+	tests := []struct {
+		attrs  map[string]any
+		field  string
+		target error
+	}{
+		{valid, "age", mp.ErrTooSmall},
+		{mptest.With(valid, "age", 12), "name", mp.ErrTooSmall},
+		{mptest.With(valid, "age", 12), "age", mp.ErrNotANumber},
+	}
+
+	for i, tt := range tests {
+		tb := &recordingTB{}
+		mptest.AssertFieldError(tb, personType, tt.attrs, tt.field, tt.target)
+		if !tb.failed {
+			t.Errorf("%d: expected failure", i)
+		}
+	}
+}
+
+func TestRunConverterCases(t *testing.T) {
+	mptest.RunConverterCases(t, mp.Int64(), []mptest.ConverterCase{
+		{Input: "42", Expected: int64(42)},
+		{Input: nil, Expected: nil},
+		{Input: "abc", Err: "not a valid number"},
+	})
+}
+
+func TestWith(t *testing.T) {
+	base := mptest.Attrs("a", 1)
+	attrs := mptest.With(base, "b", 2)
+
+	if len(base) != 1 || len(attrs) != 2 || attrs["a"] != 1 || attrs["b"] != 2 {
+		t.Errorf("unexpected attrs: base=%v attrs=%v", base, attrs)
+	}
+}
@@ -0,0 +1,203 @@
+// Package mptest provides helpers for testing code that uses package mp.
+package mptest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+)
+
+// ConverterContractOptions configures TestConverterContract.
+type ConverterContractOptions struct {
+	// Iterations is the number of generated inputs to test. If zero, 1000 is used.
+	Iterations int
+
+	// Seed is the seed for the random input generator. The same seed always produces the same inputs.
+	Seed int64
+
+	// Inputs are additional inputs that are tested in addition to the generated inputs.
+	Inputs []any
+
+	// Idempotent asserts that converting a successfully converted value again succeeds and returns an equal value.
+	// This should be true for normalizers such as SingleLineString or Int64.
+	Idempotent bool
+
+	// AllowNonNilForNil allows the converter to return a non-nil value for nil input. This is necessary for converters
+	// such as Default.
+	AllowNonNilForNil bool
+}
+
+// TestConverterContract asserts that vc satisfies the invariants that all ValueConverters should satisfy:
+//
+//   - It never panics.
+//   - When it returns an error the returned value is nil.
+//   - When the input is nil it returns nil or an error (unless AllowNonNilForNil is set).
+//   - When Idempotent is set, converting a converted value again returns an equal value.
+//
+// Inputs are generated pseudo-randomly and include strings with invalid UTF-8, numbers at the limits of their
+// ranges, NaN and infinities, slices, maps, and nil.
+func TestConverterContract(t testing.TB, vc mp.ValueConverter, options ConverterContractOptions) {
+	t.Helper()
+
+	iterations := options.Iterations
+	if iterations == 0 {
+		iterations = 1000
+	}
+
+	value, err := convert(vc, nil)
+	if err != nil && !isConvertError(err) {
+		t.Errorf("nil: %v", err)
+	} else if err == nil && value != nil && !options.AllowNonNilForNil {
+		t.Errorf("nil: returned %#v instead of nil", value)
+	}
+
+	inputs := append(fixedInputs(), options.Inputs...)
+	rng := rand.New(rand.NewSource(options.Seed))
+	for i := 0; i < iterations; i++ {
+		inputs = append(inputs, randomValue(rng, 2))
+	}
+
+	for _, input := range inputs {
+		checkInput(t, vc, input, options)
+	}
+}
+
+func checkInput(t testing.TB, vc mp.ValueConverter, input any, options ConverterContractOptions) {
+	t.Helper()
+
+	value, err := convert(vc, input)
+	if err != nil {
+		if !isConvertError(err) {
+			t.Errorf("%#v: %v", input, err)
+		} else if value != nil {
+			t.Errorf("%#v: returned %#v with error %v", input, value, err)
+		}
+		return
+	}
+
+	if options.Idempotent {
+		again, err := convert(vc, value)
+		if err != nil {
+			t.Errorf("%#v: converting %#v again failed: %v", input, value, err)
+		} else if !equalValues(value, again) {
+			t.Errorf("%#v: converting %#v again returned %#v", input, value, again)
+		}
+	}
+}
+
+// equalValues is reflect.DeepEqual except that NaN is considered equal to NaN.
+func equalValues(a, b any) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+
+	switch a := a.(type) {
+	case float32:
+		b, ok := b.(float32)
+		return ok && math.IsNaN(float64(a)) && math.IsNaN(float64(b))
+	case float64:
+		b, ok := b.(float64)
+		return ok && math.IsNaN(a) && math.IsNaN(b)
+	}
+
+	return false
+}
+
+// panicError is returned by convert when the converter panics.
+type panicError struct {
+	recovered any
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.recovered)
+}
+
+func isConvertError(err error) bool {
+	_, ok := err.(*panicError)
+	return !ok
+}
+
+func convert(vc mp.ValueConverter, input any) (value any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value = nil
+			err = &panicError{recovered: r}
+		}
+	}()
+
+	return vc.ConvertValue(input)
+}
+
+func fixedInputs() []any {
+	return []any{
+		"", " ", "\t\n", "0", "-1", "1.5", "true", "abc", "a\xfe\xffa", "​",
+		int8(math.MinInt8), uint8(math.MaxUint8), int16(math.MinInt16), uint16(math.MaxUint16),
+		int32(math.MinInt32), uint32(math.MaxUint32), int64(math.MinInt64), uint64(math.MaxUint64),
+		int(0), uint(math.MaxUint), float32(math.MaxFloat32), -math.MaxFloat64,
+		math.NaN(), math.Inf(1), math.Inf(-1), math.SmallestNonzeroFloat64,
+		true, false, []byte{}, []byte{0, 1, 2}, []any{}, []any{nil}, map[string]any{},
+		map[string]any{"": nil}, time.Time{}, struct{}{}, new(int), (*int)(nil),
+	}
+}
+
+func randomValue(rng *rand.Rand, depth int) any {
+	n := 9
+	if depth > 0 {
+		n = 11
+	}
+
+	switch rng.Intn(n) {
+	case 0:
+		return nil
+	case 1:
+		return randomString(rng)
+	case 2:
+		return rng.Int63() - rng.Int63()
+	case 3:
+		return int32(rng.Uint32())
+	case 4:
+		return rng.NormFloat64() * math.Pow(10, float64(rng.Intn(40)))
+	case 5:
+		return rng.Intn(2) == 0
+	case 6:
+		b := make([]byte, rng.Intn(20))
+		rng.Read(b)
+		return b
+	case 7:
+		return fmt.Sprint(rng.Int63() - rng.Int63())
+	case 8:
+		return time.Unix(rng.Int63n(1<<33), 0)
+	case 9:
+		s := make([]any, rng.Intn(4))
+		for i := range s {
+			s[i] = randomValue(rng, depth-1)
+		}
+		return s
+	default:
+		m := make(map[string]any)
+		for i := rng.Intn(4); i > 0; i-- {
+			m[randomString(rng)] = randomValue(rng, depth-1)
+		}
+		return m
+	}
+}
+
+func randomString(rng *rand.Rand) string {
+	const alphabet = " \t\n\r0123456789.-+eEabcxyzABCé​\U0001F600"
+	runes := []rune(alphabet)
+
+	b := make([]byte, 0, 16)
+	for i := rng.Intn(16); i > 0; i-- {
+		if rng.Intn(20) == 0 {
+			b = append(b, byte(rng.Intn(256)))
+		} else {
+			b = append(b, string(runes[rng.Intn(len(runes))])...)
+		}
+	}
+	return string(b)
+}
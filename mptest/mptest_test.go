@@ -0,0 +1,88 @@
+package mptest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/jackc/mp/mptest"
+)
+
+func TestBuiltinConvertersSatisfyContract(t *testing.T) {
+	nestedType := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+	)
+
+	tests := []struct {
+		name      string
+		converter mp.ValueConverter
+		options   mptest.ConverterContractOptions
+	}{
+		{"Int64", mp.Int64(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"Int32", mp.Int32(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"Float64", mp.Float64(), mptest.ConverterContractOptions{}},
+		{"Float32", mp.Float32(), mptest.ConverterContractOptions{}},
+		{"Bool", mp.Bool(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"BoolPtr", mp.BoolPtr(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"Time", mp.Time("2006-01-02"), mptest.ConverterContractOptions{Idempotent: true}},
+		{"UUID", mp.UUID(), mptest.ConverterContractOptions{}},
+		{"Decimal", mp.Decimal(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"String", mp.String(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"SingleLineString", mp.SingleLineString(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"MultiLineString", mp.MultiLineString(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"NotNil", mp.NotNil(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"Require", mp.Require(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"IfNotNil", mp.IfNotNil(mp.Int64()), mptest.ConverterContractOptions{Idempotent: true}},
+		{"Optional", mp.Optional(mp.Int64()), mptest.ConverterContractOptions{Idempotent: true}},
+		{"Default", mp.Default("x"), mptest.ConverterContractOptions{Idempotent: true, AllowNonNilForNil: true}},
+		{"NilifyEmpty", mp.NilifyEmpty(), mptest.ConverterContractOptions{Idempotent: true}},
+		{"MinLen", mp.MinLen(2), mptest.ConverterContractOptions{Idempotent: true}},
+		{"MaxLen", mp.MaxLen(2), mptest.ConverterContractOptions{Idempotent: true}},
+		{"AllowStrings", mp.AllowStrings("a", "b"), mptest.ConverterContractOptions{Idempotent: true}},
+		{"ExcludeStrings", mp.ExcludeStrings("a", "b"), mptest.ConverterContractOptions{Idempotent: true}},
+		{"LessThan", mp.LessThan(10), mptest.ConverterContractOptions{Idempotent: true}},
+		{"GreaterThanOrEqual", mp.GreaterThanOrEqual(10), mptest.ConverterContractOptions{Idempotent: true}},
+		{"Sort", mp.Sort("a", "b"), mptest.ConverterContractOptions{Idempotent: true}},
+		{"SliceInt64", mp.Slice[int64](mp.Int64()), mptest.ConverterContractOptions{Idempotent: true}},
+		{"SliceRecord", mp.Slice[*mp.Record](nestedType), mptest.ConverterContractOptions{}},
+		{"Type", nestedType, mptest.ConverterContractOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mptest.TestConverterContract(t, tt.converter, tt.options)
+		})
+	}
+}
+
+type recordingTB struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failed = true
+}
+
+func TestConverterContractDetectsViolations(t *testing.T) {
+	tests := []struct {
+		name      string
+		converter mp.ValueConverter
+	}{
+		{"panics", mp.ValueConverterFunc(func(value any) (any, error) { panic("boom") })},
+		{"value with error", mp.ValueConverterFunc(func(value any) (any, error) { return 1, errors.New("fail") })},
+		{"non-nil for nil", mp.ValueConverterFunc(func(value any) (any, error) { return 1, nil })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rtb := &recordingTB{TB: t}
+			mptest.TestConverterContract(rtb, tt.converter, mptest.ConverterContractOptions{Iterations: 10})
+			if !rtb.failed {
+				t.Error("expected contract violation")
+			}
+		})
+	}
+}
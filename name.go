@@ -0,0 +1,218 @@
+package mp
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NameParticles are common lowercase particles of family names such as "van" and "de". They can be used as
+// PersonNameOptions.Particles.
+var NameParticles = []string{
+	"al", "bin", "da", "das", "de", "del", "della", "der", "di", "do", "dos", "du", "la", "le", "ten", "ter", "van",
+	"von", "zu",
+}
+
+// PersonNameOptions configures the converter returned by PersonNameWithOptions.
+type PersonNameOptions struct {
+	// PreserveCase disables title-casing. By default, words that are entirely lowercase or entirely uppercase are
+	// title-cased. Words with mixed case such as "McDonald" are always kept as they are.
+	PreserveCase bool
+
+	// Particles are words that are kept lowercase unless they are the first word of the name, e.g. "van" in
+	// "Ludwig van Beethoven". See NameParticles.
+	Particles []string
+
+	// SpecialCase is used for case mapping. Use unicode.TurkishCase for Turkish and Azeri names.
+	SpecialCase unicode.SpecialCase
+}
+
+var errInvalidNameCharacter = newKindError(ErrInvalidFormat, "contains characters not allowed in a name")
+
+// PersonName returns a ValueConverter that normalizes the name of a person. If value is nil then nil is returned. If
+// value is not a string then an error is returned.
+//
+// It performs the following operations:
+//   - Trim white space and collapse runs of white space to a single space
+//   - Compose Latin letters followed by combining accents into single characters, e.g. "é" to "é"
+//   - Reject characters other than letters, combining marks, spaces, hyphens, apostrophes, and periods
+//   - Title-case words that are entirely lowercase or entirely uppercase, e.g. "o'NEIL" is unchanged but "MARY-JANE"
+//     becomes "Mary-Jane"
+func PersonName() ValueConverter {
+	return PersonNameWithOptions(PersonNameOptions{})
+}
+
+// PersonNameWithOptions is like PersonName but with options.
+func PersonNameWithOptions(options PersonNameOptions) ValueConverter {
+	c := &personNameValueConverter{options: options}
+	if len(options.Particles) > 0 {
+		c.particles = make(map[string]struct{}, len(options.Particles))
+		for _, p := range options.Particles {
+			c.particles[foldString(p)] = struct{}{}
+		}
+	}
+	return c
+}
+
+type personNameValueConverter struct {
+	options   PersonNameOptions
+	particles map[string]struct{}
+}
+
+func (c *personNameValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	words := strings.Fields(composeLatin(s))
+	for i, word := range words {
+		for _, r := range word {
+			if !isNameRune(r) {
+				return nil, errInvalidNameCharacter
+			}
+		}
+		if !c.options.PreserveCase {
+			words[i] = c.titleCase(word, i == 0)
+		}
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+func (c *personNameValueConverter) titleCase(word string, first bool) string {
+	hasUpper, hasLower := false, false
+	for _, r := range word {
+		hasUpper = hasUpper || unicode.IsUpper(r)
+		hasLower = hasLower || unicode.IsLower(r)
+	}
+	if hasUpper && hasLower {
+		return word
+	}
+
+	sc := c.options.SpecialCase
+	lower := strings.ToLowerSpecial(sc, word)
+	if !first && c.particles != nil {
+		if _, ok := c.particles[foldString(lower)]; ok {
+			return lower
+		}
+	}
+
+	startOfPart := true
+	return strings.Map(func(r rune) rune {
+		if isNameSeparator(r) {
+			startOfPart = true
+			return r
+		}
+		if startOfPart && unicode.IsLetter(r) {
+			startOfPart = false
+			return sc.ToTitle(r)
+		}
+		return r
+	}, lower)
+}
+
+func (c *personNameValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf("")
+}
+
+func (c *personNameValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"personName": true}
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || isNameSeparator(r)
+}
+
+// isNameSeparator returns true if r separates the parts of a word of a name such as "Mary-Jane" or "O'Neil".
+func isNameSeparator(r rune) bool {
+	return r == '-' || r == '\'' || r == '’' || r == '.'
+}
+
+// composeLatin composes Latin letters followed by a combining mark into the equivalent precomposed character. It
+// implements the part of Unicode NFC normalization that is needed for names written in Latin scripts.
+func composeLatin(s string) string {
+	hasMark := false
+	for _, r := range s {
+		if unicode.Is(unicode.Mn, r) {
+			hasMark = true
+			break
+		}
+	}
+	if !hasMark {
+		return s
+	}
+
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		if n := len(runes); n > 0 {
+			if composed, ok := latinCompositions[[2]rune{runes[n-1], r}]; ok {
+				runes[n-1] = composed
+				continue
+			}
+		}
+		runes = append(runes, r)
+	}
+	return string(runes)
+}
+
+// latinCompositions maps a Latin letter and a combining mark to the precomposed character of the Latin-1 Supplement
+// and Latin Extended-A and B blocks.
+var latinCompositions = map[[2]rune]rune{
+	{'A', 0x0300}: 'À', {'A', 0x0301}: 'Á', {'A', 0x0302}: 'Â', {'A', 0x0303}: 'Ã', {'A', 0x0308}: 'Ä',
+	{'A', 0x030A}: 'Å', {'C', 0x0327}: 'Ç', {'E', 0x0300}: 'È', {'E', 0x0301}: 'É', {'E', 0x0302}: 'Ê',
+	{'E', 0x0308}: 'Ë', {'I', 0x0300}: 'Ì', {'I', 0x0301}: 'Í', {'I', 0x0302}: 'Î', {'I', 0x0308}: 'Ï',
+	{'N', 0x0303}: 'Ñ', {'O', 0x0300}: 'Ò', {'O', 0x0301}: 'Ó', {'O', 0x0302}: 'Ô', {'O', 0x0303}: 'Õ',
+	{'O', 0x0308}: 'Ö', {'U', 0x0300}: 'Ù', {'U', 0x0301}: 'Ú', {'U', 0x0302}: 'Û', {'U', 0x0308}: 'Ü',
+	{'Y', 0x0301}: 'Ý', {'a', 0x0300}: 'à', {'a', 0x0301}: 'á', {'a', 0x0302}: 'â', {'a', 0x0303}: 'ã',
+	{'a', 0x0308}: 'ä', {'a', 0x030A}: 'å', {'c', 0x0327}: 'ç', {'e', 0x0300}: 'è', {'e', 0x0301}: 'é',
+	{'e', 0x0302}: 'ê', {'e', 0x0308}: 'ë', {'i', 0x0300}: 'ì', {'i', 0x0301}: 'í', {'i', 0x0302}: 'î',
+	{'i', 0x0308}: 'ï', {'n', 0x0303}: 'ñ', {'o', 0x0300}: 'ò', {'o', 0x0301}: 'ó', {'o', 0x0302}: 'ô',
+	{'o', 0x0303}: 'õ', {'o', 0x0308}: 'ö', {'u', 0x0300}: 'ù', {'u', 0x0301}: 'ú', {'u', 0x0302}: 'û',
+	{'u', 0x0308}: 'ü', {'y', 0x0301}: 'ý', {'y', 0x0308}: 'ÿ', {'A', 0x0304}: 'Ā', {'a', 0x0304}: 'ā',
+	{'A', 0x0306}: 'Ă', {'a', 0x0306}: 'ă', {'A', 0x0328}: 'Ą', {'a', 0x0328}: 'ą', {'C', 0x0301}: 'Ć',
+	{'c', 0x0301}: 'ć', {'C', 0x0302}: 'Ĉ', {'c', 0x0302}: 'ĉ', {'C', 0x0307}: 'Ċ', {'c', 0x0307}: 'ċ',
+	{'C', 0x030C}: 'Č', {'c', 0x030C}: 'č', {'D', 0x030C}: 'Ď', {'d', 0x030C}: 'ď', {'E', 0x0304}: 'Ē',
+	{'e', 0x0304}: 'ē', {'E', 0x0306}: 'Ĕ', {'e', 0x0306}: 'ĕ', {'E', 0x0307}: 'Ė', {'e', 0x0307}: 'ė',
+	{'E', 0x0328}: 'Ę', {'e', 0x0328}: 'ę', {'E', 0x030C}: 'Ě', {'e', 0x030C}: 'ě', {'G', 0x0302}: 'Ĝ',
+	{'g', 0x0302}: 'ĝ', {'G', 0x0306}: 'Ğ', {'g', 0x0306}: 'ğ', {'G', 0x0307}: 'Ġ', {'g', 0x0307}: 'ġ',
+	{'G', 0x0327}: 'Ģ', {'g', 0x0327}: 'ģ', {'H', 0x0302}: 'Ĥ', {'h', 0x0302}: 'ĥ', {'I', 0x0303}: 'Ĩ',
+	{'i', 0x0303}: 'ĩ', {'I', 0x0304}: 'Ī', {'i', 0x0304}: 'ī', {'I', 0x0306}: 'Ĭ', {'i', 0x0306}: 'ĭ',
+	{'I', 0x0328}: 'Į', {'i', 0x0328}: 'į', {'I', 0x0307}: 'İ', {'J', 0x0302}: 'Ĵ', {'j', 0x0302}: 'ĵ',
+	{'K', 0x0327}: 'Ķ', {'k', 0x0327}: 'ķ', {'L', 0x0301}: 'Ĺ', {'l', 0x0301}: 'ĺ', {'L', 0x0327}: 'Ļ',
+	{'l', 0x0327}: 'ļ', {'L', 0x030C}: 'Ľ', {'l', 0x030C}: 'ľ', {'N', 0x0301}: 'Ń', {'n', 0x0301}: 'ń',
+	{'N', 0x0327}: 'Ņ', {'n', 0x0327}: 'ņ', {'N', 0x030C}: 'Ň', {'n', 0x030C}: 'ň', {'O', 0x0304}: 'Ō',
+	{'o', 0x0304}: 'ō', {'O', 0x0306}: 'Ŏ', {'o', 0x0306}: 'ŏ', {'O', 0x030B}: 'Ő', {'o', 0x030B}: 'ő',
+	{'R', 0x0301}: 'Ŕ', {'r', 0x0301}: 'ŕ', {'R', 0x0327}: 'Ŗ', {'r', 0x0327}: 'ŗ', {'R', 0x030C}: 'Ř',
+	{'r', 0x030C}: 'ř', {'S', 0x0301}: 'Ś', {'s', 0x0301}: 'ś', {'S', 0x0302}: 'Ŝ', {'s', 0x0302}: 'ŝ',
+	{'S', 0x0327}: 'Ş', {'s', 0x0327}: 'ş', {'S', 0x030C}: 'Š', {'s', 0x030C}: 'š', {'T', 0x0327}: 'Ţ',
+	{'t', 0x0327}: 'ţ', {'T', 0x030C}: 'Ť', {'t', 0x030C}: 'ť', {'U', 0x0303}: 'Ũ', {'u', 0x0303}: 'ũ',
+	{'U', 0x0304}: 'Ū', {'u', 0x0304}: 'ū', {'U', 0x0306}: 'Ŭ', {'u', 0x0306}: 'ŭ', {'U', 0x030A}: 'Ů',
+	{'u', 0x030A}: 'ů', {'U', 0x030B}: 'Ű', {'u', 0x030B}: 'ű', {'U', 0x0328}: 'Ų', {'u', 0x0328}: 'ų',
+	{'W', 0x0302}: 'Ŵ', {'w', 0x0302}: 'ŵ', {'Y', 0x0302}: 'Ŷ', {'y', 0x0302}: 'ŷ', {'Y', 0x0308}: 'Ÿ',
+	{'Z', 0x0301}: 'Ź', {'z', 0x0301}: 'ź', {'Z', 0x0307}: 'Ż', {'z', 0x0307}: 'ż', {'Z', 0x030C}: 'Ž',
+	{'z', 0x030C}: 'ž', {'O', 0x031B}: 'Ơ', {'o', 0x031B}: 'ơ', {'U', 0x031B}: 'Ư', {'u', 0x031B}: 'ư',
+	{'A', 0x030C}: 'Ǎ', {'a', 0x030C}: 'ǎ', {'I', 0x030C}: 'Ǐ', {'i', 0x030C}: 'ǐ', {'O', 0x030C}: 'Ǒ',
+	{'o', 0x030C}: 'ǒ', {'U', 0x030C}: 'Ǔ', {'u', 0x030C}: 'ǔ', {'Ü', 0x0304}: 'Ǖ', {'ü', 0x0304}: 'ǖ',
+	{'Ü', 0x0301}: 'Ǘ', {'ü', 0x0301}: 'ǘ', {'Ü', 0x030C}: 'Ǚ', {'ü', 0x030C}: 'ǚ', {'Ü', 0x0300}: 'Ǜ',
+	{'ü', 0x0300}: 'ǜ', {'Ä', 0x0304}: 'Ǟ', {'ä', 0x0304}: 'ǟ', {'Ȧ', 0x0304}: 'Ǡ', {'ȧ', 0x0304}: 'ǡ',
+	{'Æ', 0x0304}: 'Ǣ', {'æ', 0x0304}: 'ǣ', {'G', 0x030C}: 'Ǧ', {'g', 0x030C}: 'ǧ', {'K', 0x030C}: 'Ǩ',
+	{'k', 0x030C}: 'ǩ', {'O', 0x0328}: 'Ǫ', {'o', 0x0328}: 'ǫ', {'Ǫ', 0x0304}: 'Ǭ', {'ǫ', 0x0304}: 'ǭ',
+	{'Ʒ', 0x030C}: 'Ǯ', {'ʒ', 0x030C}: 'ǯ', {'j', 0x030C}: 'ǰ', {'G', 0x0301}: 'Ǵ', {'g', 0x0301}: 'ǵ',
+	{'N', 0x0300}: 'Ǹ', {'n', 0x0300}: 'ǹ', {'Å', 0x0301}: 'Ǻ', {'å', 0x0301}: 'ǻ', {'Æ', 0x0301}: 'Ǽ',
+	{'æ', 0x0301}: 'ǽ', {'Ø', 0x0301}: 'Ǿ', {'ø', 0x0301}: 'ǿ', {'A', 0x030F}: 'Ȁ', {'a', 0x030F}: 'ȁ',
+	{'A', 0x0311}: 'Ȃ', {'a', 0x0311}: 'ȃ', {'E', 0x030F}: 'Ȅ', {'e', 0x030F}: 'ȅ', {'E', 0x0311}: 'Ȇ',
+	{'e', 0x0311}: 'ȇ', {'I', 0x030F}: 'Ȉ', {'i', 0x030F}: 'ȉ', {'I', 0x0311}: 'Ȋ', {'i', 0x0311}: 'ȋ',
+	{'O', 0x030F}: 'Ȍ', {'o', 0x030F}: 'ȍ', {'O', 0x0311}: 'Ȏ', {'o', 0x0311}: 'ȏ', {'R', 0x030F}: 'Ȑ',
+	{'r', 0x030F}: 'ȑ', {'R', 0x0311}: 'Ȓ', {'r', 0x0311}: 'ȓ', {'U', 0x030F}: 'Ȕ', {'u', 0x030F}: 'ȕ',
+	{'U', 0x0311}: 'Ȗ', {'u', 0x0311}: 'ȗ', {'S', 0x0326}: 'Ș', {'s', 0x0326}: 'ș', {'T', 0x0326}: 'Ț',
+	{'t', 0x0326}: 'ț', {'H', 0x030C}: 'Ȟ', {'h', 0x030C}: 'ȟ', {'A', 0x0307}: 'Ȧ', {'a', 0x0307}: 'ȧ',
+	{'E', 0x0327}: 'Ȩ', {'e', 0x0327}: 'ȩ', {'Ö', 0x0304}: 'Ȫ', {'ö', 0x0304}: 'ȫ', {'Õ', 0x0304}: 'Ȭ',
+	{'õ', 0x0304}: 'ȭ', {'O', 0x0307}: 'Ȯ', {'o', 0x0307}: 'ȯ', {'Ȯ', 0x0304}: 'Ȱ', {'ȯ', 0x0304}: 'ȱ',
+	{'Y', 0x0304}: 'Ȳ', {'y', 0x0304}: 'ȳ',
+}
@@ -0,0 +1,66 @@
+package mp_test
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonName(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{"  jack   christensen ", "Jack Christensen", ""},
+		{"MARY-JANE WATSON", "Mary-Jane Watson", ""},
+		{"o'neil", "O'Neil", ""},
+		{"McDonald", "McDonald", ""},
+		{"j.r.r. tolkien", "J.R.R. Tolkien", ""},
+		{"Jose\u0301 N\u0303un\u0303ez", "Jos\u00e9 \u00d1u\u00f1ez", ""},
+		{"ludwig van beethoven", "Ludwig Van Beethoven", ""},
+		{"李小龍", "李小龍", ""},
+		{"", "", ""},
+		{"R2-D2", nil, "contains characters not allowed in a name"},
+		{"Jack <script>", nil, "contains characters not allowed in a name"},
+		{nil, nil, ""},
+		{42, nil, "not a string"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.PersonName().ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+
+	_, err := mp.PersonName().ConvertValue("R2-D2")
+	assert.ErrorIs(t, err, mp.ErrInvalidFormat)
+}
+
+func TestPersonNameWithOptions(t *testing.T) {
+	tests := []struct {
+		options  mp.PersonNameOptions
+		value    string
+		expected string
+	}{
+		{mp.PersonNameOptions{Particles: mp.NameParticles}, "ludwig VAN beethoven", "Ludwig van Beethoven"},
+		{mp.PersonNameOptions{Particles: mp.NameParticles}, "de la cruz", "De la Cruz"},
+		{mp.PersonNameOptions{Particles: mp.NameParticles}, "Vincent Van Gogh", "Vincent Van Gogh"},
+		{mp.PersonNameOptions{PreserveCase: true}, " jack  CHRISTENSEN ", "jack CHRISTENSEN"},
+		{mp.PersonNameOptions{SpecialCase: unicode.TurkishCase}, "istanbul", "İstanbul"},
+		{mp.PersonNameOptions{SpecialCase: unicode.TurkishCase}, "IŞIK", "Işık"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.PersonNameWithOptions(tt.options).ConvertValue(tt.value)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+}
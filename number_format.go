@@ -0,0 +1,142 @@
+package mp
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NumberFormat describes the format of human entered numbers. It is used by Int64WithFormat, Float64WithFormat, and
+// DecimalWithFormat to normalize strings before parsing.
+type NumberFormat struct {
+	// ThousandsSeparator separates groups of three digits in the integer part of a number. If 0 then thousands
+	// separators are not allowed. When present, every group other than the first must have exactly three digits.
+	ThousandsSeparator rune
+
+	// DecimalSeparator separates the integer part of a number from the fractional part. If 0 then '.' is used.
+	DecimalSeparator rune
+
+	// AllowUnderscores allows underscores between digits (e.g. "1_000_000").
+	AllowUnderscores bool
+
+	// CurrencySymbols are stripped from the beginning or end of a number (e.g. "$", "€", "USD").
+	CurrencySymbols []string
+}
+
+var (
+	// NumberFormatEnglish accepts numbers such as "$1,234.56".
+	NumberFormatEnglish = NumberFormat{ThousandsSeparator: ',', DecimalSeparator: '.', CurrencySymbols: []string{"$", "£"}}
+
+	// NumberFormatEuropean accepts numbers such as "1.234,56 €".
+	NumberFormatEuropean = NumberFormat{ThousandsSeparator: '.', DecimalSeparator: ',', CurrencySymbols: []string{"€"}}
+)
+
+// normalize converts s to the format expected by strconv and decimal.
+func (nf *NumberFormat) normalize(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	for _, symbol := range nf.CurrencySymbols {
+		if strings.HasPrefix(s, symbol) {
+			s = strings.TrimSpace(s[len(symbol):])
+			break
+		}
+		if strings.HasSuffix(s, symbol) {
+			s = strings.TrimSpace(s[:len(s)-len(symbol)])
+			break
+		}
+		// A sign may precede the currency symbol (e.g. "-$5").
+		if len(s) > 0 && (s[0] == '-' || s[0] == '+') && strings.HasPrefix(s[1:], symbol) {
+			s = s[:1] + strings.TrimSpace(s[1+len(symbol):])
+			break
+		}
+	}
+
+	decimalSeparator := nf.DecimalSeparator
+	if decimalSeparator == 0 {
+		decimalSeparator = '.'
+	}
+
+	sb := &strings.Builder{}
+	sb.Grow(len(s))
+
+	var sign string
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		sign = s[:1]
+		s = s[1:]
+	}
+	sb.WriteString(sign)
+
+	integerPart, fractionalPart, hasFraction := cutRune(s, decimalSeparator)
+
+	if nf.ThousandsSeparator != 0 && strings.ContainsRune(integerPart, nf.ThousandsSeparator) {
+		groups := strings.Split(integerPart, string(nf.ThousandsSeparator))
+		for i, group := range groups {
+			digits := group
+			if nf.AllowUnderscores {
+				digits = strings.ReplaceAll(digits, "_", "")
+			}
+			if (i == 0 && (len(digits) == 0 || len(digits) > 3)) || (i > 0 && len(digits) != 3) {
+				return "", errNotAValidNumber
+			}
+		}
+		integerPart = strings.ReplaceAll(integerPart, string(nf.ThousandsSeparator), "")
+	}
+
+	if nf.AllowUnderscores {
+		var err error
+		integerPart, err = removeDigitUnderscores(integerPart)
+		if err != nil {
+			return "", err
+		}
+		fractionalPart, err = removeDigitUnderscores(fractionalPart)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	sb.WriteString(integerPart)
+	if hasFraction {
+		sb.WriteByte('.')
+		sb.WriteString(fractionalPart)
+	}
+
+	return sb.String(), nil
+}
+
+// cutRune is strings.Cut for a rune separator.
+func cutRune(s string, sep rune) (before, after string, found bool) {
+	if i := strings.IndexRune(s, sep); i >= 0 {
+		return s[:i], s[i+utf8.RuneLen(sep):], true
+	}
+	return s, "", false
+}
+
+// removeDigitUnderscores removes underscores that are between digits.
+func removeDigitUnderscores(s string) (string, error) {
+	if !strings.Contains(s, "_") {
+		return s, nil
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' {
+			if i == 0 || i == len(s)-1 || !unicode.IsDigit(rune(s[i-1])) || !unicode.IsDigit(rune(s[i+1])) {
+				return "", errNotAValidNumber
+			}
+		}
+	}
+
+	return strings.ReplaceAll(s, "_", ""), nil
+}
+
+// normalizeFormattedNumber applies nf to value if it is a string. Other values are returned unmodified.
+func normalizeFormattedNumber(value any, nf *NumberFormat) (any, error) {
+	if nf == nil {
+		return value, nil
+	}
+
+	if s, ok := value.(string); ok {
+		return nf.normalize(s)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,80 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInt64WithFormat(t *testing.T) {
+	tests := []struct {
+		format   mp.NumberFormat
+		value    any
+		expected any
+		success  bool
+	}{
+		{mp.NumberFormatEnglish, "1,234", int64(1234), true},
+		{mp.NumberFormatEnglish, "$1,234,567", int64(1234567), true},
+		{mp.NumberFormatEnglish, "-$5", int64(-5), true},
+		{mp.NumberFormatEnglish, "1234", int64(1234), true},
+		{mp.NumberFormatEnglish, "1,23", nil, false},
+		{mp.NumberFormatEnglish, "1234,567", nil, false},
+		{mp.NumberFormatEnglish, ",123", nil, false},
+		{mp.NumberFormatEuropean, "1.234 €", int64(1234), true},
+		{mp.NumberFormat{AllowUnderscores: true}, "1_000_000", int64(1000000), true},
+		{mp.NumberFormat{AllowUnderscores: true}, "_1000", nil, false},
+		{mp.NumberFormat{AllowUnderscores: true}, "1__000", nil, false},
+		{mp.NumberFormat{}, "1,234", nil, false},
+		{mp.NumberFormatEnglish, 42, int64(42), true},
+		{mp.NumberFormatEnglish, nil, nil, true},
+		{mp.NumberFormatEnglish, " ", nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.Int64WithFormat(tt.format).ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestFloat64WithFormat(t *testing.T) {
+	tests := []struct {
+		format   mp.NumberFormat
+		value    any
+		expected any
+		success  bool
+	}{
+		{mp.NumberFormatEnglish, "1,234.5", float64(1234.5), true},
+		{mp.NumberFormatEuropean, "1.234,5", float64(1234.5), true},
+		{mp.NumberFormatEuropean, "€ 0,25", float64(0.25), true},
+		{mp.NumberFormatEuropean, "1,234.5", nil, false},
+		{mp.NumberFormat{DecimalSeparator: ','}, "3,5", float64(3.5), true},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.Float64WithFormat(tt.format).ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestDecimalWithFormat(t *testing.T) {
+	tests := []struct {
+		format   mp.NumberFormat
+		value    any
+		expected any
+		success  bool
+	}{
+		{mp.NumberFormatEnglish, "$1,234.56", decimal.RequireFromString("1234.56"), true},
+		{mp.NumberFormatEuropean, "-1.234,56 €", decimal.RequireFromString("-1234.56"), true},
+		{mp.NumberFormatEnglish, "abc", nil, false},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.DecimalWithFormat(tt.format).ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
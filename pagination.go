@@ -0,0 +1,114 @@
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PaginationType returns a Type for pagination parameters. It has the following fields:
+//
+//   - page: the 1-based page number. Defaults to 1.
+//   - page_size: the number of items per page. It must be between 1 and maxPageSize. Defaults to maxPageSize.
+//   - cursor: an opaque cursor for cursor based pagination. It is nil when not given.
+func PaginationType(maxPageSize int32) *Type {
+	return NewType(
+		NewField("page", Int32(), Default(int32(1)), GreaterThanOrEqual(1)),
+		NewField("page_size", Int32(), Default(maxPageSize), GreaterThanOrEqual(1), LessThanOrEqual(maxPageSize)),
+		NewField("cursor", SingleLineString(), NilifyEmpty()),
+	)
+}
+
+// SortTerm is a single term of a sort order.
+type SortTerm struct {
+	Field      string
+	Descending bool
+}
+
+// String returns the term in the format accepted by Sort.
+func (st SortTerm) String() string {
+	if st.Descending {
+		return "-" + st.Field
+	}
+	return st.Field
+}
+
+// Sort returns a ValueConverter that converts value to a []SortTerm. value must be a string of comma separated field
+// names, or a slice of field names. A field name prefixed with "-" sorts in descending order. Only allowedFields may
+// be used and each field may only be used once. If value is nil or a blank string nil is returned.
+func Sort(allowedFields ...string) ValueConverter {
+	return &sortValueConverter{allowedFields: newStringSet(allowedFields)}
+}
+
+type sortValueConverter struct {
+	allowedFields stringSet
+}
+
+func (c *sortValueConverter) ConvertValue(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var names []string
+	switch value := value.(type) {
+	case string:
+		names = strings.Split(value, ",")
+	case []string:
+		names = value
+	case []any:
+		names = make([]string, len(value))
+		for i, v := range value {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("not a valid sort")
+			}
+			names[i] = s
+		}
+	case []SortTerm:
+		names = make([]string, len(value))
+		for i, st := range value {
+			names[i] = st.String()
+		}
+	default:
+		return nil, errors.New("not a valid sort")
+	}
+
+	terms := make([]SortTerm, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		var st SortTerm
+		if strings.HasPrefix(name, "-") {
+			st.Descending = true
+			name = name[1:]
+		} else {
+			name = strings.TrimPrefix(name, "+")
+		}
+		st.Field = name
+
+		if _, ok := c.allowedFields.set[name]; !ok {
+			return nil, newKindError(ErrNotAllowed, fmt.Sprintf("cannot sort by %q", name))
+		}
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("cannot sort by %q more than once", name)
+		}
+		seen[name] = struct{}{}
+
+		terms = append(terms, st)
+	}
+
+	return terms, nil
+}
+
+func (c *sortValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf([]SortTerm{})
+}
+
+func (c *sortValueConverter) ConverterParams() map[string]any {
+	fields := make([]string, len(c.allowedFields.list))
+	copy(fields, c.allowedFields.list)
+	return map[string]any{"sortFields": fields}
+}
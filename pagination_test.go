@@ -0,0 +1,57 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginationType(t *testing.T) {
+	pt := mp.PaginationType(100)
+
+	record := pt.Parse(map[string]any{})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"page": int32(1), "page_size": int32(100), "cursor": nil}, record.Attrs())
+
+	record = pt.Parse(map[string]any{"page": "3", "page_size": "25", "cursor": "abc"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"page": int32(3), "page_size": int32(25), "cursor": "abc"}, record.Attrs())
+
+	record = pt.Parse(map[string]any{"page": "0", "page_size": "101"})
+	errs := record.Errors().(mp.Errors)
+	assert.Contains(t, errs, "page")
+	assert.Contains(t, errs, "page_size")
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+		success  bool
+	}{
+		{"-created_at,name", []mp.SortTerm{{Field: "created_at", Descending: true}, {Field: "name"}}, true},
+		{" name , +id ", []mp.SortTerm{{Field: "name"}, {Field: "id"}}, true},
+		{[]any{"-id"}, []mp.SortTerm{{Field: "id", Descending: true}}, true},
+		{[]string{"name"}, []mp.SortTerm{{Field: "name"}}, true},
+		{[]mp.SortTerm{{Field: "id", Descending: true}}, []mp.SortTerm{{Field: "id", Descending: true}}, true},
+		{"password", nil, false},
+		{"name,-name", nil, false},
+		{"name,", nil, false},
+		{42, nil, false},
+		{nil, nil, true},
+		{"", nil, true},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.Sort("id", "name", "created_at").ConvertValue(tt.value)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+		assert.Equalf(t, tt.success, err == nil, "%d", i)
+	}
+}
+
+func TestSortTermString(t *testing.T) {
+	assert.Equal(t, "-id", mp.SortTerm{Field: "id", Descending: true}.String())
+	assert.Equal(t, "name", mp.SortTerm{Field: "name"}.String())
+}
@@ -0,0 +1,83 @@
+package mp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Pattern returns a field that matches every input key that matches pattern. pattern is matched against the whole
+// key. "*" matches any sequence of characters and every other character matches itself. For example, "custom_*"
+// matches "custom_color" and "header.*" matches "header.accept". The value of each matching key is converted with
+// converters and the results are available from Record.PatternValues.
+//
+// Pass the returned field to NewType like any other field. The name and aliases of other fields take precedence over
+// patterns. A key that matches more than one pattern belongs to the first of them. Keys that match a pattern are not
+// collected by Rest. Errors are recorded under the input key. Pattern fields are not included in Type.Fields or
+// Record.Attrs.
+func Pattern(pattern string, converters ...ValueConverter) *PatternField {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	re := regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+	return &PatternField{name: pattern, re: re, valueConverters: converters}
+}
+
+// RegexpPattern is like Pattern but matches input keys with re. re is not anchored unless it contains anchors. The
+// name of the field is re.String().
+func RegexpPattern(re *regexp.Regexp, converters ...ValueConverter) *PatternField {
+	return &PatternField{name: re.String(), re: re, valueConverters: converters}
+}
+
+// PatternField is a field created by Pattern or RegexpPattern.
+type PatternField struct {
+	name            string
+	re              *regexp.Regexp
+	valueConverters []ValueConverter
+}
+
+// Name returns the pattern of f.
+func (f *PatternField) Name() string {
+	return f.name
+}
+
+// ConvertValue converts value with the converters of f.
+func (f *PatternField) ConvertValue(value any) (any, error) {
+	return convertSlice(value, f.valueConverters)
+}
+
+// ValueConverters returns the converters of f.
+func (f *PatternField) ValueConverters() []ValueConverter {
+	return f.valueConverters
+}
+
+// MatchesKey returns true if key matches the pattern of f.
+func (f *PatternField) MatchesKey(key string) bool {
+	return f.re.MatchString(key)
+}
+
+// PatternValues returns the converted values of the input keys that matched the pattern field named pattern. Keys whose
+// value failed to convert are omitted. It is nil if the keys were never converted such as when the input was rejected
+// for exceeding TypeOptions.MaxInputSize. If pattern is not the name of a pattern field of the type then PatternValues
+// panics. The returned map must not be modified.
+func (r *Record) PatternValues(pattern string) map[string]any {
+	idx, ok := r.t.patternIndexes[pattern]
+	if !ok {
+		panic(fmt.Errorf("%q is not a pattern field of type", pattern))
+	}
+	if idx >= len(r.patternValues) {
+		return nil
+	}
+	return r.patternValues[idx]
+}
+
+// matchPattern returns the index of the first pattern field of t that matches key or -1 if there is none.
+func (t *Type) matchPattern(key string) int {
+	for i, f := range t.patterns {
+		if f.MatchesKey(key) {
+			return i
+		}
+	}
+	return -1
+}
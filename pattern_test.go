@@ -0,0 +1,102 @@
+package mp_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		key      string
+		expected bool
+	}{
+		{"custom_*", "custom_color", true},
+		{"custom_*", "custom_", true},
+		{"custom_*", "xcustom_color", false},
+		{"header.*", "header.accept", true},
+		{"header.*", "headerxaccept", false},
+		{"*_id", "user_id", true},
+		{"a*b*c", "a-b-c", true},
+		{"a*b*c", "a-b-cd", false},
+	}
+
+	for i, tt := range tests {
+		assert.Equalf(t, tt.expected, mp.Pattern(tt.pattern).MatchesKey(tt.key), "%d", i)
+	}
+}
+
+func TestPatternInType(t *testing.T) {
+	tp := mp.NewTypeWithOptions(mp.TypeOptions{TrackUnknownKeys: true},
+		mp.NewField("custom_id", mp.Int64()),
+		mp.Pattern("custom_*", mp.SingleLineString(), mp.MaxLen(5)),
+		mp.RegexpPattern(regexp.MustCompile(`^(custom|header)\.`), mp.Int64()),
+	)
+
+	assert.Len(t, tp.Fields(), 1)
+
+	record := tp.Parse(map[string]any{
+		"custom_id":     "7",
+		"custom_color":  " red ",
+		"header.length": "12",
+		"custom.count":  "3",
+		"other":         "x",
+	})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"custom_id": int64(7)}, record.Attrs())
+	assert.Equal(t, map[string]any{"custom_color": "red"}, record.PatternValues("custom_*"))
+	assert.Equal(t, map[string]any{"header.length": int64(12), "custom.count": int64(3)},
+		record.PatternValues(`^(custom|header)\.`))
+	assert.Equal(t, []string{"other"}, record.UnknownKeys())
+
+	record = tp.Parse(map[string]any{"custom_color": "burgundy", "header.length": "long"})
+	assert.Equal(t, map[string]string{"custom_color": "too long", "header.length": "not a valid number"},
+		errorMessages(record.Errors()))
+
+	assert.Panics(t, func() { record.PatternValues("nope_*") })
+	assert.PanicsWithError(t, `field "x_*" is defined more than once`, func() {
+		mp.NewType(mp.Pattern("x_*"), mp.Pattern("x_*"))
+	})
+}
+
+func TestPatternWithRest(t *testing.T) {
+	tp := mp.NewType(
+		mp.Pattern("custom_*", mp.SingleLineString()),
+		mp.Rest(mp.Int64()),
+	)
+
+	record := tp.Parse(map[string]any{"custom_a": "a", "b": "2"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"custom_a": "a"}, record.PatternValues("custom_*"))
+	assert.Equal(t, map[string]any{"b": int64(2)}, record.Rest())
+}
+
+func TestPatternWithFieldDefaults(t *testing.T) {
+	tp := mp.NewType(
+		mp.NewField("name"),
+		mp.Pattern("custom_*", mp.Int64()),
+		mp.Rest(mp.SingleLineString()),
+	).WithFieldDefaults(mp.SingleLineString())
+
+	record := tp.Parse(map[string]any{"name": " Jack ", "custom_a": "1", "b": " x "})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Jack", record.Get("name"))
+	assert.Equal(t, map[string]any{"custom_a": int64(1)}, record.PatternValues("custom_*"))
+	assert.Equal(t, map[string]any{"b": "x"}, record.Rest())
+}
+
+func TestPatternValuesOversizedInput(t *testing.T) {
+	ft := mp.NewTypeWithOptions(mp.TypeOptions{MaxInputSize: 16},
+		mp.Pattern("custom_*", mp.SingleLineString()),
+		mp.Rest(mp.SingleLineString()),
+	)
+
+	record := ft.Parse(map[string]any{"custom_color": "a very long value that exceeds the limit"})
+	require.Error(t, record.Errors())
+	assert.Nil(t, record.PatternValues("custom_*"))
+	assert.Nil(t, record.Rest())
+}
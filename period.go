@@ -0,0 +1,135 @@
+package mp
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Period is a range of time from Start to End inclusive.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of p.
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// Contains returns true if t is between p.Start and p.End inclusive.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && !t.After(p.End)
+}
+
+// String returns p as an ISO 8601 time interval.
+func (p Period) String() string {
+	return p.Start.Format(time.RFC3339) + "/" + p.End.Format(time.RFC3339)
+}
+
+var (
+	errNotAPeriod       = newKindError(ErrInvalidFormat, "not a valid period")
+	errPeriodStartAfter = newKindError(ErrOutOfRange, "start must not be after end")
+	errPeriodTooLong    = newKindError(ErrTooLong, "period is too long")
+)
+
+// DefaultPeriodFormats are the formats used by PeriodRange when PeriodRangeOptions.Formats is empty.
+var DefaultPeriodFormats = []string{"2006-01-02", time.RFC3339}
+
+// PeriodRangeOptions are options for PeriodRangeWithOptions.
+type PeriodRangeOptions struct {
+	// Formats are the formats used to parse the start and end. If empty DefaultPeriodFormats is used.
+	Formats []string
+
+	// MaxLength is the maximum duration of the period. If zero there is no maximum.
+	MaxLength time.Duration
+}
+
+// PeriodRange returns a ValueConverter that converts value to a Period with the default options. See
+// PeriodRangeWithOptions.
+func PeriodRange() ValueConverter {
+	return PeriodRangeWithOptions(PeriodRangeOptions{})
+}
+
+// PeriodRangeWithOptions returns a ValueConverter that converts value to a Period. value may be a map with "start" and
+// "end" keys or a string of the start and end separated by "/" such as "2024-01-01/2024-02-01". The start and end may
+// be strings in one of options.Formats or time.Time values. start must not be after end. If value is nil or a blank
+// string nil is returned.
+func PeriodRangeWithOptions(options PeriodRangeOptions) ValueConverter {
+	if len(options.Formats) == 0 {
+		options.Formats = DefaultPeriodFormats
+	}
+	return &periodValueConverter{options: options}
+}
+
+type periodValueConverter struct {
+	options PeriodRangeOptions
+}
+
+func (c *periodValueConverter) ConvertValue(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var start, end any
+	switch value := value.(type) {
+	case Period:
+		start, end = value.Start, value.End
+	case string:
+		var ok bool
+		start, end, ok = strings.Cut(value, "/")
+		if !ok {
+			return nil, errNotAPeriod
+		}
+	case map[string]any:
+		start, end = value["start"], value["end"]
+	default:
+		return nil, errNotAPeriod
+	}
+
+	var p Period
+	var ok1, ok2 bool
+	p.Start, ok1 = c.parseTime(start)
+	p.End, ok2 = c.parseTime(end)
+	if !ok1 || !ok2 {
+		return nil, errNotAPeriod
+	}
+
+	if p.Start.After(p.End) {
+		return nil, errPeriodStartAfter
+	}
+	if c.options.MaxLength > 0 && p.Duration() > c.options.MaxLength {
+		return nil, errPeriodTooLong
+	}
+
+	return p, nil
+}
+
+func (c *periodValueConverter) parseTime(value any) (time.Time, bool) {
+	switch value := value.(type) {
+	case time.Time:
+		return value, true
+	case string:
+		value = strings.TrimSpace(value)
+		for _, format := range c.options.Formats {
+			t, err := time.Parse(format, value)
+			if err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (c *periodValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf(Period{})
+}
+
+func (c *periodValueConverter) ConverterParams() map[string]any {
+	if c.options.MaxLength == 0 {
+		return nil
+	}
+	return map[string]any{"maxPeriod": c.options.MaxLength.String()}
+}
@@ -0,0 +1,74 @@
+package mp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriodRange(t *testing.T) {
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb1 := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	jun1 := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    any
+		expected any
+		errMsg   string
+	}{
+		{"2024-01-01/2024-02-01", mp.Period{Start: jan1, End: feb1}, ""},
+		{" 2024-01-01 / 2024-01-01 ", mp.Period{Start: jan1, End: jan1}, ""},
+		{"2024-01-01T00:00:00Z/2024-02-01", mp.Period{Start: jan1, End: feb1}, ""},
+		{map[string]any{"start": "2024-01-01", "end": "2024-02-01"}, mp.Period{Start: jan1, End: feb1}, ""},
+		{map[string]any{"start": jan1, "end": feb1}, mp.Period{Start: jan1, End: feb1}, ""},
+		{mp.Period{Start: jan1, End: feb1}, mp.Period{Start: jan1, End: feb1}, ""},
+		{"2024-01-01/2024-06-01", nil, "period is too long"},
+		{"2024-02-01/2024-01-01", nil, "start must not be after end"},
+		{"2024-01-01", nil, "not a valid period"},
+		{"2024-01-01/tomorrow", nil, "not a valid period"},
+		{map[string]any{"start": "2024-01-01"}, nil, "not a valid period"},
+		{42, nil, "not a valid period"},
+		{"", nil, ""},
+		{nil, nil, ""},
+	}
+
+	vc := mp.PeriodRangeWithOptions(mp.PeriodRangeOptions{MaxLength: 90 * 24 * time.Hour})
+	for i, tt := range tests {
+		value, err := vc.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+
+	_, err := vc.ConvertValue("2024-01-01/2024-06-01")
+	assert.ErrorIs(t, err, mp.ErrTooLong)
+
+	value, err := mp.PeriodRange().ConvertValue("2024-01-01/2024-06-01")
+	require.NoError(t, err)
+	p := value.(mp.Period)
+	assert.Equal(t, mp.Period{Start: jan1, End: jun1}, p)
+	assert.Equal(t, "2024-01-01T00:00:00Z/2024-06-01T00:00:00Z", p.String())
+	assert.True(t, p.Contains(feb1))
+	assert.True(t, p.Contains(jun1))
+	assert.False(t, p.Contains(jun1.Add(time.Second)))
+	assert.Equal(t, jun1.Sub(jan1), p.Duration())
+}
+
+func TestPeriodRangeInType(t *testing.T) {
+	tp := mp.NewType(
+		mp.NewField("range", mp.PeriodRangeWithOptions(mp.PeriodRangeOptions{Formats: []string{"2006-01-02"}}), mp.Require()),
+	)
+
+	record := tp.Parse(map[string]any{"range": map[string]any{"start": "2024-01-01", "end": "2024-01-31"}})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, 30*24*time.Hour, record.Get("range").(mp.Period).Duration())
+
+	record = tp.Parse(map[string]any{"range": "2024-01-31/2024-01-01"})
+	assert.EqualError(t, record.Errors(), "range start must not be after end")
+}
@@ -0,0 +1,75 @@
+package mp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// PresetConverter is a named group of converters. It allows a shared validation vocabulary such as "username" or
+// "email" to be defined once and used across many Types. The converters are visible to introspection such as
+// FieldIsRequired and FieldConverterParams as if they were listed directly on the field.
+type PresetConverter struct {
+	name string
+	all  allValueConverter
+}
+
+// Preset returns a PresetConverter named name that applies converters in order.
+func Preset(name string, converters ...ValueConverter) *PresetConverter {
+	return &PresetConverter{name: name, all: allValueConverter{valueConverters: converters}}
+}
+
+// Name returns the name of the preset.
+func (p *PresetConverter) Name() string {
+	return p.name
+}
+
+// ConvertValue implements the ValueConverter interface.
+func (p *PresetConverter) ConvertValue(value any) (any, error) {
+	return p.all.ConvertValue(value)
+}
+
+// ConvertValueContext implements the ContextValueConverter interface.
+func (p *PresetConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	return p.all.ConvertValueContext(ctx, value)
+}
+
+// ConvertedType implements the ConvertedTyper interface.
+func (p *PresetConverter) ConvertedType() reflect.Type {
+	return p.all.ConvertedType()
+}
+
+func (p *PresetConverter) converters() []ValueConverter {
+	return p.all.valueConverters
+}
+
+func (p *PresetConverter) convertWithDependencies(ctx context.Context, value any, deps map[string]any) (any, error) {
+	return p.all.convertWithDependencies(ctx, value, deps)
+}
+
+// FieldPresets returns the names of the presets used by f.
+func FieldPresets(f Field) []string {
+	return appendPresetNames(nil, fieldValueConverters(f))
+}
+
+func appendPresetNames(dst []string, converters []ValueConverter) []string {
+	for _, vc := range converters {
+		if p, ok := vc.(*PresetConverter); ok {
+			dst = append(dst, p.name)
+		}
+		if g, ok := vc.(converterGroup); ok {
+			dst = appendPresetNames(dst, g.converters())
+		}
+	}
+	return dst
+}
+
+// RegisterPreset registers p under its name so it can be referenced from a schema definition.
+func (r *Registry) RegisterPreset(p *PresetConverter) {
+	r.Register(p.name, func(args ...any) (ValueConverter, error) {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("expected 0 arguments, got %d", len(args))
+		}
+		return p, nil
+	})
+}
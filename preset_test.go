@@ -0,0 +1,50 @@
+package mp_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var username = mp.Preset("username",
+	mp.SingleLineString(), mp.Require(), mp.MinLen(3), mp.MaxLen(32), mp.Matches(regexp.MustCompile(`^[a-z0-9_]+$`)),
+)
+
+func TestPreset(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("username", username),
+		mp.NewField("referrer", mp.IfNotNil(username)),
+	)
+
+	record := ft.Parse(map[string]any{"username": " jack_c "})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "jack_c", record.Get("username"))
+
+	record = ft.Parse(map[string]any{"username": "Jack C"})
+	assert.EqualError(t, record.Errors(), "username invalid format")
+
+	assert.Equal(t, "username", username.Name())
+	assert.Equal(t, []string{"username"}, mp.FieldPresets(ft.Fields()[0]))
+	assert.True(t, mp.FieldIsRequired(ft.Fields()[0]))
+	assert.Equal(t,
+		map[string]any{"minLen": 3, "maxLen": 32, "pattern": `^[a-z0-9_]+$`},
+		mp.FieldConverterParams(ft.Fields()[0]),
+	)
+}
+
+func TestRegistryRegisterPreset(t *testing.T) {
+	r := mp.NewRegistry(true)
+	r.RegisterPreset(username)
+
+	td := mp.TypeDefinition{Fields: []mp.FieldDefinition{
+		{Name: "username", Converters: []mp.ConverterDefinition{{Name: "username"}}},
+	}}
+	ft, err := td.BuildWithRegistry(r)
+	require.NoError(t, err)
+
+	record := ft.Parse(map[string]any{"username": "ab"})
+	assert.EqualError(t, record.Errors(), "username too short")
+}
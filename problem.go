@@ -0,0 +1,181 @@
+package mp
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldError is the error of a single field. Path is the path to the field through nested Types and slices, e.g.
+// ["addresses", "0", "city"].
+type FieldError struct {
+	Path []string
+	Err  error
+}
+
+// Pointer returns the path as a JSON Pointer (RFC 6901), e.g. "/addresses/0/city".
+func (fe FieldError) Pointer() string {
+	return jsonPointer(fe.Path)
+}
+
+// jsonPointer returns path as a JSON Pointer (RFC 6901).
+func jsonPointer(path []string) string {
+	sb := &strings.Builder{}
+	for _, p := range path {
+		sb.WriteByte('/')
+		p = strings.ReplaceAll(p, "~", "~0")
+		p = strings.ReplaceAll(p, "/", "~1")
+		sb.WriteString(p)
+	}
+	return sb.String()
+}
+
+// Code returns a machine readable code for the kind of the error such as "required" or "too_long". It is based on the
+// sentinel errors such as ErrRequired. If the error does not match a sentinel error then "invalid" is returned.
+func (fe FieldError) Code() string {
+	return errorCode(fe.Err)
+}
+
+// errorCode returns the code of err such as "required" or "invalid".
+func errorCode(err error) string {
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind) {
+			return strings.ReplaceAll(kind.Error(), " ", "_")
+		}
+	}
+	return "invalid"
+}
+
+var errorKinds = []error{
+	ErrRequired,
+	ErrNotANumber,
+	ErrOutOfRange,
+	ErrTooShort,
+	ErrTooLong,
+	ErrTooSmall,
+	ErrTooLarge,
+	ErrNotAllowed,
+	ErrInvalidFormat,
+}
+
+// FlattenErrors returns the individual field errors in err sorted by path. Errors of nested Types and slice elements
+// are expanded into their own FieldErrors. If err is not an Errors then it is returned as a single FieldError with an
+// empty path.
+func FlattenErrors(err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+
+	fieldErrors := appendFieldErrors(nil, nil, err)
+	sort.SliceStable(fieldErrors, func(i, j int) bool {
+		return fieldErrors[i].Pointer() < fieldErrors[j].Pointer()
+	})
+	return fieldErrors
+}
+
+func appendFieldErrors(dst []FieldError, path []string, err error) []FieldError {
+	switch err := err.(type) {
+	case Errors:
+		for name, fieldErr := range err {
+			var fieldPath []string
+			if name != BaseErrorKey || len(path) > 0 {
+				fieldPath = appendPath(path, name)
+			} else {
+				fieldPath = path
+			}
+			dst = appendFieldErrors(dst, fieldPath, fieldErr)
+		}
+		return dst
+	case sliceElementErrors:
+		for _, ee := range err {
+			dst = appendFieldErrors(dst, appendPath(path, strconv.Itoa(ee.Index)), ee.Err)
+		}
+		return dst
+	}
+
+	return append(dst, FieldError{Path: path, Err: err})
+}
+
+func appendPath(path []string, name string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, name)
+}
+
+// ProblemDetails is a problem details document as defined by RFC 7807. It should be served with the
+// application/problem+json content type.
+type ProblemDetails struct {
+	Type   string `json:"type,omitempty"`
+	Title  string `json:"title"`
+	Status int    `json:"status,omitempty"`
+	Detail string `json:"detail,omitempty"`
+
+	// InvalidParams is the "invalid-params" extension member described in RFC 7807.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam is an entry of ProblemDetails.InvalidParams.
+type InvalidParam struct {
+	// Name is the JSON Pointer to the invalid field.
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	Code   string `json:"code"`
+}
+
+// NewProblemDetails returns a ProblemDetails for err with status. The title is the text of status.
+func NewProblemDetails(status int, err error) *ProblemDetails {
+	pd := &ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	for _, fe := range FlattenErrors(err) {
+		pd.InvalidParams = append(pd.InvalidParams, InvalidParam{Name: fe.Pointer(), Reason: fe.Err.Error(), Code: fe.Code()})
+	}
+
+	return pd
+}
+
+// JSONAPIError is an error object as defined by JSON:API.
+type JSONAPIError struct {
+	Status string              `json:"status,omitempty"`
+	Code   string              `json:"code,omitempty"`
+	Title  string              `json:"title,omitempty"`
+	Detail string              `json:"detail,omitempty"`
+	Source *JSONAPIErrorSource `json:"source,omitempty"`
+}
+
+// JSONAPIErrorSource is the source member of a JSONAPIError.
+type JSONAPIErrorSource struct {
+	Pointer string `json:"pointer,omitempty"`
+}
+
+// JSONAPIErrorsDocument is a JSON:API top-level document containing errors.
+type JSONAPIErrorsDocument struct {
+	Errors []JSONAPIError `json:"errors"`
+}
+
+// NewJSONAPIErrors returns a JSON:API errors document for err with status. Source pointers are prefixed with
+// pointerPrefix. For a resource document the prefix is typically "/data/attributes".
+func NewJSONAPIErrors(status int, err error, pointerPrefix string) *JSONAPIErrorsDocument {
+	doc := &JSONAPIErrorsDocument{Errors: []JSONAPIError{}}
+	statusText := strconv.Itoa(status)
+
+	for _, fe := range FlattenErrors(err) {
+		je := JSONAPIError{
+			Status: statusText,
+			Code:   fe.Code(),
+			Title:  http.StatusText(status),
+			Detail: fe.Err.Error(),
+		}
+		if len(fe.Path) > 0 {
+			je.Source = &JSONAPIErrorSource{Pointer: pointerPrefix + fe.Pointer()}
+		}
+		doc.Errors = append(doc.Errors, je)
+	}
+
+	return doc
+}
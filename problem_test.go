@@ -0,0 +1,79 @@
+package mp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseInvalidPerson(t *testing.T) error {
+	addressType := mp.NewType(
+		mp.NewField("city", mp.SingleLineString(), mp.Require()),
+	)
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int64()),
+		mp.NewField("addresses", mp.Slice[*mp.Record](addressType)),
+	)
+
+	err := ft.Parse(map[string]any{
+		"age":       "abc",
+		"addresses": []any{map[string]any{"city": "Dallas"}, map[string]any{}},
+	}).Errors()
+	require.Error(t, err)
+	return err
+}
+
+func TestFlattenErrors(t *testing.T) {
+	fieldErrors := mp.FlattenErrors(parseInvalidPerson(t))
+
+	pointers := make([]string, len(fieldErrors))
+	codes := make([]string, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		pointers[i] = fe.Pointer()
+		codes[i] = fe.Code()
+	}
+	assert.Equal(t, []string{"/addresses/1/city", "/age", "/name"}, pointers)
+	assert.Equal(t, []string{"required", "not_a_number", "required"}, codes)
+
+	fieldErrors = mp.FlattenErrors(errors.New("boom"))
+	require.Len(t, fieldErrors, 1)
+	assert.Equal(t, "", fieldErrors[0].Pointer())
+	assert.Equal(t, "invalid", fieldErrors[0].Code())
+
+	assert.Nil(t, mp.FlattenErrors(nil))
+}
+
+func TestNewProblemDetails(t *testing.T) {
+	pd := mp.NewProblemDetails(http.StatusUnprocessableEntity, parseInvalidPerson(t))
+
+	buf, err := json.Marshal(pd)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"invalid-params": [
+			{"name": "/addresses/1/city", "reason": "cannot be nil or empty", "code": "required"},
+			{"name": "/age", "reason": "not a valid number", "code": "not_a_number"},
+			{"name": "/name", "reason": "cannot be nil or empty", "code": "required"}
+		]
+	}`, string(buf))
+}
+
+func TestNewJSONAPIErrors(t *testing.T) {
+	doc := mp.NewJSONAPIErrors(http.StatusUnprocessableEntity, parseInvalidPerson(t), "/data/attributes")
+
+	buf, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"errors": [
+		{"status": "422", "code": "required", "title": "Unprocessable Entity", "detail": "cannot be nil or empty", "source": {"pointer": "/data/attributes/addresses/1/city"}},
+		{"status": "422", "code": "not_a_number", "title": "Unprocessable Entity", "detail": "not a valid number", "source": {"pointer": "/data/attributes/age"}},
+		{"status": "422", "code": "required", "title": "Unprocessable Entity", "detail": "cannot be nil or empty", "source": {"pointer": "/data/attributes/name"}}
+	]}`, string(buf))
+}
@@ -0,0 +1,109 @@
+package mp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DefaultRefMaxDepth is the MaxDepth used by Ref.
+const DefaultRefMaxDepth = 32
+
+// RefOptions configures a converter created by RefWithOptions.
+type RefOptions struct {
+	// MaxDepth is the maximum nesting depth of input maps. Input nested deeper fails before it is parsed to prevent stack
+	// exhaustion from hostile input. If MaxDepth is 0 then DefaultRefMaxDepth is used.
+	MaxDepth int
+}
+
+// Ref returns a ValueConverter that converts value with the Type returned by resolve. resolve is called once on first
+// use. This allows a Type to reference itself, directly or through a slice, which is not possible with a *Type value
+// because it does not exist until NewType returns. e.g.
+//
+//	var commentType *mp.Type
+//	commentType = mp.NewType(
+//		mp.NewField("body", mp.MultiLineString(), mp.Require()),
+//		mp.NewField("replies", mp.Slice[*mp.Record](mp.Ref(func() *mp.Type { return commentType }))),
+//	)
+//
+// Input nested deeper than DefaultRefMaxDepth fails. Use RefWithOptions to configure the maximum depth.
+func Ref(resolve func() *Type) ValueConverter {
+	return RefWithOptions(resolve, RefOptions{})
+}
+
+// RefWithOptions is like Ref but with options.
+func RefWithOptions(resolve func() *Type, options RefOptions) ValueConverter {
+	if options.MaxDepth == 0 {
+		options.MaxDepth = DefaultRefMaxDepth
+	}
+	return &refValueConverter{resolve: resolve, options: options}
+}
+
+type refValueConverter struct {
+	resolve func() *Type
+	options RefOptions
+
+	once sync.Once
+	t    *Type
+}
+
+func (c *refValueConverter) resolvedType() *Type {
+	c.once.Do(func() {
+		c.t = c.resolve()
+		if c.t == nil {
+			panic(errors.New("Ref resolved to a nil Type"))
+		}
+	})
+	return c.t
+}
+
+func (c *refValueConverter) ConvertValue(value any) (any, error) {
+	if inputDepthExceeds(value, c.options.MaxDepth) {
+		return nil, fmt.Errorf("exceeds maximum depth of %d", c.options.MaxDepth)
+	}
+
+	return c.resolvedType().ConvertValue(value)
+}
+
+// ConvertValueContext implements the ContextValueConverter interface. It parses value with ParseCtx of the referenced
+// Type.
+func (c *refValueConverter) ConvertValueContext(ctx context.Context, value any) (any, error) {
+	if inputDepthExceeds(value, c.options.MaxDepth) {
+		return nil, fmt.Errorf("exceeds maximum depth of %d", c.options.MaxDepth)
+	}
+
+	return c.resolvedType().ConvertValueContext(ctx, value)
+}
+
+func (c *refValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf((*Record)(nil))
+}
+
+func (c *refValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"maxDepth": c.options.MaxDepth}
+}
+
+// inputDepthExceeds returns true if value contains maps nested more than limit deep. value itself counts as one level
+// if it is a map. Slices do not count as a level.
+func inputDepthExceeds(value any, limit int) bool {
+	switch value := value.(type) {
+	case map[string]any:
+		if limit == 0 {
+			return true
+		}
+		for _, v := range value {
+			if inputDepthExceeds(v, limit-1) {
+				return true
+			}
+		}
+	case []any:
+		for _, v := range value {
+			if inputDepthExceeds(v, limit) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRef(t *testing.T) {
+	var commentType *mp.Type
+	commentType = mp.NewType(
+		mp.NewField("body", mp.MultiLineString(), mp.Require()),
+		mp.NewField("parent", mp.Ref(func() *mp.Type { return commentType })),
+		mp.NewField("replies", mp.Slice[*mp.Record](mp.Ref(func() *mp.Type { return commentType }))),
+	)
+
+	record := commentType.Parse(map[string]any{
+		"body": "first",
+		"replies": []any{
+			map[string]any{"body": "second", "replies": []any{map[string]any{"body": "third"}}},
+		},
+	})
+	require.NoError(t, record.Errors())
+
+	replies := record.Get("replies").([]*mp.Record)
+	require.Len(t, replies, 1)
+	assert.Equal(t, "second", replies[0].Get("body"))
+	nested := replies[0].Get("replies").([]*mp.Record)
+	require.Len(t, nested, 1)
+	assert.Equal(t, "third", nested[0].Get("body"))
+
+	record = commentType.Parse(map[string]any{"body": "first", "parent": map[string]any{}})
+	assert.EqualError(t, record.Errors(), "parent body cannot be nil or empty")
+
+	assert.NoError(t, commentType.Check(map[string]any{
+		"body":    "first",
+		"replies": []any{map[string]any{"body": "second"}},
+	}))
+	assert.Error(t, commentType.Check(map[string]any{
+		"body":    "first",
+		"replies": []any{map[string]any{"body": 2}},
+	}))
+}
+
+func TestRefMaxDepth(t *testing.T) {
+	var categoryType *mp.Type
+	categoryType = mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("parent", mp.RefWithOptions(func() *mp.Type { return categoryType }, mp.RefOptions{MaxDepth: 3})),
+	)
+
+	nest := func(depth int) map[string]any {
+		m := map[string]any{"name": "leaf"}
+		for i := 0; i < depth; i++ {
+			m = map[string]any{"name": "node", "parent": m}
+		}
+		return m
+	}
+
+	assert.NoError(t, categoryType.Parse(nest(3)).Errors())
+
+	err := categoryType.Parse(nest(4)).Errors()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds maximum depth of 3")
+}
+
+func TestRefParams(t *testing.T) {
+	var ft *mp.Type
+	ft = mp.NewType(mp.NewField("self", mp.Ref(func() *mp.Type { return ft })))
+	assert.Equal(t, map[string]any{"maxDepth": mp.DefaultRefMaxDepth}, mp.FieldConverterParams(ft.Fields()[0]))
+}
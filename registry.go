@@ -0,0 +1,289 @@
+package mp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ConverterConstructor builds a ValueConverter from the arguments given in a schema definition. Arguments decoded
+// from JSON or YAML are untyped so constructors should convert them as needed.
+type ConverterConstructor func(args ...any) (ValueConverter, error)
+
+// Registry maps names to ConverterConstructors. It is safe for concurrent use.
+type Registry struct {
+	mux          sync.RWMutex
+	constructors map[string]ConverterConstructor
+}
+
+// NewRegistry returns a new Registry. If includeBuiltins is true then the built-in converters are registered.
+func NewRegistry(includeBuiltins bool) *Registry {
+	r := &Registry{constructors: make(map[string]ConverterConstructor)}
+	if includeBuiltins {
+		registerBuiltinConverters(r)
+	}
+	return r
+}
+
+// DefaultRegistry is the Registry used by RegisterConverter, LookupConverter, and the schema loaders. The built-in
+// converters are pre-registered.
+var DefaultRegistry = NewRegistry(true)
+
+// Register registers constructor under name. Registering a name that is already registered replaces the previous
+// constructor.
+func (r *Registry) Register(name string, constructor ConverterConstructor) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.constructors[name] = constructor
+}
+
+// Lookup returns the constructor registered under name.
+func (r *Registry) Lookup(name string) (ConverterConstructor, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	constructor, ok := r.constructors[name]
+	return constructor, ok
+}
+
+// Names returns the registered names in sorted order.
+func (r *Registry) Names() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	names := make([]string, 0, len(r.constructors))
+	for name := range r.constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds a ValueConverter with the constructor registered under name.
+func (r *Registry) New(name string, args ...any) (ValueConverter, error) {
+	constructor, ok := r.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown converter %q", name)
+	}
+
+	return constructor(args...)
+}
+
+// RegisterConverter registers constructor under name in DefaultRegistry so it can be referenced from a schema
+// definition.
+func RegisterConverter(name string, constructor ConverterConstructor) {
+	DefaultRegistry.Register(name, constructor)
+}
+
+// LookupConverter returns the constructor registered under name in DefaultRegistry.
+func LookupConverter(name string) (ConverterConstructor, bool) {
+	return DefaultRegistry.Lookup(name)
+}
+
+func registerBuiltinConverters(r *Registry) {
+	noArgs := func(f func() ValueConverter) ConverterConstructor {
+		return func(args ...any) (ValueConverter, error) {
+			if len(args) != 0 {
+				return nil, fmt.Errorf("expected 0 arguments, got %d", len(args))
+			}
+			return f(), nil
+		}
+	}
+
+	r.Register("int64", noArgs(Int64))
+	r.Register("int32", noArgs(Int32))
+	r.Register("float64", noArgs(Float64))
+	r.Register("float32", noArgs(Float32))
+	r.Register("bool", noArgs(Bool))
+	r.Register("boolPtr", noArgs(BoolPtr))
+	r.Register("uuid", noArgs(UUID))
+	r.Register("latLng", noArgs(LatLng))
+	r.Register("periodRange", noArgs(PeriodRange))
+	r.Register("decimal", noArgs(Decimal))
+	r.Register("string", noArgs(String))
+	r.Register("singleLineString", noArgs(SingleLineString))
+	r.Register("multiLineString", noArgs(MultiLineString))
+	r.Register("personName", noArgs(PersonName))
+	r.Register("safeFilename", noArgs(SafeFilename))
+	r.Register("normalizeNewlines", noArgs(NormalizeNewlines))
+	r.Register("noEmoji", noArgs(NoEmoji))
+	r.Register("stripEmoji", noArgs(StripEmoji))
+	r.Register("cronExpr", noArgs(CronExpr))
+	r.Register("rrule", noArgs(RRule))
+	r.Register("notNil", noArgs(NotNil))
+	r.Register("require", noArgs(Require))
+	r.Register("nilifyEmpty", noArgs(NilifyEmpty))
+	r.Register("localeDate", noArgs(LocaleDate))
+	r.Register("localeInt64", noArgs(LocaleInt64))
+	r.Register("localeFloat64", noArgs(LocaleFloat64))
+	r.Register("localeDecimal", noArgs(LocaleDecimal))
+	r.Register("localeCountry", noArgs(LocaleCountry))
+	r.Register("domainExists", func(args ...any) (ValueConverter, error) {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("expected 0 arguments, got %d", len(args))
+		}
+		return DomainExists(nil), nil
+	})
+	r.Register("emailDeliverable", func(args ...any) (ValueConverter, error) {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("expected 0 arguments, got %d", len(args))
+		}
+		return EmailDeliverable(nil), nil
+	})
+	r.Register("notDisposableEmail", func(args ...any) (ValueConverter, error) {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("expected 0 arguments, got %d", len(args))
+		}
+		return NotDisposableEmail(nil), nil
+	})
+	r.Register("notInFuture", noArgs(NotInFuture))
+	r.Register("drop", noArgs(Drop))
+	r.Register("forbidden", noArgs(Forbidden))
+
+	r.Register("default", func(args ...any) (ValueConverter, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+		return Default(args[0]), nil
+	})
+
+	r.Register("equals", func(args ...any) (ValueConverter, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+		return Equals(args[0]), nil
+	})
+
+	r.Register("const", func(args ...any) (ValueConverter, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+		return Const(args[0]), nil
+	})
+
+	r.Register("time", func(args ...any) (ValueConverter, error) {
+		formats, err := stringArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return Time(formats...), nil
+	})
+
+	intArg := func(f func(int) ValueConverter) ConverterConstructor {
+		return func(args ...any) (ValueConverter, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+			}
+			n, err := convertInt64(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return f(int(n)), nil
+		}
+	}
+	r.Register("minLen", intArg(MinLen))
+	r.Register("maxLen", intArg(MaxLen))
+	r.Register("bytes", intArg(Bytes))
+	r.Register("minWords", intArg(MinWords))
+	r.Register("maxWords", intArg(MaxWords))
+	r.Register("maxLines", intArg(MaxLines))
+	r.Register("maxEmoji", intArg(MaxEmoji))
+
+	stringsArg := func(f func(...string) ValueConverter) ConverterConstructor {
+		return func(args ...any) (ValueConverter, error) {
+			items, err := stringArgs(args)
+			if err != nil {
+				return nil, err
+			}
+			return f(items...), nil
+		}
+	}
+	r.Register("allowStrings", stringsArg(AllowStrings))
+	r.Register("excludeStrings", stringsArg(ExcludeStrings))
+	r.Register("sort", stringsArg(Sort))
+	r.Register("denyWords", stringsArg(func(words ...string) ValueConverter { return DenyWords(words, DenyReject) }))
+
+	r.Register("birthdate", func(args ...any) (ValueConverter, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expected 2 arguments, got %d", len(args))
+		}
+		minAge, err := convertInt64(args[0])
+		if err != nil {
+			return nil, err
+		}
+		maxAge, err := convertInt64(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return Birthdate(int(minAge), int(maxAge), nil), nil
+	})
+
+	r.Register("olderThan", func(args ...any) (ValueConverter, error) {
+		strs, err := stringArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		if len(strs) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(strs))
+		}
+		d, err := time.ParseDuration(strs[0])
+		if err != nil {
+			return nil, err
+		}
+		return OlderThan(d), nil
+	})
+
+	r.Register("cleanRelPath", func(args ...any) (ValueConverter, error) {
+		strs, err := stringArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		if len(strs) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(strs))
+		}
+		return CleanRelPath(strs[0]), nil
+	})
+
+	r.Register("matches", func(args ...any) (ValueConverter, error) {
+		strs, err := stringArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		if len(strs) != 1 {
+			return nil, fmt.Errorf("expected 1 argument, got %d", len(strs))
+		}
+		re, err := regexp.Compile(strs[0])
+		if err != nil {
+			return nil, err
+		}
+		return Matches(re), nil
+	})
+
+	decimalArg := func(f func(any) ValueConverter) ConverterConstructor {
+		return func(args ...any) (ValueConverter, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+			}
+			if _, ok := tryDecimal(args[0]); !ok {
+				return nil, fmt.Errorf("%v is not convertable to a decimal number", args[0])
+			}
+			return f(args[0]), nil
+		}
+	}
+	r.Register("lessThan", decimalArg(LessThan))
+	r.Register("lessThanOrEqual", decimalArg(LessThanOrEqual))
+	r.Register("greaterThan", decimalArg(GreaterThan))
+	r.Register("greaterThanOrEqual", decimalArg(GreaterThanOrEqual))
+}
+
+func stringArgs(args []any) ([]string, error) {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument %d is not a string", i)
+		}
+		strs[i] = s
+	}
+	return strs, nil
+}
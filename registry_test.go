@@ -0,0 +1,87 @@
+package mp_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterConverter(t *testing.T) {
+	mp.RegisterConverter("testOdd", func(args ...any) (mp.ValueConverter, error) {
+		return mp.ValueConverterFunc(func(value any) (any, error) {
+			if value.(int64)%2 == 0 {
+				return nil, errors.New("not odd")
+			}
+			return value, nil
+		}), nil
+	})
+
+	ft, err := mp.LoadTypeJSON([]byte(`{"fields": [{"name": "n", "converters": ["int64", "testOdd"]}]}`))
+	require.NoError(t, err)
+
+	require.NoError(t, ft.Parse(map[string]any{"n": 3}).Errors())
+	require.Error(t, ft.Parse(map[string]any{"n": 4}).Errors())
+}
+
+func TestRegistry(t *testing.T) {
+	r := mp.NewRegistry(false)
+	assert.Empty(t, r.Names())
+
+	r.Register("upper", func(args ...any) (mp.ValueConverter, error) { return mp.String(), nil })
+	r.Register("lower", func(args ...any) (mp.ValueConverter, error) { return mp.String(), nil })
+	assert.Equal(t, []string{"lower", "upper"}, r.Names())
+
+	_, ok := r.Lookup("upper")
+	assert.True(t, ok)
+	_, ok = r.Lookup("int64")
+	assert.False(t, ok)
+
+	_, err := r.New("int64")
+	require.ErrorContains(t, err, `unknown converter "int64"`)
+
+	td := mp.TypeDefinition{Fields: []mp.FieldDefinition{{Name: "n", Converters: []mp.ConverterDefinition{{Name: "int64"}}}}}
+	_, err = td.BuildWithRegistry(r)
+	require.Error(t, err)
+}
+
+func TestDefaultRegistryBuiltins(t *testing.T) {
+	names := mp.DefaultRegistry.Names()
+	assert.Contains(t, names, "int64")
+	assert.Contains(t, names, "minLen")
+	assert.Contains(t, names, "greaterThanOrEqual")
+
+	vc, err := mp.DefaultRegistry.New("maxLen", 3)
+	require.NoError(t, err)
+	_, err = vc.ConvertValue("abcd")
+	require.Error(t, err)
+
+	constructor, ok := mp.LookupConverter("int32")
+	require.True(t, ok)
+	vc, err = constructor()
+	require.NoError(t, err)
+	value, err := vc.ConvertValue("42")
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), value)
+
+	vc, err = mp.DefaultRegistry.New("equals", 2)
+	require.NoError(t, err)
+	_, err = vc.ConvertValue(int64(2))
+	require.NoError(t, err)
+
+	vc, err = mp.DefaultRegistry.New("const", "user")
+	require.NoError(t, err)
+	value, err = vc.ConvertValue("admin")
+	require.NoError(t, err)
+	assert.Equal(t, "user", value)
+
+	vc, err = mp.DefaultRegistry.New("matches", `^\d+$`)
+	require.NoError(t, err)
+	_, err = vc.ConvertValue("abc")
+	require.Error(t, err)
+
+	_, err = mp.DefaultRegistry.New("matches", "(")
+	require.Error(t, err)
+}
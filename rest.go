@@ -0,0 +1,98 @@
+package mp
+
+import (
+	"context"
+	"sort"
+)
+
+// RestFieldName is the name of a RestField. It is not an input key.
+const RestFieldName = "*"
+
+// Rest returns a catch-all field that collects every input key that is not the name or alias of another field of the
+// Type. The value of each such key is converted with converters and the results are available from Record.Rest. An
+// error is recorded under the input key. This allows extension or metadata maps to be preserved but sanitized.
+//
+// Pass the returned field to NewType like any other field. A Type can have at most one Rest field. It is not included
+// in Type.Fields or Record.Attrs.
+func Rest(converters ...ValueConverter) *RestField {
+	return &RestField{valueConverters: converters}
+}
+
+// RestField is a catch-all field created by Rest.
+type RestField struct {
+	valueConverters []ValueConverter
+}
+
+// Name returns RestFieldName.
+func (f *RestField) Name() string {
+	return RestFieldName
+}
+
+// ConvertValue converts value with the converters of f.
+func (f *RestField) ConvertValue(value any) (any, error) {
+	return convertSlice(value, f.valueConverters)
+}
+
+// ValueConverters returns the converters of f.
+func (f *RestField) ValueConverters() []ValueConverter {
+	return f.valueConverters
+}
+
+// Rest returns the converted values of the input keys collected by the Rest field of r's Type. It is nil if the Type
+// has no Rest field. Keys whose value failed to convert are omitted. The returned map must not be modified.
+func (r *Record) Rest() map[string]any {
+	return r.rest
+}
+
+// parseExtraKeys converts the values of the keys of attrs that are not fields of t with the pattern field they match
+// or the Rest field. Keys are converted in sorted order.
+func (t *Type) parseExtraKeys(ctx context.Context, r *Record, attrs map[string]any) {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		if !t.isFieldKey(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	if t.rest != nil {
+		r.rest = make(map[string]any)
+	}
+	if len(t.patterns) > 0 {
+		r.patternValues = make([]map[string]any, len(t.patterns))
+		for i := range r.patternValues {
+			r.patternValues[i] = make(map[string]any)
+		}
+	}
+
+	for _, k := range keys {
+		var converters []ValueConverter
+		var values map[string]any
+		if idx := t.matchPattern(k); idx >= 0 {
+			converters, values = t.patterns[idx].valueConverters, r.patternValues[idx]
+		} else if t.rest != nil {
+			converters, values = t.rest.valueConverters, r.rest
+		} else {
+			continue
+		}
+
+		value, err := convertSliceWithoutDependencies(ctx, t.options.StringPolicy.apply(attrs[k]), converters)
+		if err != nil {
+			if r.errors == nil {
+				r.errors = make(Errors)
+			}
+			r.errors[k] = err
+			continue
+		}
+		values[k] = value
+	}
+}
+
+// isFieldKey returns true if k is the name or alias of a field of t.
+func (t *Type) isFieldKey(k string) bool {
+	if _, ok := t.fieldsByName[k]; ok {
+		return true
+	}
+	_, ok := t.aliases[k]
+	return ok
+}
@@ -0,0 +1,41 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRest(t *testing.T) {
+	tp := mp.NewTypeWithOptions(mp.TypeOptions{TrackUnknownKeys: true},
+		mp.NewField("name", mp.SingleLineString()).WithAlias("full_name"),
+		mp.Rest(mp.SingleLineString(), mp.MaxLen(10)),
+	)
+
+	assert.Len(t, tp.Fields(), 1)
+	assert.False(t, tp.HasField(mp.RestFieldName))
+
+	record := tp.Parse(map[string]any{"name": "Jack", "color": " blue ", "size": "L"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{"name": "Jack"}, record.Attrs())
+	assert.Equal(t, map[string]any{"color": "blue", "size": "L"}, record.Rest())
+	assert.Nil(t, record.UnknownKeys())
+
+	record = tp.Parse(map[string]any{"full_name": "Jack"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, map[string]any{}, record.Rest())
+
+	record = tp.Parse(map[string]any{"name": "Jack", "color": "blue", "note": "much too long", "n": 7})
+	assert.Equal(t, map[string]string{"note": "too long", "n": "not a string"}, errorMessages(record.Errors()))
+	assert.Equal(t, map[string]any{"color": "blue"}, record.Rest())
+	assert.Equal(t, map[string]any{"color": "blue"}, record.Clone().Rest())
+
+	record = mp.NewType(mp.NewField("name")).Parse(map[string]any{"color": "blue"})
+	assert.Nil(t, record.Rest())
+
+	assert.PanicsWithError(t, "type cannot have more than one Rest field", func() {
+		mp.NewType(mp.Rest(), mp.Rest())
+	})
+}
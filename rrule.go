@@ -0,0 +1,208 @@
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceRule is a parsed iCalendar (RFC 5545) recurrence rule. Only the rule is parsed and validated; occurrences
+// are not expanded.
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []string
+	ByMonth    []int
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+	WeekStart  string
+
+	rule string
+}
+
+// String returns the rule rr was parsed from.
+func (rr *RecurrenceRule) String() string {
+	return rr.rule
+}
+
+var rruleFreqs = map[string]struct{}{
+	"SECONDLY": {}, "MINUTELY": {}, "HOURLY": {}, "DAILY": {}, "WEEKLY": {}, "MONTHLY": {}, "YEARLY": {},
+}
+
+var rruleWeekdays = map[string]struct{}{"MO": {}, "TU": {}, "WE": {}, "TH": {}, "FR": {}, "SA": {}, "SU": {}}
+
+// ParseRRule parses an iCalendar recurrence rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE". An optional "RRULE:"
+// prefix is allowed. FREQ is required and COUNT and UNTIL are mutually exclusive.
+func ParseRRule(rule string) (*RecurrenceRule, error) {
+	rr := &RecurrenceRule{rule: rule, Interval: 1}
+
+	s := strings.TrimSpace(rule)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	if s == "" {
+		return nil, errors.New("missing FREQ")
+	}
+
+	seen := make(map[string]struct{})
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("%s given more than once", name)
+		}
+		seen[name] = struct{}{}
+
+		var err error
+		switch name {
+		case "FREQ":
+			rr.Freq = strings.ToUpper(value)
+			if _, ok := rruleFreqs[rr.Freq]; !ok {
+				err = fmt.Errorf("invalid FREQ %q", value)
+			}
+		case "INTERVAL":
+			rr.Interval, err = parseRRulePositive(name, value)
+		case "COUNT":
+			rr.Count, err = parseRRulePositive(name, value)
+		case "UNTIL":
+			rr.Until, err = parseRRuleUntil(value)
+		case "BYDAY":
+			rr.ByDay, err = parseRRuleByDay(value)
+		case "BYMONTH":
+			rr.ByMonth, err = parseRRuleInts(name, value, 1, 12, false)
+		case "BYMONTHDAY":
+			rr.ByMonthDay, err = parseRRuleInts(name, value, 1, 31, true)
+		case "BYYEARDAY":
+			rr.ByYearDay, err = parseRRuleInts(name, value, 1, 366, true)
+		case "BYWEEKNO":
+			rr.ByWeekNo, err = parseRRuleInts(name, value, 1, 53, true)
+		case "BYHOUR":
+			rr.ByHour, err = parseRRuleInts(name, value, 0, 23, false)
+		case "BYMINUTE":
+			rr.ByMinute, err = parseRRuleInts(name, value, 0, 59, false)
+		case "BYSECOND":
+			rr.BySecond, err = parseRRuleInts(name, value, 0, 60, false)
+		case "BYSETPOS":
+			rr.BySetPos, err = parseRRuleInts(name, value, 1, 366, true)
+		case "WKST":
+			rr.WeekStart = strings.ToUpper(value)
+			if _, ok := rruleWeekdays[rr.WeekStart]; !ok {
+				err = fmt.Errorf("invalid WKST %q", value)
+			}
+		default:
+			err = fmt.Errorf("unknown rule part %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rr.Freq == "" {
+		return nil, errors.New("missing FREQ")
+	}
+	if rr.Count != 0 && !rr.Until.IsZero() {
+		return nil, errors.New("COUNT and UNTIL cannot both be given")
+	}
+
+	return rr, nil
+}
+
+func parseRRulePositive(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return n, nil
+}
+
+func parseRRuleUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+}
+
+// parseRRuleInts parses a comma separated list of integers between min and max. If signed is true negative values
+// between -max and -min are also allowed.
+func parseRRuleInts(name, value string, min, max int, signed bool) ([]int, error) {
+	items := strings.Split(value, ",")
+	ns := make([]int, len(items))
+	for i, item := range items {
+		n, err := strconv.Atoi(item)
+		if err == nil && signed && n < 0 {
+			n = -n
+			ns[i] = -n
+		} else {
+			ns[i] = n
+		}
+		if err != nil || n < min || n > max {
+			return nil, fmt.Errorf("invalid %s %q", name, item)
+		}
+	}
+	return ns, nil
+}
+
+func parseRRuleByDay(value string) ([]string, error) {
+	items := strings.Split(strings.ToUpper(value), ",")
+	for _, item := range items {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY %q", item)
+		}
+		ordinal, day := item[:len(item)-2], item[len(item)-2:]
+		if _, ok := rruleWeekdays[day]; !ok {
+			return nil, fmt.Errorf("invalid BYDAY %q", item)
+		}
+		if ordinal != "" {
+			if _, err := parseRRuleInts("BYDAY", strings.TrimPrefix(ordinal, "+"), 1, 53, true); err != nil {
+				return nil, fmt.Errorf("invalid BYDAY %q", item)
+			}
+		}
+	}
+	return items, nil
+}
+
+// RRule returns a ValueConverter that converts value to a *RecurrenceRule with ParseRRule. If value is nil or a blank
+// string nil is returned.
+func RRule() ValueConverter {
+	return rruleValueConverter{}
+}
+
+type rruleValueConverter struct{}
+
+func (c rruleValueConverter) ConvertValue(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case *RecurrenceRule:
+		return value, nil
+	case string:
+		rr, err := ParseRRule(value)
+		if err != nil {
+			return nil, newKindError(ErrInvalidFormat, err.Error())
+		}
+		return rr, nil
+	}
+
+	return nil, errors.New("not a string")
+}
+
+func (c rruleValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf((*RecurrenceRule)(nil))
+}
@@ -0,0 +1,147 @@
+package mp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TypeDefinition is a declarative definition of a Type. It can be decoded from JSON or YAML.
+type TypeDefinition struct {
+	Fields []FieldDefinition `json:"fields" yaml:"fields"`
+
+	// OmitMissing sets TypeOptions.OmitMissing.
+	OmitMissing bool `json:"omitMissing" yaml:"omitMissing"`
+}
+
+// FieldDefinition is a declarative definition of a field.
+type FieldDefinition struct {
+	Name       string                `json:"name" yaml:"name"`
+	Converters []ConverterDefinition `json:"converters" yaml:"converters"`
+
+	// Examples are example input values. See StandardField.WithExample.
+	Examples []any `json:"examples" yaml:"examples"`
+}
+
+// ConverterDefinition is a declarative reference to a registered converter. In JSON or YAML it can be written as
+// either the converter name as a string or as an object with name and args keys.
+type ConverterDefinition struct {
+	Name string `json:"name" yaml:"name"`
+	Args []any  `json:"args" yaml:"args"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (cd *ConverterDefinition) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*cd = ConverterDefinition{Name: name}
+		return nil
+	}
+
+	type converterDefinition ConverterDefinition
+	var d converterDefinition
+	err := json.Unmarshal(data, &d)
+	if err != nil {
+		return err
+	}
+	*cd = ConverterDefinition(d)
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (cd *ConverterDefinition) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*cd = ConverterDefinition{Name: node.Value}
+		return nil
+	}
+
+	type converterDefinition ConverterDefinition
+	var d converterDefinition
+	err := node.Decode(&d)
+	if err != nil {
+		return err
+	}
+	*cd = ConverterDefinition(d)
+	return nil
+}
+
+// Build creates a Type from td. Converter names are resolved with DefaultRegistry.
+func (td *TypeDefinition) Build() (*Type, error) {
+	return td.BuildWithRegistry(DefaultRegistry)
+}
+
+// BuildWithRegistry creates a Type from td. Converter names are resolved with r.
+func (td *TypeDefinition) BuildWithRegistry(r *Registry) (*Type, error) {
+	fields := make([]Field, 0, len(td.Fields))
+	names := make(map[string]struct{}, len(td.Fields))
+	for _, fd := range td.Fields {
+		if fd.Name == "" {
+			return nil, errors.New("field name cannot be empty")
+		}
+		if _, ok := names[fd.Name]; ok {
+			return nil, fmt.Errorf("field %q is defined more than once", fd.Name)
+		}
+		names[fd.Name] = struct{}{}
+
+		converters := make([]ValueConverter, 0, len(fd.Converters))
+		for _, cd := range fd.Converters {
+			vc, err := cd.BuildWithRegistry(r)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", fd.Name, err)
+			}
+			converters = append(converters, vc)
+		}
+
+		f := NewField(fd.Name, converters...)
+		for _, example := range fd.Examples {
+			f = f.WithExample(example)
+		}
+		fields = append(fields, f)
+	}
+
+	return NewTypeWithOptions(TypeOptions{OmitMissing: td.OmitMissing}, fields...), nil
+}
+
+// Build creates the ValueConverter referenced by cd. The converter name is resolved with DefaultRegistry.
+func (cd *ConverterDefinition) Build() (ValueConverter, error) {
+	return cd.BuildWithRegistry(DefaultRegistry)
+}
+
+// BuildWithRegistry creates the ValueConverter referenced by cd. The converter name is resolved with r.
+func (cd *ConverterDefinition) BuildWithRegistry(r *Registry) (ValueConverter, error) {
+	constructor, ok := r.Lookup(cd.Name)
+	if !ok {
+		return nil, fmt.Errorf("unknown converter %q", cd.Name)
+	}
+
+	vc, err := constructor(cd.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("converter %q: %w", cd.Name, err)
+	}
+
+	return vc, nil
+}
+
+// LoadTypeJSON creates a Type from a JSON encoded TypeDefinition.
+func LoadTypeJSON(data []byte) (*Type, error) {
+	var td TypeDefinition
+	err := json.Unmarshal(data, &td)
+	if err != nil {
+		return nil, err
+	}
+
+	return td.Build()
+}
+
+// LoadTypeYAML creates a Type from a YAML encoded TypeDefinition.
+func LoadTypeYAML(data []byte) (*Type, error) {
+	var td TypeDefinition
+	err := yaml.Unmarshal(data, &td)
+	if err != nil {
+		return nil, err
+	}
+
+	return td.Build()
+}
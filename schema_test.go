@@ -0,0 +1,70 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTypeJSON(t *testing.T) {
+	ft, err := mp.LoadTypeJSON([]byte(`{
+		"fields": [
+			{"name": "name", "converters": ["singleLineString", "require", {"name": "maxLen", "args": [5]}]},
+			{"name": "age", "converters": ["int32", {"name": "greaterThanOrEqual", "args": [18]}], "examples": [30]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	record := ft.Parse(map[string]any{"name": " Adam ", "age": "30"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Adam", record.Get("name"))
+	assert.Equal(t, int32(30), record.Get("age"))
+
+	record = ft.Parse(map[string]any{"name": "Adam Smith", "age": "12"})
+	require.Error(t, record.Errors())
+
+	assert.Equal(t, map[string]any{"age": float64(30)}, ft.Example())
+}
+
+func TestLoadTypeYAML(t *testing.T) {
+	ft, err := mp.LoadTypeYAML([]byte(`
+fields:
+  - name: status
+    converters:
+      - singleLineString
+      - name: allowStrings
+        args: [active, inactive]
+`))
+	require.NoError(t, err)
+
+	record := ft.Parse(map[string]any{"status": "active"})
+	require.NoError(t, record.Errors())
+
+	record = ft.Parse(map[string]any{"status": "deleted"})
+	require.Error(t, record.Errors())
+}
+
+func TestLoadTypeJSONUnknownConverter(t *testing.T) {
+	_, err := mp.LoadTypeJSON([]byte(`{"fields": [{"name": "name", "converters": ["nope"]}]}`))
+	require.ErrorContains(t, err, `unknown converter "nope"`)
+}
+
+func TestLoadTypeJSONInvalidArguments(t *testing.T) {
+	_, err := mp.LoadTypeJSON([]byte(`{"fields": [{"name": "name", "converters": [{"name": "minLen", "args": ["abc"]}]}]}`))
+	require.Error(t, err)
+}
+
+func TestLoadTypeJSONOmitMissing(t *testing.T) {
+	ft, err := mp.LoadTypeJSON([]byte(`{"omitMissing": true, "fields": [{"name": "a"}, {"name": "b"}]}`))
+	require.NoError(t, err)
+
+	record := ft.Parse(map[string]any{"a": "1"})
+	assert.Equal(t, map[string]any{"a": "1"}, record.Attrs())
+}
+
+func TestLoadTypeJSONDuplicateField(t *testing.T) {
+	_, err := mp.LoadTypeJSON([]byte(`{"fields": [{"name": "name"}, {"name": "age"}, {"name": "name"}]}`))
+	require.EqualError(t, err, `field "name" is defined more than once`)
+}
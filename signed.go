@@ -0,0 +1,58 @@
+package mp
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Sign returns payload signed with secret. The result can be verified and parsed with Signed.
+func Sign(secret []byte, payload string) string {
+	return signToken(secret, []byte(payload))
+}
+
+// Signed returns a ValueConverter that verifies a value created by Sign with the same secret and converts the embedded
+// payload with codec. This is useful for values that round trip through a client such as cookies, hidden form fields,
+// or unsubscribe links. If codec is nil the payload is returned as a string. If value is nil or a blank string nil is
+// returned.
+func Signed(secret []byte, codec ValueConverter) ValueConverter {
+	return &signedValueConverter{secret: secret, codec: codec}
+}
+
+type signedValueConverter struct {
+	secret []byte
+	codec  ValueConverter
+}
+
+func (c *signedValueConverter) ConvertValue(value any) (any, error) {
+	value = normalizeForParsing(value)
+
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a valid signed value")
+	}
+
+	payload, err := verifyToken(c.secret, s)
+	if err != nil {
+		return nil, errors.New("not a valid signed value")
+	}
+
+	if c.codec == nil {
+		return string(payload), nil
+	}
+
+	return c.codec.ConvertValue(string(payload))
+}
+
+func (c *signedValueConverter) ConvertedType() reflect.Type {
+	if c.codec == nil {
+		return reflect.TypeOf("")
+	}
+	if ct, ok := c.codec.(ConvertedTyper); ok {
+		return ct.ConvertedType()
+	}
+	return anyType
+}
@@ -0,0 +1,31 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigned(t *testing.T) {
+	secret := []byte("secret")
+	ft := mp.NewType(
+		mp.NewField("user_id", mp.Signed(secret, mp.Int64()), mp.Require()),
+		mp.NewField("step", mp.Signed(secret, nil)),
+	)
+
+	record := ft.Parse(map[string]any{"user_id": mp.Sign(secret, "42"), "step": mp.Sign(secret, "billing")})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, int64(42), record.Get("user_id"))
+	assert.Equal(t, "billing", record.Get("step"))
+
+	record = ft.Parse(map[string]any{"user_id": mp.Sign([]byte("other"), "42")})
+	assert.EqualError(t, record.Errors(), "user_id not a valid signed value")
+
+	record = ft.Parse(map[string]any{"user_id": mp.Sign(secret, "abc")})
+	assert.Error(t, record.Errors())
+
+	record = ft.Parse(map[string]any{"user_id": "42"})
+	assert.EqualError(t, record.Errors(), "user_id not a valid signed value")
+}
@@ -0,0 +1,44 @@
+package mp
+
+// inputOverhead is the approximate size in bytes counted for each value in addition to the data it references.
+const inputOverhead = 16
+
+// inputSizeExceeds returns true if the approximate size of value exceeds limit. It stops as soon as the limit is
+// exceeded so it is cheap for very large input.
+func inputSizeExceeds(value any, limit int) bool {
+	return inputSize(value, limit) > limit
+}
+
+// inputSize returns the approximate size of value in bytes. It returns early with a size greater than limit once limit
+// is exceeded.
+func inputSize(value any, limit int) int {
+	size := inputOverhead
+	switch value := value.(type) {
+	case string:
+		size += len(value)
+	case []byte:
+		size += len(value)
+	case map[string]any:
+		for k, v := range value {
+			size += len(k) + inputSize(v, limit-size)
+			if size > limit {
+				return size
+			}
+		}
+	case []any:
+		for _, v := range value {
+			size += inputSize(v, limit-size)
+			if size > limit {
+				return size
+			}
+		}
+	case []string:
+		for _, v := range value {
+			size += inputOverhead + len(v)
+			if size > limit {
+				return size
+			}
+		}
+	}
+	return size
+}
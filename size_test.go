@@ -0,0 +1,39 @@
+package mp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeMaxInputSize(t *testing.T) {
+	itemType := mp.NewType(mp.NewField("name", mp.SingleLineString()))
+	ft := mp.NewTypeWithOptions(
+		mp.TypeOptions{MaxInputSize: 1024},
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("items", mp.Slice[*mp.Record](itemType)),
+	)
+
+	record := ft.Parse(map[string]any{"name": "Adam", "items": []any{map[string]any{"name": "a"}}})
+	require.NoError(t, record.Errors())
+
+	tests := []map[string]any{
+		{"name": strings.Repeat("x", 2000)},
+		{"items": []any{map[string]any{"name": strings.Repeat("x", 2000)}}},
+		{"items": make([]any, 1000)},
+		{"other": strings.Repeat("x", 2000)},
+	}
+
+	for i, attrs := range tests {
+		record := ft.Parse(attrs)
+		err := record.Errors()
+		assert.EqualErrorf(t, err, "base input is too large", "%d", i)
+		assert.ErrorIsf(t, err, mp.ErrTooLarge, "%d", i)
+		assert.Nilf(t, record.Get("name"), "%d", i)
+	}
+
+	assert.Error(t, ft.ParsePartial(tests[0]).Errors())
+}
@@ -0,0 +1,269 @@
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// structFieldName returns the attribute name of sf. It is the value of the mp tag if present and the Go field name
+// otherwise. ok is false for unexported fields and fields tagged with `mp:"-"`.
+func structFieldName(sf reflect.StructField) (name string, ok bool) {
+	if !sf.IsExported() {
+		return "", false
+	}
+
+	tag := sf.Tag.Get("mp")
+	switch tag {
+	case "-":
+		return "", false
+	case "":
+		return sf.Name, true
+	default:
+		return tag, true
+	}
+}
+
+// Scan copies the values of r into the struct pointed to by dst. Struct fields are matched to record fields by the mp
+// tag or by the Go field name if there is no tag. Fields tagged with `mp:"-"` and fields that are not fields of r's
+// Type are skipped. Nested Records are scanned into struct or pointer to struct fields and slices of Records are
+// scanned into slices of structs or pointers to structs. Numeric values are converted to the numeric type of the
+// struct field. Scan returns an error if a value cannot be stored in its struct field.
+func (r *Record) Scan(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot scan into %T: not a non-nil pointer to a struct", dst)
+	}
+
+	return r.scanStruct(v.Elem())
+}
+
+func (r *Record) scanStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := structFieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+
+		value, ok := r.TryGet(name)
+		if !ok {
+			continue
+		}
+
+		err := scanValue(v.Field(i), value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func scanValue(dst reflect.Value, value any) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch value := value.(type) {
+	case *Record:
+		return scanRecord(dst, value)
+	case []*Record:
+		if dst.Kind() != reflect.Slice {
+			break
+		}
+		s := reflect.MakeSlice(dst.Type(), len(value), len(value))
+		for i, record := range value {
+			err := scanValue(s.Index(i), record)
+			if err != nil {
+				return fmt.Errorf("%d: %w", i, err)
+			}
+		}
+		dst.Set(s)
+		return nil
+	}
+
+	src := reflect.ValueOf(value)
+	if dst.Kind() == reflect.Pointer && !src.Type().AssignableTo(dst.Type()) {
+		elem := reflect.New(dst.Type().Elem())
+		err := scanValue(elem.Elem(), value)
+		if err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+		return nil
+	case isNumericKind(src.Kind()) && isNumericKind(dst.Kind()):
+		if reason := numberConversionLoss(src, dst); reason != "" {
+			return fmt.Errorf("cannot scan %v into %v: %s", value, dst.Type(), reason)
+		}
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T into %v", value, dst.Type())
+}
+
+func scanRecord(dst reflect.Value, record *Record) error {
+	switch {
+	case dst.Kind() == reflect.Struct:
+		return record.scanStruct(dst)
+	case dst.Kind() == reflect.Pointer && dst.Type().Elem().Kind() == reflect.Struct:
+		elem := reflect.New(dst.Type().Elem())
+		err := record.scanStruct(elem.Elem())
+		if err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.TypeOf(record).AssignableTo(dst.Type()):
+		dst.Set(reflect.ValueOf(record))
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T into %v", record, dst.Type())
+}
+
+// numberConversionLoss returns why converting the number src to the type of dst would change its value or "" if it
+// would not. Precision lost converting an integer to a float is ignored.
+func numberConversionLoss(src, dst reflect.Value) string {
+	switch {
+	case src.CanInt():
+		n := src.Int()
+		switch {
+		case dst.CanInt():
+			if dst.OverflowInt(n) {
+				return "out of range"
+			}
+		case dst.CanUint():
+			if n < 0 || dst.OverflowUint(uint64(n)) {
+				return "out of range"
+			}
+		}
+	case src.CanUint():
+		n := src.Uint()
+		switch {
+		case dst.CanInt():
+			if n > math.MaxInt64 || dst.OverflowInt(int64(n)) {
+				return "out of range"
+			}
+		case dst.CanUint():
+			if dst.OverflowUint(n) {
+				return "out of range"
+			}
+		}
+	case src.CanFloat():
+		f := src.Float()
+		switch {
+		case dst.CanFloat():
+			if dst.OverflowFloat(f) {
+				return "out of range"
+			}
+		case dst.CanInt():
+			if f != math.Trunc(f) {
+				return "has a fractional part"
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 || dst.OverflowInt(int64(f)) {
+				return "out of range"
+			}
+		case dst.CanUint():
+			if f != math.Trunc(f) {
+				return "has a fractional part"
+			}
+			if f < 0 || f >= math.MaxUint64 || dst.OverflowUint(uint64(f)) {
+				return "out of range"
+			}
+		}
+	}
+
+	return ""
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// AttrsFromStruct returns the attrs of the struct v or the struct pointed to by v. It is the inverse of Record.Scan and
+// uses the same field names. Nested structs with exported fields are converted to maps and slices of such structs are
+// converted to []any of maps so they can be parsed by nested Types. Other values, such as time.Time or decimal.Decimal,
+// are included as is. Pointers are dereferenced and nil pointers become nil. AttrsFromStruct panics if v is not a
+// struct or a non-nil pointer to a struct.
+func AttrsFromStruct(v any) map[string]any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(errors.New("AttrsFromStruct requires a struct or a non-nil pointer to a struct"))
+	}
+
+	return structAttrs(rv)
+}
+
+func structAttrs(v reflect.Value) map[string]any {
+	t := v.Type()
+	attrs := make(map[string]any, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := structFieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+		attrs[name] = attrValue(v.Field(i))
+	}
+	return attrs
+}
+
+func attrValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return attrValue(v.Elem())
+	case reflect.Struct:
+		if isAttrsStruct(v.Type()) {
+			return structAttrs(v)
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		elemType := v.Type().Elem()
+		if isAttrsStruct(elemType) || (elemType.Kind() == reflect.Pointer && isAttrsStruct(elemType.Elem())) {
+			elements := make([]any, v.Len())
+			for i := range elements {
+				elements[i] = attrValue(v.Index(i))
+			}
+			return elements
+		}
+	}
+
+	return v.Interface()
+}
+
+// isAttrsStruct returns true if t is a struct with at least one exported field. Structs without exported fields such
+// as time.Time and decimal.Decimal are treated as values.
+func isAttrsStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
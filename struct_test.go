@@ -0,0 +1,168 @@
+package mp_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type structAddress struct {
+	City string `mp:"city"`
+}
+
+type structPerson struct {
+	Name       string          `mp:"name"`
+	Age        int             `mp:"age"`
+	Nickname   *string         `mp:"nickname"`
+	Balance    decimal.Decimal `mp:"balance"`
+	Born       time.Time       `mp:"born"`
+	Tags       []string        `mp:"tags"`
+	Address    structAddress   `mp:"address"`
+	Previous   []*structAddress
+	Internal   string `mp:"-"`
+	notScanned string
+}
+
+var structPersonType = mp.NewType(
+	mp.NewField("name", mp.SingleLineString(), mp.Require()),
+	mp.NewField("age", mp.Int64()),
+	mp.NewField("nickname", mp.SingleLineString()),
+	mp.NewField("balance", mp.Decimal()),
+	mp.NewField("born", mp.Time(time.RFC3339)),
+	mp.NewField("tags", mp.Slice[string](mp.SingleLineString())),
+	mp.NewField("address", mp.NewType(mp.NewField("city", mp.SingleLineString(), mp.Require()))),
+	mp.NewField("Previous", mp.Slice[*mp.Record](mp.NewType(mp.NewField("city", mp.SingleLineString(), mp.Require())))),
+)
+
+func TestRecordScan(t *testing.T) {
+	record := structPersonType.Parse(map[string]any{
+		"name":     "Adam",
+		"age":      "30",
+		"nickname": "Ad",
+		"balance":  "12.50",
+		"born":     "1990-01-02T03:04:05Z",
+		"tags":     []any{"a", "b"},
+		"address":  map[string]any{"city": "Dallas"},
+		"Previous": []any{map[string]any{"city": "Austin"}},
+	})
+	require.NoError(t, record.Errors())
+
+	var p structPerson
+	err := record.Scan(&p)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Adam", p.Name)
+	assert.Equal(t, 30, p.Age)
+	require.NotNil(t, p.Nickname)
+	assert.Equal(t, "Ad", *p.Nickname)
+	assert.Equal(t, "12.5", p.Balance.String())
+	assert.Equal(t, time.Date(1990, 1, 2, 3, 4, 5, 0, time.UTC), p.Born)
+	assert.Equal(t, []string{"a", "b"}, p.Tags)
+	assert.Equal(t, structAddress{City: "Dallas"}, p.Address)
+	assert.Equal(t, []*structAddress{{City: "Austin"}}, p.Previous)
+}
+
+func TestRecordScanNil(t *testing.T) {
+	record := structPersonType.Parse(map[string]any{"name": "Adam"})
+	require.NoError(t, record.Errors())
+
+	nickname := "old"
+	p := structPerson{Age: 10, Nickname: &nickname}
+	err := record.Scan(&p)
+	require.NoError(t, err)
+	assert.Equal(t, 0, p.Age)
+	assert.Nil(t, p.Nickname)
+}
+
+func TestRecordScanErrors(t *testing.T) {
+	record := structPersonType.Parse(map[string]any{"name": "Adam"})
+
+	var p structPerson
+	assert.Error(t, record.Scan(p))
+	assert.Error(t, record.Scan((*structPerson)(nil)))
+
+	var wrongType struct {
+		Name int `mp:"name"`
+	}
+	assert.EqualError(t, record.Scan(&wrongType), "name: cannot scan string into int")
+}
+
+func TestAttrsFromStruct(t *testing.T) {
+	nickname := "Ad"
+	p := structPerson{
+		Name:     "Adam",
+		Age:      30,
+		Nickname: &nickname,
+		Balance:  decimal.RequireFromString("12.5"),
+		Born:     time.Date(1990, 1, 2, 3, 4, 5, 0, time.UTC),
+		Tags:     []string{"a", "b"},
+		Address:  structAddress{City: "Dallas"},
+		Previous: []*structAddress{{City: "Austin"}},
+		Internal: "secret",
+	}
+
+	attrs := mp.AttrsFromStruct(&p)
+	assert.Equal(t, map[string]any{
+		"name":     "Adam",
+		"age":      30,
+		"nickname": "Ad",
+		"balance":  decimal.RequireFromString("12.5"),
+		"born":     time.Date(1990, 1, 2, 3, 4, 5, 0, time.UTC),
+		"tags":     []string{"a", "b"},
+		"address":  map[string]any{"city": "Dallas"},
+		"Previous": []any{map[string]any{"city": "Austin"}},
+	}, attrs)
+
+	record := structPersonType.Parse(attrs)
+	require.NoError(t, record.Errors())
+
+	var roundTripped structPerson
+	require.NoError(t, record.Scan(&roundTripped))
+	p.Internal = ""
+	assert.Equal(t, p, roundTripped)
+}
+
+func TestAttrsFromStructPanicsOnNonStruct(t *testing.T) {
+	assert.Panics(t, func() { mp.AttrsFromStruct(42) })
+	assert.Panics(t, func() { mp.AttrsFromStruct((*structPerson)(nil)) })
+}
+
+func TestRecordScanNumberOutOfRange(t *testing.T) {
+	tests := []struct {
+		value    any
+		dst      any
+		expected string
+	}{
+		{int64(300), new(int8), "n: cannot scan 300 into int8: out of range"},
+		{int64(-1), new(uint8), "n: cannot scan -1 into uint8: out of range"},
+		{uint64(math.MaxUint64), new(int64), "n: cannot scan 18446744073709551615 into int64: out of range"},
+		{1.9, new(int), "n: cannot scan 1.9 into int: has a fractional part"},
+		{-1.0, new(uint), "n: cannot scan -1 into uint: out of range"},
+		{1e300, new(int64), "n: cannot scan 1e+300 into int64: out of range"},
+		{1e300, new(float32), "n: cannot scan 1e+300 into float32: out of range"},
+	}
+
+	for i, tt := range tests {
+		ft := mp.NewType(mp.NewField("n", mp.ValueConverterFunc(func(any) (any, error) { return tt.value, nil })))
+		record := ft.Parse(map[string]any{"n": "x"})
+		require.NoErrorf(t, record.Errors(), "%d", i)
+
+		dst := reflect.New(reflect.StructOf([]reflect.StructField{
+			{Name: "N", Type: reflect.TypeOf(tt.dst).Elem(), Tag: `mp:"n"`},
+		}))
+		assert.EqualErrorf(t, record.Scan(dst.Interface()), tt.expected, "%d", i)
+	}
+
+	ft := mp.NewType(mp.NewField("n", mp.Int64()))
+	var dst struct {
+		N int8 `mp:"n"`
+	}
+	require.NoError(t, ft.Parse(map[string]any{"n": "100"}).Scan(&dst))
+	assert.Equal(t, int8(100), dst.N)
+}
@@ -0,0 +1,225 @@
+package mp
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+var (
+	errTooFewWords  = newKindError(ErrTooShort, "too few words")
+	errTooManyWords = newKindError(ErrTooLong, "too many words")
+	errTooManyLines = newKindError(ErrTooLong, "too many lines")
+)
+
+// MinWords returns a ValueConverter that fails if value has fewer than min words. Words are separated by white space.
+// value must be a string. nil is returned unmodified.
+func MinWords(min int) ValueConverter {
+	return minWordsValueConverter{min: min}
+}
+
+type minWordsValueConverter struct {
+	min int
+}
+
+func (c minWordsValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	if countWords(s) < c.min {
+		return nil, errTooFewWords
+	}
+
+	return value, nil
+}
+
+func (c minWordsValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"minWords": c.min}
+}
+
+// MaxWords returns a ValueConverter that fails if value has more than max words. Words are separated by white space.
+// value must be a string. nil is returned unmodified.
+func MaxWords(max int) ValueConverter {
+	return maxWordsValueConverter{max: max}
+}
+
+type maxWordsValueConverter struct {
+	max int
+}
+
+func (c maxWordsValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	if countWords(s) > c.max {
+		return nil, errTooManyWords
+	}
+
+	return value, nil
+}
+
+func (c maxWordsValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"maxWords": c.max}
+}
+
+// MaxLines returns a ValueConverter that fails if value has more than max lines. Lines are terminated by "\n", "\r\n",
+// or "\r". A final line terminator does not start another line. value must be a string. nil is returned unmodified.
+func MaxLines(max int) ValueConverter {
+	return maxLinesValueConverter{max: max}
+}
+
+type maxLinesValueConverter struct {
+	max int
+}
+
+func (c maxLinesValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	if countLines(s) > c.max {
+		return nil, errTooManyLines
+	}
+
+	return value, nil
+}
+
+func (c maxLinesValueConverter) ConverterParams() map[string]any {
+	return map[string]any{"maxLines": c.max}
+}
+
+// countWords returns the number of white space separated words in s.
+func countWords(s string) int {
+	n := 0
+	inWord := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			inWord = false
+		} else if !inWord {
+			inWord = true
+			n++
+		}
+	}
+	return n
+}
+
+// countLines returns the number of lines in s.
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+
+	n := 1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+			fallthrough
+		case '\n':
+			if i < len(s)-1 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// NormalizeNewlines returns a ValueConverter that replaces "\r\n" and "\r" with "\n". It is intended for text submitted
+// from a textarea where the line endings depend on the operating system of the client. value must be a string. nil is
+// returned unmodified.
+func NormalizeNewlines() ValueConverter {
+	return normalizeNewlinesValueConverter{}
+}
+
+type normalizeNewlinesValueConverter struct{}
+
+func (c normalizeNewlinesValueConverter) ConvertValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+
+	return normalizeNewlines(s), nil
+}
+
+func (c normalizeNewlinesValueConverter) ConvertedType() reflect.Type {
+	return reflect.TypeOf("")
+}
+
+func normalizeNewlines(s string) string {
+	if !strings.Contains(s, "\r") {
+		return s
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+// expandTabs replaces each tab in s with spaces up to the next multiple of width columns. Columns are counted in runes
+// from the start of each line.
+func expandTabs(s string, width int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+
+	sb := &strings.Builder{}
+	sb.Grow(len(s))
+	column := 0
+	for _, r := range s {
+		switch r {
+		case '\t':
+			spaces := width - column%width
+			sb.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+		case '\n', '\r':
+			sb.WriteRune(r)
+			column = 0
+		default:
+			sb.WriteRune(r)
+			column++
+		}
+	}
+	return sb.String()
+}
+
+// limitBlankLines removes blank lines from s that follow max consecutive blank lines.
+func limitBlankLines(s string, max int) string {
+	lines := strings.SplitAfter(s, "\n")
+	kept := lines[:0]
+	blanks := 0
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" && line != "" {
+			blanks++
+			if blanks > max {
+				continue
+			}
+		} else {
+			blanks = 0
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "")
+}
@@ -0,0 +1,120 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordCountValidators(t *testing.T) {
+	tests := []struct {
+		vc     mp.ValueConverter
+		value  any
+		errMsg string
+	}{
+		{mp.MinWords(3), "one two three", ""},
+		{mp.MinWords(3), "  one\ttwo\n\nthree  ", ""},
+		{mp.MinWords(3), "one two", "too few words"},
+		{mp.MinWords(1), "   ", "too few words"},
+		{mp.MinWords(3), nil, ""},
+		{mp.MinWords(3), 42, "not a string"},
+		{mp.MaxWords(2), "one two", ""},
+		{mp.MaxWords(2), "don't stop", ""},
+		{mp.MaxWords(2), "one two three", "too many words"},
+		{mp.MaxWords(2), "", ""},
+		{mp.MaxWords(2), nil, ""},
+	}
+
+	for i, tt := range tests {
+		value, err := tt.vc.ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.value, value, "%d", i)
+	}
+
+	_, err := mp.MinWords(2).ConvertValue("one")
+	assert.ErrorIs(t, err, mp.ErrTooShort)
+	_, err = mp.MaxWords(1).ConvertValue("one two")
+	assert.ErrorIs(t, err, mp.ErrTooLong)
+}
+
+func TestMaxLines(t *testing.T) {
+	tests := []struct {
+		value  any
+		errMsg string
+	}{
+		{"", ""},
+		{"one", ""},
+		{"one\ntwo", ""},
+		{"one\ntwo\n", ""},
+		{"one\r\ntwo\r\n", ""},
+		{"one\rtwo", ""},
+		{"one\ntwo\nthree", "too many lines"},
+		{"one\r\n\r\nthree", "too many lines"},
+		{"\n\n", ""},
+		{"\n\n\n", "too many lines"},
+		{nil, ""},
+		{42, "not a string"},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.MaxLines(2).ConvertValue(tt.value)
+		if tt.errMsg != "" {
+			assert.EqualErrorf(t, err, tt.errMsg, "%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.value, value, "%d", i)
+	}
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+	}{
+		{"one\r\ntwo\rthree\nfour", "one\ntwo\nthree\nfour"},
+		{"one\n\rtwo", "one\n\ntwo"},
+		{"plain", "plain"},
+		{nil, nil},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.NormalizeNewlines().ConvertValue(tt.value)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+
+	_, err := mp.NormalizeNewlines().ConvertValue(42)
+	assert.EqualError(t, err, "not a string")
+}
+
+func TestMultiLineStringWithOptions(t *testing.T) {
+	tests := []struct {
+		options  mp.MultiLineStringOptions
+		value    string
+		expected string
+	}{
+		{mp.MultiLineStringOptions{}, "a\r\n\tb", "a\r\n\tb"},
+		{mp.MultiLineStringOptions{NormalizeNewlines: true}, "a\r\nb\rc", "a\nb\nc"},
+		{mp.MultiLineStringOptions{TabWidth: 4}, "\tx\n12\ty\n1234\tz", "    x\n12  y\n1234    z"},
+		{mp.MultiLineStringOptions{MaxBlankLines: 1}, "a\n\n\n \nb\n\nc\n", "a\n\nb\n\nc\n"},
+		{mp.MultiLineStringOptions{MaxBlankLines: 2}, "a\n\n\n\n\nb", "a\n\n\nb"},
+		{
+			mp.MultiLineStringOptions{NormalizeNewlines: true, TabWidth: 2, MaxBlankLines: 1},
+			"a\r\n\r\n\r\n\tb\x00",
+			"a\n\n  b ",
+		},
+	}
+
+	for i, tt := range tests {
+		value, err := mp.MultiLineStringWithOptions(tt.options).ConvertValue(tt.value)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, value, "%d", i)
+	}
+}
@@ -0,0 +1,393 @@
+package mp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/shopspring/decimal"
+)
+
+// TypeScriptInterface returns a TypeScript interface declaration named name that describes the records produced by t.
+// Field types are inferred from the ConvertedTyper of the last converter of each field that implements it. Fields
+// without a required marker (e.g. Require or NotNil) are optional and nullable.
+func TypeScriptInterface(name string, t *Type) string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "export interface %s ", name)
+	writeTypeScriptObject(sb, t, "")
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// ZodSchema returns a TypeScript declaration of a Zod schema named name that validates the same shape as t. It is
+// intended to keep client side validation in sync with server side validation. Keys collected by a Rest field are
+// validated with catchall. Keys matching a Pattern field are validated in a superRefine that tests the key with the
+// pattern's regular expression.
+func ZodSchema(name string, t *Type) string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "export const %s = ", name)
+	writeZodObject(sb, t, "")
+	sb.WriteString(";\n")
+	return sb.String()
+}
+
+// schemaField is the information about a field needed to generate a schema in another language.
+type schemaField struct {
+	name     string
+	required bool
+	params   map[string]any
+
+	// nonEmpty is true if the field rejects "" (e.g. Require).
+	nonEmpty bool
+
+	// typer is the last converter that determines the type of the field. It is nil if the type is unknown.
+	typer ValueConverter
+}
+
+func describeField(f Field) schemaField {
+	sf := schemaField{name: f.Name(), required: FieldIsRequired(f), params: FieldConverterParams(f)}
+
+	for _, vc := range fieldConverters(f) {
+		if _, ok := vc.(ConvertedTyper); ok {
+			sf.typer = vc
+		}
+		if _, ok := vc.(requireValueConverter); ok {
+			sf.nonEmpty = sf.required
+		}
+	}
+
+	return sf
+}
+
+func writeTypeScriptObject(sb *strings.Builder, t *Type, indent string) {
+	sb.WriteString("{\n")
+	for _, f := range t.Fields() {
+		sf := describeField(f)
+		if message, ok := FieldDeprecation(f); ok {
+			fmt.Fprintf(sb, "%s  /** @deprecated %s */\n", indent, message)
+		}
+		sb.WriteString(indent)
+		sb.WriteString("  ")
+		sb.WriteString(quoteTypeScriptKey(sf.name))
+		if !sf.required {
+			sb.WriteString("?")
+		}
+		sb.WriteString(": ")
+		writeTypeScriptType(sb, sf.typer, indent)
+		if !sf.required {
+			sb.WriteString(" | null")
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString(indent)
+	sb.WriteString("}")
+}
+
+func writeZodObject(sb *strings.Builder, t *Type, indent string) {
+	sb.WriteString("z.object({\n")
+	for _, f := range t.Fields() {
+		sf := describeField(f)
+		sb.WriteString(indent)
+		sb.WriteString("  ")
+		sb.WriteString(quoteTypeScriptKey(sf.name))
+		sb.WriteString(": ")
+		writeZodField(sb, sf, indent)
+		sb.WriteString(",\n")
+	}
+	sb.WriteString(indent)
+	sb.WriteString("})")
+
+	if len(t.patterns) > 0 {
+		writeZodPatterns(sb, t, indent)
+	} else if t.rest != nil {
+		sb.WriteString(".catchall(")
+		writeZodField(sb, describeField(NewField(t.rest.Name(), t.rest.ValueConverters()...)), indent)
+		sb.WriteString(")")
+	}
+}
+
+// writeZodField writes the Zod schema of the value of sf.
+func writeZodField(sb *strings.Builder, sf schemaField, indent string) {
+	writeZodType(sb, sf.typer, indent)
+	writeZodParams(sb, sf.params, zodBase(sf.typer), sf.nonEmpty)
+	if !sf.required {
+		sb.WriteString(".nullish()")
+	}
+}
+
+// writeZodPatterns writes a superRefine that validates the keys of an object that are not fields of t with the first
+// pattern field of t they match or else the Rest field of t.
+func writeZodPatterns(sb *strings.Builder, t *Type, indent string) {
+	var names []string
+	for _, f := range t.Fields() {
+		names = append(names, f.Name())
+	}
+
+	sb.WriteString(".catchall(z.unknown()).superRefine((v, ctx) => {\n")
+	fmt.Fprintf(sb, "%s  for (const [k, x] of Object.entries(v)) {\n", indent)
+	fmt.Fprintf(sb, "%s    if (%s.includes(k)) continue;\n", indent, jsonString(names))
+	fmt.Fprintf(sb, "%s    let s: z.ZodTypeAny | null = null;\n", indent)
+	for i, f := range t.patterns {
+		if i > 0 {
+			sb.WriteString(" else ")
+		} else {
+			fmt.Fprintf(sb, "%s    ", indent)
+		}
+		fmt.Fprintf(sb, "if (new RegExp(%s).test(k)) s = ", jsonString(f.re.String()))
+		writeZodField(sb, describeField(NewField(f.Name(), f.ValueConverters()...)), indent+"    ")
+		sb.WriteString(";")
+	}
+	if t.rest != nil {
+		sb.WriteString(" else s = ")
+		writeZodField(sb, describeField(NewField(t.rest.Name(), t.rest.ValueConverters()...)), indent+"    ")
+		sb.WriteString(";")
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(sb, "%s    if (s === null) continue;\n", indent)
+	fmt.Fprintf(sb, "%s    const r = s.safeParse(x);\n", indent)
+	fmt.Fprintf(sb, "%s    if (!r.success) r.error.issues.forEach((i) => ctx.addIssue({ ...i, path: [k, ...i.path] }));\n", indent)
+	fmt.Fprintf(sb, "%s  }\n", indent)
+	fmt.Fprintf(sb, "%s})", indent)
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	uuidType    = reflect.TypeOf(uuid.UUID{})
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+)
+
+func writeTypeScriptType(sb *strings.Builder, vc ValueConverter, indent string) {
+	switch vc := vc.(type) {
+	case *Type:
+		writeTypeScriptObject(sb, vc, indent+"  ")
+	case sliceConverter:
+		elementConverter := vc.element()
+		if _, ok := elementConverter.(ConvertedTyper); !ok {
+			elementConverter = nil
+		}
+		sb.WriteString("(")
+		writeTypeScriptType(sb, elementConverter, indent)
+		sb.WriteString(")[]")
+	case ConvertedTyper:
+		sb.WriteString(typeScriptType(vc.ConvertedType()))
+	default:
+		sb.WriteString("unknown")
+	}
+}
+
+func writeZodType(sb *strings.Builder, vc ValueConverter, indent string) {
+	switch vc := vc.(type) {
+	case *Type:
+		writeZodObject(sb, vc, indent+"  ")
+	case sliceConverter:
+		elementConverter := vc.element()
+		if _, ok := elementConverter.(ConvertedTyper); !ok {
+			elementConverter = nil
+		}
+		sb.WriteString("z.array(")
+		writeZodType(sb, elementConverter, indent)
+		sb.WriteString(")")
+	case ConvertedTyper:
+		sb.WriteString(zodType(vc.ConvertedType()))
+	default:
+		sb.WriteString("z.unknown()")
+	}
+}
+
+// zodBaseKind is the kind of Zod schema written for a converter. It determines which refinements can be applied.
+type zodBaseKind int
+
+const (
+	zodOther zodBaseKind = iota
+	zodString
+	zodFormattedString // a string with a fixed format such as a time, UUID, or decimal
+	zodNumber
+	zodArray
+)
+
+// zodBase returns the kind of Zod schema written by writeZodType for vc.
+func zodBase(vc ValueConverter) zodBaseKind {
+	switch vc := vc.(type) {
+	case *Type:
+		return zodOther
+	case sliceConverter:
+		return zodArray
+	case ConvertedTyper:
+		t := vc.ConvertedType()
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil {
+			return zodOther
+		}
+		switch t {
+		case timeType, uuidType, decimalType:
+			return zodFormattedString
+		}
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return zodNumber
+		case reflect.String:
+			return zodString
+		case reflect.Slice, reflect.Array:
+			return zodArray
+		}
+	}
+	return zodOther
+}
+
+func typeScriptType(t reflect.Type) string {
+	if t == nil {
+		return "unknown"
+	}
+
+	switch t {
+	case timeType, uuidType, decimalType:
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return typeScriptType(t.Elem()) + "[]"
+	case reflect.Pointer:
+		return typeScriptType(t.Elem())
+	}
+
+	return "unknown"
+}
+
+func zodType(t reflect.Type) string {
+	if t == nil {
+		return "z.unknown()"
+	}
+
+	switch t {
+	case timeType, decimalType:
+		return "z.string()"
+	case uuidType:
+		return "z.string().uuid()"
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "z.number().int()"
+	case reflect.Float32, reflect.Float64:
+		return "z.number()"
+	case reflect.String:
+		return "z.string()"
+	case reflect.Bool:
+		return "z.boolean()"
+	case reflect.Slice, reflect.Array:
+		return "z.array(" + zodType(t.Elem()) + ")"
+	case reflect.Pointer:
+		return zodType(t.Elem())
+	}
+
+	return "z.unknown()"
+}
+
+// writeZodParams writes the Zod refinements for the converter parameters that have a Zod equivalent for a schema of
+// kind base. Lengths are only written for strings and arrays and comparisons are only written for numbers. If nonEmpty
+// is true and there is no minLen then .min(1) is written for strings.
+func writeZodParams(sb *strings.Builder, params map[string]any, base zodBaseKind, nonEmpty bool) {
+	switch base {
+	case zodString, zodArray:
+		if n, ok := zodNumberLiteral(params["minLen"]); ok {
+			fmt.Fprintf(sb, ".min(%s)", n)
+		} else if nonEmpty && base == zodString {
+			sb.WriteString(".min(1)")
+		}
+		if n, ok := zodNumberLiteral(params["maxLen"]); ok {
+			fmt.Fprintf(sb, ".max(%s)", n)
+		}
+	case zodFormattedString:
+		if nonEmpty {
+			sb.WriteString(".min(1)")
+		}
+	case zodNumber:
+		for _, name := range []string{"lessThan", "lessThanOrEqual", "greaterThan", "greaterThanOrEqual"} {
+			if n, ok := zodNumberLiteral(params[name]); ok {
+				fmt.Fprintf(sb, ".%s(%s)", zodComparisons[name], n)
+			}
+		}
+	}
+
+	// A case insensitive set cannot be checked with includes.
+	if items, ok := params["allowStrings"].([]string); ok && params["stringMatching"] == nil && base == zodString {
+		sb.WriteString(".refine((v) => [")
+		for i, item := range items {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(jsonString(item))
+		}
+		sb.WriteString("].includes(v))")
+	}
+}
+
+// zodNumberLiteral returns v as a JavaScript number literal. It returns false if v is not a finite number.
+func zodNumberLiteral(v any) (string, bool) {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return "", false
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case decimal.Decimal:
+		return v.String(), true
+	}
+	return "", false
+}
+
+var zodComparisons = map[string]string{
+	"lessThan":           "lt",
+	"lessThanOrEqual":    "lte",
+	"greaterThan":        "gt",
+	"greaterThanOrEqual": "gte",
+}
+
+// quoteTypeScriptKey returns name unchanged if it is a valid identifier. Otherwise it returns name as a quoted string.
+func quoteTypeScriptKey(name string) string {
+	for i, r := range name {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return jsonString(name)
+	}
+
+	if name == "" {
+		return `""`
+	}
+
+	return name
+}
+
+// jsonString returns v encoded as JSON. It is used for JavaScript string and array literals because Go quoting with %q
+// produces escapes such as \x00 and \U0001F600 that have a different meaning or are invalid in JavaScript.
+func jsonString(v any) string {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(buf)
+}
@@ -0,0 +1,152 @@
+package mp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeScriptInterface(t *testing.T) {
+	addressType := mp.NewType(
+		mp.NewField("city", mp.SingleLineString(), mp.Require()),
+	)
+
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("age", mp.Int32()),
+		mp.NewField("id", mp.UUID(), mp.NotNil()),
+		mp.NewField("address", addressType),
+		mp.NewField("first-name"),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString())),
+		mp.NewField("previousAddresses", mp.Slice[*mp.Record](addressType)),
+		mp.NewField("fax", mp.SingleLineString(), mp.Deprecated("fax is no longer used")),
+	)
+
+	expected := `export interface Person {
+  name: string;
+  age?: number | null;
+  id: string;
+  address?: {
+    city: string;
+  } | null;
+  "first-name"?: unknown | null;
+  tags?: (string)[] | null;
+  previousAddresses?: ({
+    city: string;
+  })[] | null;
+  /** @deprecated fax is no longer used */
+  fax?: string | null;
+}
+`
+	assert.Equal(t, expected, mp.TypeScriptInterface("Person", ft))
+}
+
+func TestZodSchema(t *testing.T) {
+	addressType := mp.NewType(
+		mp.NewField("city", mp.SingleLineString(), mp.Require()),
+	)
+
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require(), mp.MaxLen(30)),
+		mp.NewField("age", mp.Int32(), mp.GreaterThanOrEqual(18)),
+		mp.NewField("score", mp.Float64()),
+		mp.NewField("status", mp.SingleLineString(), mp.AllowStrings("active", "inactive")),
+		mp.NewField("admin", mp.Bool()),
+		mp.NewField("verified", mp.BoolPtr()),
+		mp.NewField("id", mp.UUID(), mp.NotNil()),
+		mp.NewField("address", addressType),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString()), mp.MaxLen(3)),
+	)
+
+	expected := `export const PersonSchema = z.object({
+  name: z.string().min(1).max(30),
+  age: z.number().int().gte(18).nullish(),
+  score: z.number().nullish(),
+  status: z.string().refine((v) => ["active", "inactive"].includes(v)).nullish(),
+  admin: z.boolean().nullish(),
+  verified: z.boolean().nullish(),
+  id: z.string().uuid(),
+  address: z.object({
+    city: z.string().min(1),
+  }).nullish(),
+  tags: z.array(z.string()).max(3).nullish(),
+});
+`
+	assert.Equal(t, expected, mp.ZodSchema("PersonSchema", ft))
+}
+
+func TestZodSchemaEscapesStrings(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("a\x00b", mp.SingleLineString(), mp.AllowStrings("\u2028", "😀")),
+	)
+
+	expected := `export const S = z.object({
+  "a\u0000b": z.string().refine((v) => ["\u2028", "😀"].includes(v)).nullish(),
+});
+`
+	assert.Equal(t, expected, mp.ZodSchema("S", ft))
+}
+
+func TestZodSchemaRestAndPatterns(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+		mp.Rest(mp.SingleLineString()),
+	)
+
+	expected := `export const S = z.object({
+  name: z.string().min(1),
+}).catchall(z.string().nullish());
+`
+	assert.Equal(t, expected, mp.ZodSchema("S", ft))
+
+	ft = mp.NewType(
+		mp.NewField("name", mp.SingleLineString()),
+		mp.Pattern("custom_*", mp.Int64(), mp.Require()),
+		mp.Rest(mp.SingleLineString()),
+	)
+
+	expected = `export const S = z.object({
+  name: z.string().nullish(),
+}).catchall(z.unknown()).superRefine((v, ctx) => {
+  for (const [k, x] of Object.entries(v)) {
+    if (["name"].includes(k)) continue;
+    let s: z.ZodTypeAny | null = null;
+    if (new RegExp("^custom_.*$").test(k)) s = z.number().int(); else s = z.string().nullish();
+    if (s === null) continue;
+    const r = s.safeParse(x);
+    if (!r.success) r.error.issues.forEach((i) => ctx.addIssue({ ...i, path: [k, ...i.path] }));
+  }
+});
+`
+	assert.Equal(t, expected, mp.ZodSchema("S", ft))
+}
+
+type timeBoundConverter struct{}
+
+func (timeBoundConverter) ConvertValue(value any) (any, error) { return value, nil }
+
+func (timeBoundConverter) ConverterParams() map[string]any {
+	return map[string]any{"lessThan": time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestZodSchemaParamsMatchBaseType(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("balance", mp.Decimal(), mp.Require(), mp.LessThan(10)),
+		mp.NewField("born", mp.Time("2006-01-02"), mp.MinLen(2)),
+		mp.NewField("score", mp.Float64(), mp.LessThan(1.5), mp.MinLen(2)),
+		mp.NewField("count", mp.Int64(), timeBoundConverter{}),
+		mp.NewField("tags", mp.Slice[string](mp.SingleLineString()), mp.MinLen(1), mp.LessThan(3)),
+	)
+
+	expected := `export const S = z.object({
+  balance: z.string().min(1),
+  born: z.string().nullish(),
+  score: z.number().lt(1.5).nullish(),
+  count: z.number().int().nullish(),
+  tags: z.array(z.string()).min(1).nullish(),
+});
+`
+	assert.Equal(t, expected, mp.ZodSchema("S", ft))
+}
@@ -0,0 +1,28 @@
+package mp
+
+import "sort"
+
+// UnknownKeys returns the sorted input keys that are not the name or alias of a field of r's Type and do not match one
+// of its pattern fields. Unknown keys are ignored by Parse. They are only recorded when TypeOptions.TrackUnknownKeys or
+// TypeOptions.OnUnknownKeys is set. Otherwise, nil is returned. A Type with a Rest field has no unknown keys. The
+// returned slice must not be modified.
+func (r *Record) UnknownKeys() []string {
+	return r.unknownKeys
+}
+
+// unknownKeys returns the sorted keys of attrs that are not the name or alias of a field of t and do not match a pattern
+// field of t or nil if there are none.
+func (t *Type) unknownKeys(attrs map[string]any) []string {
+	if t.rest != nil {
+		return nil
+	}
+
+	var keys []string
+	for k := range attrs {
+		if !t.isFieldKey(k) && t.matchPattern(k) < 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,50 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordUnknownKeys(t *testing.T) {
+	fields := []mp.Field{
+		mp.NewField("name", mp.SingleLineString()),
+		mp.NewField("role", mp.Drop()),
+	}
+	attrs := map[string]any{"name": "Jack", "role": "admin", "is_admin": true, "age": 30}
+
+	record := mp.NewType(fields...).Parse(attrs)
+	require.NoError(t, record.Errors())
+	assert.Nil(t, record.UnknownKeys())
+
+	ft := mp.NewTypeWithOptions(mp.TypeOptions{TrackUnknownKeys: true}, fields...)
+	record = ft.Parse(attrs)
+	require.NoError(t, record.Errors())
+	assert.Equal(t, []string{"age", "is_admin"}, record.UnknownKeys())
+	assert.Equal(t, []string{"age", "is_admin"}, record.Clone().UnknownKeys())
+	assert.Equal(t, map[string]any{"name": "Jack"}, record.Attrs())
+
+	record = ft.Parse(map[string]any{"name": "Jack"})
+	assert.Nil(t, record.UnknownKeys())
+}
+
+func TestTypeOptionsOnUnknownKeys(t *testing.T) {
+	var calls [][]string
+	ft := mp.NewTypeWithOptions(
+		mp.TypeOptions{OnUnknownKeys: func(r *mp.Record, keys []string) { calls = append(calls, keys) }},
+		mp.NewField("name", mp.SingleLineString()),
+	)
+
+	record := ft.Parse(map[string]any{"name": "Jack"})
+	require.NoError(t, record.Errors())
+	assert.Nil(t, calls)
+
+	record = ft.Parse(map[string]any{"name": "Jack", "is_admin": true})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, []string{"is_admin"}, record.UnknownKeys())
+
+	ft.ParsePartial(map[string]any{"role": "admin"})
+	assert.Equal(t, [][]string{{"is_admin"}, {"role"}}, calls)
+}
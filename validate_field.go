@@ -0,0 +1,28 @@
+package mp
+
+import (
+	"context"
+	"fmt"
+)
+
+// ValidateField converts value with the converters of the field name and returns the error, if any. It allows a single
+// field to be validated without constructing a full input, for example when a form field loses focus. The Type's
+// StringPolicy is applied. Converters that depend on other fields (see DependsOn) are skipped because the other fields
+// are not available. ValidateField panics if name is not a field of t.
+func (t *Type) ValidateField(name string, value any) error {
+	idx, ok := t.fieldIndexes[name]
+	if !ok {
+		panic(fmt.Errorf("%q is not a field of type", name))
+	}
+	f := t.fields[idx]
+
+	value = t.stringPolicies[idx].apply(value)
+
+	if _, ok := t.dependencies[name]; !ok {
+		_, err := f.ConvertValue(value)
+		return err
+	}
+
+	_, err := convertSliceWithoutDependencies(context.Background(), value, fieldValueConverters(f))
+	return err
+}
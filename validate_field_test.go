@@ -0,0 +1,27 @@
+package mp_test
+
+import (
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeValidateField(t *testing.T) {
+	ft := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require(), mp.MaxLen(5)),
+		mp.NewField("min", mp.Int64()),
+		mp.NewField("max", mp.Int64(), mp.DependsOn([]string{"min"}, func(value any, deps map[string]any) (any, error) {
+			return mp.GreaterThanOrEqual(deps["min"]).ConvertValue(value)
+		})),
+	)
+
+	assert.NoError(t, ft.ValidateField("name", "Adam"))
+	assert.EqualError(t, ft.ValidateField("name", "Adam Smith"), "too long")
+	assert.EqualError(t, ft.ValidateField("name", nil), "cannot be nil or empty")
+
+	assert.NoError(t, ft.ValidateField("max", "5"))
+	assert.Error(t, ft.ValidateField("max", "abc"))
+
+	assert.PanicsWithError(t, `"missing" is not a field of type`, func() { ft.ValidateField("missing", 1) })
+}
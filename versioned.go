@@ -0,0 +1,84 @@
+package mp
+
+import "fmt"
+
+// TypeVersion is a version of a VersionedType.
+type TypeVersion struct {
+	Type *Type
+
+	// Upgrade converts input for the previous version into input for this version. It must not modify attrs. It is
+	// ignored for the first version.
+	Upgrade func(attrs map[string]any) (map[string]any, error)
+}
+
+// VersionedType is a sequence of versions of a Type. It allows input for any supported version to be upgraded to the
+// latest version and parsed by its Type. This lets an API accept older payloads without a separate handler for each
+// version.
+//
+// Versions are numbered from 1 in the order they were given to NewVersionedType. A VersionedType is immutable and is
+// safe for concurrent use.
+type VersionedType struct {
+	versions []TypeVersion
+}
+
+// NewVersionedType returns a VersionedType with versions. Every version after the first must have an Upgrade function.
+func NewVersionedType(versions ...TypeVersion) *VersionedType {
+	if len(versions) == 0 {
+		panic("NewVersionedType requires at least one version")
+	}
+	for i, v := range versions {
+		if v.Type == nil {
+			panic(fmt.Errorf("version %d has no Type", i+1))
+		}
+		if i > 0 && v.Upgrade == nil {
+			panic(fmt.Errorf("version %d has no Upgrade", i+1))
+		}
+	}
+
+	return &VersionedType{versions: append([]TypeVersion(nil), versions...)}
+}
+
+// Latest returns the latest version number.
+func (vt *VersionedType) Latest() int {
+	return len(vt.versions)
+}
+
+// Type returns the Type of version. It returns nil if version is not supported.
+func (vt *VersionedType) Type(version int) *Type {
+	if version < 1 || version > len(vt.versions) {
+		return nil
+	}
+	return vt.versions[version-1].Type
+}
+
+// Upgrade upgrades attrs from version to the latest version.
+func (vt *VersionedType) Upgrade(version int, attrs map[string]any) (map[string]any, error) {
+	if version < 1 || version > len(vt.versions) {
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+
+	for i := version; i < len(vt.versions); i++ {
+		var err error
+		attrs, err = vt.versions[i].Upgrade(attrs)
+		if err != nil {
+			return nil, fmt.Errorf("upgrade to version %d: %w", i+1, err)
+		}
+	}
+
+	return attrs, nil
+}
+
+// Parse upgrades attrs from version to the latest version and parses the result with the latest Type. If the upgrade
+// fails the error is added to the returned Record under BaseErrorKey.
+func (vt *VersionedType) Parse(version int, attrs map[string]any) *Record {
+	latest := vt.versions[len(vt.versions)-1].Type
+
+	upgraded, err := vt.Upgrade(version, attrs)
+	if err != nil {
+		r := &Record{t: latest, original: attrs, values: make([]fieldValue, len(latest.fields))}
+		r.addError(err)
+		return r
+	}
+
+	return latest.Parse(upgraded)
+}
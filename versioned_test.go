@@ -0,0 +1,57 @@
+package mp_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/mp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedType(t *testing.T) {
+	v1 := mp.NewType(
+		mp.NewField("name", mp.SingleLineString(), mp.Require()),
+	)
+	v2 := mp.NewType(
+		mp.NewField("first_name", mp.SingleLineString(), mp.Require()),
+		mp.NewField("last_name", mp.SingleLineString(), mp.Require()),
+	)
+
+	vt := mp.NewVersionedType(
+		mp.TypeVersion{Type: v1},
+		mp.TypeVersion{Type: v2, Upgrade: func(attrs map[string]any) (map[string]any, error) {
+			name, _ := attrs["name"].(string)
+			first, last, ok := strings.Cut(name, " ")
+			if !ok {
+				return nil, errors.New("name must include a first and last name")
+			}
+			return map[string]any{"first_name": first, "last_name": last}, nil
+		}},
+	)
+
+	assert.Equal(t, 2, vt.Latest())
+	assert.Same(t, v1, vt.Type(1))
+	assert.Nil(t, vt.Type(3))
+
+	record := vt.Parse(1, map[string]any{"name": "Adam Smith"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Adam", record.Get("first_name"))
+	assert.Equal(t, "Smith", record.Get("last_name"))
+
+	record = vt.Parse(2, map[string]any{"first_name": "Bob", "last_name": "Jones"})
+	require.NoError(t, record.Errors())
+	assert.Equal(t, "Bob", record.Get("first_name"))
+
+	record = vt.Parse(1, map[string]any{"name": "Adam"})
+	assert.EqualError(t, record.Errors(), "base upgrade to version 2: name must include a first and last name")
+
+	record = vt.Parse(3, map[string]any{})
+	assert.EqualError(t, record.Errors(), "base unsupported version 3")
+}
+
+func TestNewVersionedTypeRequiresUpgrade(t *testing.T) {
+	ft := mp.NewType(mp.NewField("name"))
+	assert.Panics(t, func() { mp.NewVersionedType(mp.TypeVersion{Type: ft}, mp.TypeVersion{Type: ft}) })
+}